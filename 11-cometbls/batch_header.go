@@ -0,0 +1,62 @@
+package cometbls
+
+import (
+	errorsmod "cosmossdk.io/errors"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+var _ exported.ClientMessage = (*BatchHeader)(nil)
+
+const (
+	// MaxBatchHeaders bounds the number of headers of a batch, hence the
+	// number of proofs verified by a single message.
+	MaxBatchHeaders = 32
+	// BatchHeaderVerificationGas is consumed for each header of a batch,
+	// which is verified as if it had been submitted on its own while the
+	// transaction overhead is only paid once.
+	BatchHeaderVerificationGas = 100_000
+)
+
+// NewBatchHeader creates a new BatchHeader instance.
+func NewBatchHeader(headers ...*Header) *BatchHeader {
+	return &BatchHeader{
+		Headers: headers,
+	}
+}
+
+// ClientType defines that the BatchHeader is a CometBLS client message
+func (BatchHeader) ClientType() string {
+	return ClientType
+}
+
+// ValidateBasic checks that the batch is neither empty nor too large, that
+// each header is valid and that no two headers are at the same height.
+func (b BatchHeader) ValidateBasic() error {
+	if len(b.Headers) == 0 {
+		return errorsmod.Wrap(clienttypes.ErrInvalidHeader, "batch cannot be empty")
+	}
+	if len(b.Headers) > MaxBatchHeaders {
+		return errorsmod.Wrapf(clienttypes.ErrInvalidHeader, "batch cannot have more than %d headers, got %d", MaxBatchHeaders, len(b.Headers))
+	}
+
+	heights := make(map[exported.Height]bool, len(b.Headers))
+	for i, header := range b.Headers {
+		if header == nil {
+			return errorsmod.Wrapf(clienttypes.ErrInvalidHeader, "header %d of the batch cannot be nil", i)
+		}
+		if header.TrustedHeight == nil {
+			return errorsmod.Wrapf(ErrInvalidHeaderHeight, "header %d of the batch cannot have a nil trusted height", i)
+		}
+		if err := header.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "header %d of the batch failed validation", i)
+		}
+		if heights[header.GetHeight()] {
+			return errorsmod.Wrapf(clienttypes.ErrInvalidHeader, "header %d of the batch is at the height %s of an earlier header", i, header.GetHeight())
+		}
+		heights[header.GetHeight()] = true
+	}
+
+	return nil
+}
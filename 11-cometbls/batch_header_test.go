@@ -0,0 +1,177 @@
+package cometbls
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+// A header at the given height, trusting the given one, with a copy of the
+// light header of the original.
+func chainedHeader(header *Header, trustedHeight clienttypes.Height, height int64, blockTime time.Time) *Header {
+	signedHeader := *header.SignedHeader
+	signedHeader.Height = height
+	signedHeader.Time = blockTime
+	return &Header{
+		SignedHeader:       &signedHeader,
+		TrustedHeight:      &trustedHeight,
+		ZeroKnowledgeProof: header.ZeroKnowledgeProof,
+	}
+}
+
+func TestBatchHeaderValidateBasic(t *testing.T) {
+	_, _, _, header := newTestClient(t)
+	height := header.GetHeight().(clienttypes.Height)
+	next := chainedHeader(header, height, header.SignedHeader.Height+1, header.GetTime().Add(time.Second))
+
+	tooMany := make([]*Header, MaxBatchHeaders+1)
+	for i := range tooMany {
+		tooMany[i] = chainedHeader(header, *header.TrustedHeight, header.SignedHeader.Height+int64(i), header.GetTime())
+	}
+	require.NoError(t, NewBatchHeader(tooMany[:MaxBatchHeaders]...).ValidateBasic())
+
+	tests := []struct {
+		description string
+		batch       *BatchHeader
+		message     string
+	}{
+		{"empty", NewBatchHeader(), "batch cannot be empty"},
+		{"too many headers", NewBatchHeader(tooMany...), "batch cannot have more than 32 headers, got 33"},
+		{"nil header", NewBatchHeader(header, nil), "header 1 of the batch cannot be nil"},
+		{"nil trusted height", NewBatchHeader(&Header{SignedHeader: header.SignedHeader}), "header 0 of the batch cannot have a nil trusted height"},
+		{"invalid header", NewBatchHeader(chainedHeader(header, height, int64(height.RevisionHeight), header.GetTime())), "header 0 of the batch failed validation"},
+		{"duplicate height", NewBatchHeader(header, next, header), "header 2 of the batch is at the height"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			require.ErrorContains(t, tt.batch.ValidateBasic(), tt.message)
+		})
+	}
+
+	require.NoError(t, NewBatchHeader(header, next).ValidateBasic())
+}
+
+func TestVerifyBatchHeader(t *testing.T) {
+	cs, clientStore, cdc, header := newTestClient(t)
+	blockTime := header.GetTime().Add(time.Minute)
+	height := header.GetHeight().(clienttypes.Height)
+
+	t.Run("gas per header", func(t *testing.T) {
+		ctx := atTime(blockTime).WithGasMeter(storetypes.NewInfiniteGasMeter())
+		require.NoError(t, cs.VerifyClientMessage(ctx, cdc, clientStore, NewBatchHeader(header)))
+		require.Equal(t, storetypes.Gas(BatchHeaderVerificationGas), ctx.GasMeter().GasConsumed())
+
+		ctx = atTime(blockTime).WithGasMeter(storetypes.NewGasMeter(BatchHeaderVerificationGas + BatchHeaderVerificationGas/2))
+		next := chainedHeader(header, height, header.SignedHeader.Height+1, header.GetTime().Add(time.Second))
+		require.PanicsWithValue(t, storetypes.ErrorOutOfGas{Descriptor: "verify 11-cometbls batch header"}, func() {
+			_ = cs.VerifyClientMessage(ctx, cdc, clientStore, NewBatchHeader(header, next))
+		})
+	})
+
+	t.Run("trusting an earlier header of the batch", func(t *testing.T) {
+		ctx := atTime(blockTime).WithGasMeter(storetypes.NewInfiniteGasMeter())
+		// Only the consensus state of the first header has this timestamp
+		next := chainedHeader(header, height, header.SignedHeader.Height+1, header.GetTime())
+		err := cs.VerifyClientMessage(ctx, cdc, clientStore, NewBatchHeader(header, next))
+		require.ErrorIs(t, err, ErrInvalidHeaderTimestamp)
+		require.ErrorContains(t, err, "verifying header 1 of the batch failed")
+		require.Equal(t, storetypes.Gas(2*BatchHeaderVerificationGas), ctx.GasMeter().GasConsumed())
+
+		// The proof of the first header doesn't prove another one
+		next = chainedHeader(header, height, header.SignedHeader.Height+1, header.GetTime().Add(time.Second))
+		err = cs.VerifyClientMessage(ctx, cdc, clientStore, NewBatchHeader(header, next))
+		require.ErrorContains(t, err, "verifying header 1 of the batch failed")
+	})
+
+	t.Run("trusting a later header of the batch", func(t *testing.T) {
+		ctx := atTime(blockTime).WithGasMeter(storetypes.NewInfiniteGasMeter())
+		previous := chainedHeader(header, height, header.SignedHeader.Height+1, header.GetTime().Add(time.Second))
+		err := cs.VerifyClientMessage(ctx, cdc, clientStore, NewBatchHeader(previous, header))
+		require.ErrorIs(t, err, clienttypes.ErrConsensusStateNotFound)
+		require.ErrorContains(t, err, "header 0 of the batch")
+	})
+
+	t.Run("invalid batch", func(t *testing.T) {
+		ctx := atTime(blockTime).WithGasMeter(storetypes.NewInfiniteGasMeter())
+		err := cs.VerifyClientMessage(ctx, cdc, clientStore, NewBatchHeader())
+		require.ErrorIs(t, err, clienttypes.ErrInvalidHeader)
+		require.Zero(t, ctx.GasMeter().GasConsumed())
+	})
+}
+
+func TestBatchHeaderUpdateState(t *testing.T) {
+	cs, clientStore, cdc, header := newTestClient(t)
+	ctx := atTime(header.GetTime().Add(time.Minute))
+	trustedHeight := *header.TrustedHeight
+	height := header.GetHeight().(clienttypes.Height)
+
+	// A skipped height, the trusted one and the latest one trusting the first
+	skipped := chainedHeader(header, trustedHeight, header.SignedHeader.Height-1, header.GetTime().Add(-time.Second))
+	trusted, found := GetConsensusState(clientStore, cdc, trustedHeight)
+	require.True(t, found)
+	latest := chainedHeader(header, height, header.SignedHeader.Height+10, header.GetTime().Add(10*time.Second))
+	batch := NewBatchHeader(header, skipped, latest)
+
+	require.False(t, cs.CheckForMisbehaviour(ctx, cdc, clientStore, batch))
+	heights := cs.UpdateState(ctx, cdc, clientStore, batch)
+	require.Equal(t, []exported.Height{height, skipped.GetHeight(), latest.GetHeight()}, heights)
+
+	clientState := storedClientState(t, clientStore, cdc)
+	require.Equal(t, latest.GetHeight(), clientState.GetLatestHeight())
+	for _, h := range batch.Headers {
+		consState, found := GetConsensusState(clientStore, cdc, h.GetHeight())
+		require.True(t, found)
+		require.Equal(t, h.ConsensusState(), consState)
+	}
+	consState, found := GetConsensusState(clientStore, cdc, trustedHeight)
+	require.True(t, found)
+	require.Equal(t, trusted, consState)
+
+	// Submitted again, nothing changes
+	require.False(t, cs.CheckForMisbehaviour(ctx, cdc, clientStore, batch))
+	require.Equal(t, heights, clientState.UpdateState(ctx, cdc, clientStore, batch))
+}
+
+func TestBatchHeaderCheckForMisbehaviour(t *testing.T) {
+	cs, clientStore, cdc, header := newTestClient(t)
+	ctx := atTime(header.GetTime().Add(time.Minute))
+	height := header.GetHeight().(clienttypes.Height)
+	next := func(delta int64, blockTime time.Time) *Header {
+		return chainedHeader(header, height, header.SignedHeader.Height+delta, blockTime)
+	}
+
+	tests := []struct {
+		description  string
+		batch        *BatchHeader
+		misbehaviour bool
+	}{
+		{"monotonic", NewBatchHeader(header, next(1, header.GetTime().Add(time.Second)), next(2, header.GetTime().Add(2*time.Second))), false},
+		{"unordered but monotonic", NewBatchHeader(next(2, header.GetTime().Add(2*time.Second)), header, next(1, header.GetTime().Add(time.Second))), false},
+		{"same time within the batch", NewBatchHeader(header, next(1, header.GetTime())), true},
+		{"time going back within the batch", NewBatchHeader(header, next(2, header.GetTime().Add(2*time.Second)), next(1, header.GetTime().Add(3*time.Second))), true},
+		{"time going back from the trusted consensus state", NewBatchHeader(chainedHeader(header, *header.TrustedHeight, header.SignedHeader.Height, header.GetTime().Add(-time.Hour))), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			require.Equal(t, tt.misbehaviour, cs.CheckForMisbehaviour(ctx, cdc, clientStore, tt.batch))
+		})
+	}
+
+	t.Run("conflicting with a stored consensus state", func(t *testing.T) {
+		cs.UpdateState(ctx, cdc, clientStore, header)
+		conflicting := *header
+		signedHeader := *header.SignedHeader
+		signedHeader.AppHash = []byte("conflicting")
+		conflicting.SignedHeader = &signedHeader
+		require.False(t, cs.CheckForMisbehaviour(ctx, cdc, clientStore, NewBatchHeader(header)))
+		require.True(t, cs.CheckForMisbehaviour(ctx, cdc, clientStore, NewBatchHeader(next(1, header.GetTime().Add(time.Second)), &conflicting)))
+	})
+}
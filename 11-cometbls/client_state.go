@@ -1,12 +1,17 @@
 package cometbls
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"strings"
 
+	backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	plonk_bn254 "github.com/consensys/gnark/backend/plonk/bn254"
 	ics23 "github.com/cosmos/ics23/go"
 
 	errorsmod "cosmossdk.io/errors"
 	storetypes "cosmossdk.io/store/types"
+	upgradetypes "cosmossdk.io/x/upgrade/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -26,22 +31,109 @@ func NewClientState(
 	chainID string,
 	trustingPeriod, ubdPeriod, maxClockDrift uint64,
 	latestHeight clienttypes.Height,
+	verifyingKey, plonkVerifyingKey []byte, upgradePath []string,
 ) *ClientState {
 	return &ClientState{
-		ChainId:         chainID,
-		TrustingPeriod:  trustingPeriod,
-		UnbondingPeriod: ubdPeriod,
-		MaxClockDrift:   maxClockDrift,
-		LatestHeight:    latestHeight,
-		FrozenHeight:    clienttypes.ZeroHeight(),
+		ChainId:                      chainID,
+		TrustingPeriod:               trustingPeriod,
+		UnbondingPeriod:              ubdPeriod,
+		MaxClockDrift:                maxClockDrift,
+		LatestHeight:                 latestHeight,
+		FrozenHeight:                 clienttypes.ZeroHeight(),
+		VerifyingKeyFingerprint:      fingerprintOf(verifyingKey),
+		UpgradePath:                  upgradePath,
+		VerifyingKey:                 verifyingKey,
+		PlonkVerifyingKeyFingerprint: fingerprintOf(plonkVerifyingKey),
+		PlonkVerifyingKey:            plonkVerifyingKey,
 	}
 }
 
+// The sha256 of the given key encoding, nil for a missing key.
+func fingerprintOf(verifyingKey []byte) []byte {
+	if len(verifyingKey) == 0 {
+		return nil
+	}
+	fingerprint := sha256.Sum256(verifyingKey)
+	return fingerprint[:]
+}
+
 // GetChainID returns the chain-id
 func (cs ClientState) GetChainID() string {
 	return cs.ChainId
 }
 
+// GetVerifyingKeyFingerprint returns the fingerprint of the verifying key the
+// headers are verified with, the embedded key if the client doesn't name one.
+func (cs ClientState) GetVerifyingKeyFingerprint() []byte {
+	if len(cs.VerifyingKeyFingerprint) == 0 {
+		return DefaultVerifyingKeyFingerprint
+	}
+	return cs.VerifyingKeyFingerprint
+}
+
+// GetVerifyingKey returns the verifying key the headers are verified with,
+// read from the client store under its fingerprint. The embedded key if the
+// client doesn't name another one.
+func (cs ClientState) GetVerifyingKey(clientStore storetypes.KVStore) (*backend_bn254.VerifyingKey, error) {
+	fingerprint := cs.GetVerifyingKeyFingerprint()
+	if bytes.Equal(fingerprint, DefaultVerifyingKeyFingerprint) {
+		return defaultVerifyingKey, nil
+	}
+	data, found := getVerifyingKey(clientStore, KeyVerifyingKeyPrefix, fingerprint)
+	if !found {
+		return nil, errorsmod.Wrapf(ErrUnknownVerifyingKey, "the verifying key of fingerprint %X isn't in the client store", fingerprint)
+	}
+	return ParseVerifyingKey(data)
+}
+
+// GetPlonkVerifyingKey returns the verifying key the PLONK proofs are verified
+// with, read from the client store under its fingerprint. PLONK proofs are
+// rejected if the client doesn't name one.
+func (cs ClientState) GetPlonkVerifyingKey(clientStore storetypes.KVStore) (*plonk_bn254.VerifyingKey, error) {
+	if len(cs.PlonkVerifyingKeyFingerprint) == 0 {
+		return nil, errorsmod.Wrap(ErrUnknownVerifyingKey, "the client doesn't trust a plonk verifying key")
+	}
+	data, found := getVerifyingKey(clientStore, KeyPlonkVerifyingKeyPrefix, cs.PlonkVerifyingKeyFingerprint)
+	if !found {
+		return nil, errorsmod.Wrapf(ErrUnknownVerifyingKey, "the plonk verifying key of fingerprint %X isn't in the client store", cs.PlonkVerifyingKeyFingerprint)
+	}
+	return ParsePlonkVerifyingKey(data)
+}
+
+// storeVerifyingKeys moves the verifying keys carried by the client state,
+// when creating, upgrading or substituting the client, to the client store.
+// The stored client state only names them by their fingerprint, the keys it
+// names without carrying them must already be in the client store.
+func (cs *ClientState) storeVerifyingKeys(clientStore storetypes.KVStore) error {
+	// the embedded key is never stored
+	if bytes.Equal(cs.GetVerifyingKeyFingerprint(), DefaultVerifyingKeyFingerprint) {
+		cs.VerifyingKey = nil
+	} else if len(cs.VerifyingKey) != 0 {
+		setVerifyingKey(clientStore, KeyVerifyingKeyPrefix, cs.VerifyingKey)
+		cs.VerifyingKey = nil
+	} else if _, found := getVerifyingKey(clientStore, KeyVerifyingKeyPrefix, cs.VerifyingKeyFingerprint); !found {
+		return errorsmod.Wrapf(ErrUnknownVerifyingKey, "the client must carry the verifying key of fingerprint %X", cs.VerifyingKeyFingerprint)
+	}
+	if len(cs.PlonkVerifyingKey) != 0 {
+		setVerifyingKey(clientStore, KeyPlonkVerifyingKeyPrefix, cs.PlonkVerifyingKey)
+		cs.PlonkVerifyingKey = nil
+	} else if len(cs.PlonkVerifyingKeyFingerprint) != 0 {
+		if _, found := getVerifyingKey(clientStore, KeyPlonkVerifyingKeyPrefix, cs.PlonkVerifyingKeyFingerprint); !found {
+			return errorsmod.Wrapf(ErrUnknownVerifyingKey, "the client must carry the plonk verifying key of fingerprint %X", cs.PlonkVerifyingKeyFingerprint)
+		}
+	}
+	return nil
+}
+
+// GetUpgradePath returns the path the upgraded client and consensus state are
+// committed under, the standard path of the cosmos-sdk if the client doesn't set one.
+func (cs ClientState) GetUpgradePath() []string {
+	if len(cs.UpgradePath) == 0 {
+		return []string{upgradetypes.StoreKey, upgradetypes.KeyUpgradedIBCState}
+	}
+	return cs.UpgradePath
+}
+
 // ClientType is tendermint.
 func (ClientState) ClientType() string {
 	return ClientType
@@ -144,7 +236,56 @@ func (cs ClientState) Validate() error {
 			"trusting period (%d) should be < unbonding period (%d)", cs.TrustingPeriod, cs.UnbondingPeriod,
 		)
 	}
+	for i, k := range cs.UpgradePath {
+		if strings.TrimSpace(k) == "" {
+			return errorsmod.Wrapf(clienttypes.ErrInvalidClient, "key in upgrade path at index %d cannot be empty", i)
+		}
+	}
+	if len(cs.VerifyingKey) == 0 {
+		// the key named without being carried is the embedded one or looked up
+		// in the client store
+		if len(cs.VerifyingKeyFingerprint) != 0 && len(cs.VerifyingKeyFingerprint) != VerifyingKeyFingerprintSize {
+			return errorsmod.Wrapf(
+				ErrInvalidVerifyingKey,
+				"verifying key fingerprint must be %d bytes, got %d", VerifyingKeyFingerprintSize, len(cs.VerifyingKeyFingerprint),
+			)
+		}
+	} else {
+		if _, err := ParseVerifyingKey(cs.VerifyingKey); err != nil {
+			return err
+		}
+		if err := checkFingerprint(cs.VerifyingKey, cs.VerifyingKeyFingerprint); err != nil {
+			return err
+		}
+	}
+	if len(cs.PlonkVerifyingKey) == 0 {
+		// the key named without being carried is looked up in the client store
+		if len(cs.PlonkVerifyingKeyFingerprint) != 0 && len(cs.PlonkVerifyingKeyFingerprint) != VerifyingKeyFingerprintSize {
+			return errorsmod.Wrapf(
+				ErrInvalidVerifyingKey,
+				"plonk verifying key fingerprint must be %d bytes, got %d", VerifyingKeyFingerprintSize, len(cs.PlonkVerifyingKeyFingerprint),
+			)
+		}
+	} else {
+		if _, err := ParsePlonkVerifyingKey(cs.PlonkVerifyingKey); err != nil {
+			return err
+		}
+		if err := checkFingerprint(cs.PlonkVerifyingKey, cs.PlonkVerifyingKeyFingerprint); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
+func checkFingerprint(verifyingKey, expected []byte) error {
+	fingerprint := fingerprintOf(verifyingKey)
+	if !bytes.Equal(expected, fingerprint) {
+		return errorsmod.Wrapf(
+			ErrInvalidVerifyingKey,
+			"verifying key fingerprint %X doesn't match the fingerprint %X of the verifying key", expected, fingerprint,
+		)
+	}
 	return nil
 }
 
@@ -154,9 +295,14 @@ func (cs ClientState) ZeroCustomFields() exported.ClientState {
 	// copy over all chain-specified fields
 	// and leave custom fields empty
 	return &ClientState{
-		ChainId:         cs.ChainId,
-		UnbondingPeriod: cs.UnbondingPeriod,
-		LatestHeight:    cs.LatestHeight,
+		ChainId:                      cs.ChainId,
+		UnbondingPeriod:              cs.UnbondingPeriod,
+		LatestHeight:                 cs.LatestHeight,
+		VerifyingKeyFingerprint:      cs.VerifyingKeyFingerprint,
+		UpgradePath:                  cs.UpgradePath,
+		VerifyingKey:                 cs.VerifyingKey,
+		PlonkVerifyingKeyFingerprint: cs.PlonkVerifyingKeyFingerprint,
+		PlonkVerifyingKey:            cs.PlonkVerifyingKey,
 	}
 }
 
@@ -169,6 +315,9 @@ func (cs ClientState) Initialize(ctx sdk.Context, cdc codec.BinaryCodec, clientS
 			&ConsensusState{}, consState)
 	}
 
+	if err := cs.storeVerifyingKeys(clientStore); err != nil {
+		return err
+	}
 	setClientState(clientStore, cdc, &cs)
 	setConsensusState(clientStore, cdc, consensusState, cs.GetLatestHeight())
 	setConsensusMetadata(ctx, clientStore, cs.GetLatestHeight())
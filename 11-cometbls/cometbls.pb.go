@@ -30,6 +30,32 @@ var _ = time.Kitchen
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// The proving system the zero knowledge proof of a header was produced with.
+type ProofType int32
+
+const (
+	ProofTypeGroth16 ProofType = 0
+	ProofTypePlonk   ProofType = 1
+)
+
+var ProofType_name = map[int32]string{
+	0: "PROOF_TYPE_GROTH16",
+	1: "PROOF_TYPE_PLONK",
+}
+
+var ProofType_value = map[string]int32{
+	"PROOF_TYPE_GROTH16": 0,
+	"PROOF_TYPE_PLONK":   1,
+}
+
+func (x ProofType) String() string {
+	return proto.EnumName(ProofType_name, int32(x))
+}
+
+func (ProofType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_6e4c33c744877a4e, []int{0}
+}
+
 type ClientState struct {
 	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	// duration of the period since the LastestTimestamp during which the
@@ -43,6 +69,30 @@ type ClientState struct {
 	FrozenHeight types.Height `protobuf:"bytes,5,opt,name=frozen_height,json=frozenHeight,proto3" json:"frozen_height"`
 	// Latest height the client was updated to
 	LatestHeight types.Height `protobuf:"bytes,6,opt,name=latest_height,json=latestHeight,proto3" json:"latest_height"`
+	// Fingerprint of the groth16 verifying key the client trusts, the sha256 of
+	// its compressed encoding. The key embedded in the client if empty
+	VerifyingKeyFingerprint []byte `protobuf:"bytes,7,opt,name=verifying_key_fingerprint,json=verifyingKeyFingerprint,proto3" json:"verifying_key_fingerprint,omitempty"`
+	// Path at which next upgraded client will be committed.
+	// Each element corresponds to the key for a single CommitmentProof in the
+	// chained proof. NOTE: ClientState must stored under
+	// `{upgradePath}/{upgradeHeight}/clientState` ConsensusState must be stored
+	// under `{upgradepath}/{upgradeHeight}/consensusState`, the standard upgrade
+	// path of the cosmos-sdk if empty
+	UpgradePath []string `protobuf:"bytes,8,rep,name=upgrade_path,json=upgradePath,proto3" json:"upgrade_path,omitempty"`
+	// Compressed encoding of the groth16 verifying key the client trusts, as
+	// exported by galoisd. Only carried when creating, upgrading or substituting
+	// the client: the key is then stored once in the client store under its
+	// fingerprint, the stored client state only names it. The key embedded in
+	// the client is never stored
+	VerifyingKey []byte `protobuf:"bytes,9,opt,name=verifying_key,json=verifyingKey,proto3" json:"verifying_key,omitempty"`
+	// Fingerprint of the PLONK verifying key the client trusts, the sha256 of
+	// its encoding. PLONK proofs are rejected if empty
+	PlonkVerifyingKeyFingerprint []byte `protobuf:"bytes,10,opt,name=plonk_verifying_key_fingerprint,json=plonkVerifyingKeyFingerprint,proto3" json:"plonk_verifying_key_fingerprint,omitempty"`
+	// Encoding of the PLONK verifying key the client trusts, as exported by
+	// galoisd for the plonk backend. Only carried when creating, upgrading or
+	// substituting the client: the key is then stored once in the client store
+	// under its fingerprint, the stored client state only names it
+	PlonkVerifyingKey []byte `protobuf:"bytes,11,opt,name=plonk_verifying_key,json=plonkVerifyingKey,proto3" json:"plonk_verifying_key,omitempty"`
 }
 
 func (m *ClientState) Reset()         { *m = ClientState{} }
@@ -249,9 +299,14 @@ func (m *LightHeader) GetAppHash() []byte {
 }
 
 type Header struct {
-	SignedHeader       *LightHeader  `protobuf:"bytes,1,opt,name=signed_header,json=signedHeader,proto3" json:"signed_header,omitempty"`
-	TrustedHeight      *types.Height `protobuf:"bytes,2,opt,name=trusted_height,json=trustedHeight,proto3" json:"trusted_height,omitempty"`
-	ZeroKnowledgeProof []byte        `protobuf:"bytes,3,opt,name=zero_knowledge_proof,json=zeroKnowledgeProof,proto3" json:"zero_knowledge_proof,omitempty"`
+	SignedHeader  *LightHeader  `protobuf:"bytes,1,opt,name=signed_header,json=signedHeader,proto3" json:"signed_header,omitempty"`
+	TrustedHeight *types.Height `protobuf:"bytes,2,opt,name=trusted_height,json=trustedHeight,proto3" json:"trusted_height,omitempty"`
+	// The EVM encoding of a groth16 proof (`evm_proof` of galoisd), or the raw
+	// gnark encoding of a PLONK proof (`content` of galoisd). The Solidity
+	// encoding of PLONK proofs can't be used, it omits the claimed value of the
+	// linearized polynomial the gnark verifier checks
+	ZeroKnowledgeProof []byte    `protobuf:"bytes,3,opt,name=zero_knowledge_proof,json=zeroKnowledgeProof,proto3" json:"zero_knowledge_proof,omitempty"`
+	ProofType          ProofType `protobuf:"varint,4,opt,name=proof_type,json=proofType,proto3,enum=union.ibc.lightclients.cometbls.v1.ProofType" json:"proof_type,omitempty"`
 }
 
 func (m *Header) Reset()         { *m = Header{} }
@@ -308,12 +363,67 @@ func (m *Header) GetZeroKnowledgeProof() []byte {
 	return nil
 }
 
+func (m *Header) GetProofType() ProofType {
+	if m != nil {
+		return m.ProofType
+	}
+	return ProofTypeGroth16
+}
+
+// Headers updating the client at once, each trusting a height the client
+// already has a consensus state for or the height of an earlier header.
+type BatchHeader struct {
+	Headers []*Header `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+}
+
+func (m *BatchHeader) Reset()         { *m = BatchHeader{} }
+func (m *BatchHeader) String() string { return proto.CompactTextString(m) }
+func (*BatchHeader) ProtoMessage()    {}
+func (*BatchHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6e4c33c744877a4e, []int{5}
+}
+func (m *BatchHeader) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchHeader.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchHeader.Merge(m, src)
+}
+func (m *BatchHeader) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchHeader proto.InternalMessageInfo
+
+func (m *BatchHeader) GetHeaders() []*Header {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("union.ibc.lightclients.cometbls.v1.ProofType", ProofType_name, ProofType_value)
 	proto.RegisterType((*ClientState)(nil), "union.ibc.lightclients.cometbls.v1.ClientState")
 	proto.RegisterType((*ConsensusState)(nil), "union.ibc.lightclients.cometbls.v1.ConsensusState")
 	proto.RegisterType((*Misbehaviour)(nil), "union.ibc.lightclients.cometbls.v1.Misbehaviour")
 	proto.RegisterType((*LightHeader)(nil), "union.ibc.lightclients.cometbls.v1.LightHeader")
 	proto.RegisterType((*Header)(nil), "union.ibc.lightclients.cometbls.v1.Header")
+	proto.RegisterType((*BatchHeader)(nil), "union.ibc.lightclients.cometbls.v1.BatchHeader")
 }
 
 func init() {
@@ -321,49 +431,63 @@ func init() {
 }
 
 var fileDescriptor_6e4c33c744877a4e = []byte{
-	// 665 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcf, 0x4e, 0xd4, 0x40,
-	0x1c, 0xde, 0x42, 0x59, 0x60, 0x76, 0x17, 0x4c, 0x43, 0xcc, 0xba, 0x31, 0xbb, 0x64, 0x0f, 0x82,
-	0x1e, 0x5a, 0xba, 0x5e, 0xd4, 0x78, 0x11, 0x24, 0xc1, 0x28, 0x09, 0xa9, 0xc4, 0x83, 0x97, 0x66,
-	0xda, 0xce, 0xb6, 0x13, 0xda, 0x99, 0x66, 0x66, 0xb6, 0x12, 0x9e, 0xc0, 0x23, 0x0f, 0xe0, 0xc1,
-	0x83, 0x0f, 0xe1, 0x23, 0x70, 0x24, 0x31, 0x26, 0x9e, 0xd4, 0xc0, 0x8b, 0x98, 0xf9, 0xd3, 0x65,
-	0x4d, 0x24, 0x10, 0x6f, 0xd3, 0xef, 0xf7, 0x7d, 0xdf, 0x6f, 0xe6, 0x9b, 0xf9, 0x15, 0xf8, 0x13,
-	0x82, 0x29, 0xf1, 0x70, 0x14, 0x7b, 0x39, 0x4e, 0x33, 0x11, 0xe7, 0x18, 0x11, 0xc1, 0xbd, 0x98,
-	0x16, 0x48, 0x44, 0x39, 0xf7, 0x2a, 0x7f, 0xba, 0x76, 0x4b, 0x46, 0x05, 0x75, 0x86, 0x4a, 0xe2,
-	0xe2, 0x28, 0x76, 0x67, 0x25, 0xee, 0x94, 0x56, 0xf9, 0xbd, 0x41, 0x4a, 0x69, 0x9a, 0x23, 0x4f,
-	0x29, 0xa2, 0xc9, 0xd8, 0x13, 0xb8, 0x40, 0x5c, 0xc0, 0xa2, 0xd4, 0x26, 0xbd, 0x81, 0xec, 0x18,
-	0x53, 0x86, 0x3c, 0x2d, 0x57, 0x7d, 0xd4, 0xca, 0x10, 0x36, 0xae, 0x08, 0xb4, 0x28, 0xb0, 0x28,
-	0x6a, 0xd2, 0xf4, 0xcb, 0x10, 0xd7, 0x52, 0x9a, 0x52, 0xb5, 0xf4, 0xe4, 0x4a, 0xa3, 0xc3, 0xaf,
-	0x73, 0xa0, 0xb5, 0xa3, 0xfc, 0xde, 0x0a, 0x28, 0x90, 0x73, 0x0f, 0x2c, 0xc5, 0x19, 0xc4, 0x24,
-	0xc4, 0x49, 0xd7, 0x5a, 0xb7, 0x36, 0x97, 0x83, 0x45, 0xf5, 0xfd, 0x2a, 0x71, 0x36, 0xc0, 0xaa,
-	0x60, 0x13, 0x2e, 0x30, 0x49, 0xc3, 0x12, 0x31, 0x4c, 0x93, 0xee, 0xdc, 0xba, 0xb5, 0x69, 0x07,
-	0x2b, 0x35, 0x7c, 0xa0, 0x50, 0xe7, 0x21, 0xb8, 0x33, 0x21, 0x11, 0x25, 0xc9, 0x0c, 0x73, 0x5e,
-	0x31, 0x57, 0xa7, 0xb8, 0xa1, 0x3e, 0x00, 0xab, 0x05, 0x3c, 0x0e, 0xe3, 0x9c, 0xc6, 0x47, 0x61,
-	0xc2, 0xf0, 0x58, 0x74, 0x6d, 0xc5, 0xec, 0x14, 0xf0, 0x78, 0x47, 0xa2, 0x2f, 0x25, 0xe8, 0xec,
-	0x82, 0xce, 0x98, 0xd1, 0x13, 0x44, 0xc2, 0x0c, 0xc9, 0x2c, 0xbb, 0x0b, 0xeb, 0xd6, 0x66, 0x6b,
-	0xd4, 0x53, 0xe9, 0xca, 0xd3, 0xbb, 0x26, 0x94, 0xca, 0x77, 0xf7, 0x14, 0x63, 0xdb, 0x3e, 0xfb,
-	0x39, 0x68, 0x04, 0x6d, 0x2d, 0xd3, 0x98, 0xb4, 0xc9, 0xa1, 0x40, 0x5c, 0xd4, 0x36, 0xcd, 0xdb,
-	0xda, 0x68, 0x99, 0xc6, 0x9e, 0xd9, 0x1f, 0x3f, 0x0f, 0x1a, 0xc3, 0x2f, 0x16, 0x58, 0xd9, 0xa1,
-	0x84, 0x23, 0xc2, 0x27, 0x5c, 0xa7, 0x77, 0x1f, 0x2c, 0x4f, 0x2f, 0x50, 0xc5, 0x67, 0x07, 0x57,
-	0x80, 0xf3, 0x1c, 0xd8, 0x8c, 0x52, 0xa1, 0x52, 0x6b, 0x8d, 0x86, 0x33, 0x4d, 0xaf, 0xee, 0xaa,
-	0xf2, 0xdd, 0x7d, 0xc4, 0x8e, 0x72, 0x14, 0x50, 0x5a, 0x37, 0x57, 0x2a, 0x67, 0x0b, 0xac, 0x11,
-	0x74, 0x2c, 0xc2, 0x0a, 0xe6, 0x38, 0x81, 0x82, 0x32, 0x1e, 0x66, 0x90, 0x67, 0x2a, 0xd9, 0x76,
-	0xe0, 0xc8, 0xda, 0xbb, 0x69, 0x69, 0x0f, 0xf2, 0xcc, 0x6c, 0xf3, 0x93, 0x05, 0xda, 0xfb, 0x98,
-	0x47, 0x28, 0x83, 0x15, 0xa6, 0x13, 0xe6, 0xec, 0x82, 0xa5, 0x0c, 0xc1, 0x04, 0xb1, 0xd0, 0x57,
-	0x7b, 0x6c, 0x8d, 0x1e, 0xb9, 0x37, 0x3f, 0x55, 0x77, 0x4f, 0x69, 0x82, 0x45, 0xad, 0xf5, 0x67,
-	0x6c, 0x46, 0xe6, 0x44, 0xff, 0x61, 0x33, 0x1a, 0x7e, 0xb7, 0x40, 0xeb, 0x8d, 0x24, 0xeb, 0x82,
-	0x73, 0x17, 0x34, 0xcd, 0xdd, 0xc8, 0xbd, 0xcd, 0x07, 0xe6, 0xcb, 0x79, 0x02, 0x6c, 0x99, 0xa4,
-	0x69, 0xd5, 0x73, 0xf5, 0xe0, 0xb8, 0xf5, 0xe0, 0xb8, 0x87, 0x75, 0xcc, 0xdb, 0x4b, 0x32, 0xb4,
-	0xd3, 0x5f, 0x03, 0x2b, 0x50, 0x0a, 0xf9, 0x6e, 0xff, 0x9d, 0xd9, 0x4a, 0xf5, 0x57, 0x5e, 0xd7,
-	0x26, 0x6c, 0x5f, 0x97, 0xb0, 0x9c, 0x16, 0x58, 0x96, 0x9a, 0xb5, 0xa0, 0x58, 0x8b, 0xb0, 0x2c,
-	0x65, 0x69, 0xf8, 0xcd, 0x02, 0x4d, 0x73, 0xa4, 0x43, 0xd0, 0xe1, 0x38, 0x25, 0x28, 0x09, 0xf5,
-	0xa1, 0x4d, 0xea, 0xde, 0x6d, 0xe2, 0x9a, 0x89, 0x26, 0x68, 0x6b, 0x17, 0xe3, 0xfa, 0x02, 0xe8,
-	0xb9, 0x53, 0xb6, 0x2a, 0xb0, 0xb9, 0x9b, 0x1e, 0x73, 0xd0, 0x31, 0x0a, 0x33, 0x0e, 0x5b, 0x60,
-	0xed, 0x04, 0x31, 0x1a, 0x1e, 0x11, 0xfa, 0x21, 0x47, 0x49, 0x8a, 0xc2, 0x92, 0x51, 0x3a, 0xae,
-	0x9f, 0x94, 0xac, 0xbd, 0xae, 0x4b, 0x07, 0xb2, 0xb2, 0xfd, 0xf4, 0xec, 0xa2, 0x6f, 0x9d, 0x5f,
-	0xf4, 0xad, 0xdf, 0x17, 0x7d, 0xeb, 0xf4, 0xb2, 0xdf, 0x38, 0xbf, 0xec, 0x37, 0x7e, 0x5c, 0xf6,
-	0x1b, 0xef, 0x07, 0x37, 0xfc, 0x20, 0xa3, 0xa6, 0xba, 0xaa, 0xc7, 0x7f, 0x02, 0x00, 0x00, 0xff,
-	0xff, 0xc9, 0xcd, 0xbe, 0x34, 0x4a, 0x05, 0x00, 0x00,
+	// 888 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdd, 0x6e, 0xdb, 0x36,
+	0x18, 0xb5, 0x12, 0x35, 0x89, 0xe9, 0x9f, 0x78, 0x5c, 0xb0, 0xa9, 0x46, 0x61, 0x7b, 0x1e, 0xb0,
+	0x7a, 0xc5, 0x26, 0x4d, 0x1e, 0x50, 0x6c, 0xc5, 0x6e, 0x96, 0x34, 0x6d, 0x86, 0xa4, 0x8b, 0xa1,
+	0x1a, 0x05, 0xb6, 0x1b, 0x82, 0x96, 0x68, 0x89, 0xb0, 0x2c, 0x0a, 0x14, 0xad, 0xc5, 0x7d, 0x82,
+	0xa2, 0x57, 0x7d, 0x80, 0x15, 0xd8, 0xb0, 0xbd, 0xc1, 0x5e, 0xa2, 0x97, 0xbd, 0x19, 0xb0, 0xab,
+	0x6d, 0x48, 0x5e, 0x64, 0x20, 0xf5, 0x13, 0x17, 0xab, 0x91, 0xa0, 0x77, 0xe4, 0xf7, 0x9d, 0x73,
+	0xc8, 0xef, 0xe0, 0x88, 0x02, 0xf6, 0x22, 0xa2, 0x2c, 0xb2, 0xe8, 0xc4, 0xb5, 0x42, 0xea, 0x07,
+	0xc2, 0x0d, 0x29, 0x89, 0x44, 0x62, 0xb9, 0x6c, 0x4e, 0xc4, 0x24, 0x4c, 0xac, 0xd4, 0x2e, 0xd7,
+	0x66, 0xcc, 0x99, 0x60, 0xb0, 0xaf, 0x28, 0x26, 0x9d, 0xb8, 0xe6, 0x2a, 0xc5, 0x2c, 0x61, 0xa9,
+	0xdd, 0xee, 0xfa, 0x8c, 0xf9, 0x21, 0xb1, 0x14, 0x63, 0xb2, 0x98, 0x5a, 0x82, 0xce, 0x49, 0x22,
+	0xf0, 0x3c, 0xce, 0x44, 0xda, 0x5d, 0x79, 0xa2, 0xcb, 0x38, 0xb1, 0x32, 0xba, 0x3a, 0x47, 0xad,
+	0x72, 0xc0, 0xed, 0x4b, 0x00, 0x9b, 0xcf, 0xa9, 0x98, 0x17, 0xa0, 0x72, 0x97, 0x03, 0xf7, 0x7c,
+	0xe6, 0x33, 0xb5, 0xb4, 0xe4, 0x2a, 0xab, 0xf6, 0xff, 0xd0, 0x41, 0xed, 0x40, 0xe9, 0x3d, 0x16,
+	0x58, 0x10, 0x78, 0x13, 0xec, 0xb8, 0x01, 0xa6, 0x11, 0xa2, 0x9e, 0xa1, 0xf5, 0xb4, 0x41, 0xd5,
+	0xd9, 0x56, 0xfb, 0xef, 0x3c, 0x78, 0x1b, 0xec, 0x0a, 0xbe, 0x48, 0x04, 0x8d, 0x7c, 0x14, 0x13,
+	0x4e, 0x99, 0x67, 0x6c, 0xf4, 0xb4, 0x81, 0xee, 0x34, 0x8b, 0xf2, 0x48, 0x55, 0xe1, 0xa7, 0xa0,
+	0xb5, 0x88, 0x26, 0x2c, 0xf2, 0x56, 0x90, 0x9b, 0x0a, 0xb9, 0x5b, 0xd6, 0x73, 0xe8, 0x27, 0x60,
+	0x77, 0x8e, 0xcf, 0x90, 0x1b, 0x32, 0x77, 0x86, 0x3c, 0x4e, 0xa7, 0xc2, 0xd0, 0x15, 0xb2, 0x31,
+	0xc7, 0x67, 0x07, 0xb2, 0x7a, 0x5f, 0x16, 0xe1, 0x21, 0x68, 0x4c, 0x39, 0x7b, 0x4a, 0x22, 0x14,
+	0x10, 0xe9, 0xa5, 0x71, 0xa3, 0xa7, 0x0d, 0x6a, 0xc3, 0xb6, 0x72, 0x57, 0x4e, 0x6f, 0xe6, 0xa6,
+	0xa4, 0xb6, 0x79, 0xa4, 0x10, 0xfb, 0xfa, 0xab, 0xbf, 0xbb, 0x15, 0xa7, 0x9e, 0xd1, 0xb2, 0x9a,
+	0x94, 0x09, 0xb1, 0x20, 0x89, 0x28, 0x64, 0xb6, 0xae, 0x2b, 0x93, 0xd1, 0x72, 0x99, 0x7b, 0xe0,
+	0x66, 0x4a, 0x38, 0x9d, 0x2e, 0xe5, 0x80, 0x33, 0xb2, 0x44, 0x53, 0x1a, 0xf9, 0x84, 0xc7, 0x9c,
+	0x46, 0xc2, 0xd8, 0xee, 0x69, 0x83, 0xba, 0xf3, 0x61, 0x09, 0x38, 0x26, 0xcb, 0x07, 0x97, 0x6d,
+	0xf8, 0x11, 0xa8, 0x2f, 0x62, 0x9f, 0x63, 0x8f, 0xa0, 0x18, 0x8b, 0xc0, 0xd8, 0xe9, 0x6d, 0x0e,
+	0xaa, 0x4e, 0x2d, 0xaf, 0x8d, 0xb0, 0x08, 0xe0, 0xc7, 0xa0, 0xf1, 0x86, 0xbc, 0x51, 0x55, 0x92,
+	0xf5, 0x55, 0x49, 0x78, 0x08, 0xba, 0x71, 0xc8, 0xa2, 0x19, 0x5a, 0x7f, 0x13, 0xa0, 0x68, 0xb7,
+	0x14, 0xec, 0xc9, 0x9a, 0xeb, 0x98, 0xe0, 0xfd, 0xb7, 0xc8, 0x18, 0x35, 0x45, 0x7d, 0xef, 0x7f,
+	0xd4, 0x7b, 0xfa, 0xb3, 0x5f, 0xba, 0x95, 0xfe, 0xef, 0x1a, 0x68, 0x1e, 0xb0, 0x28, 0x21, 0x51,
+	0xb2, 0x48, 0xb2, 0xe0, 0xdc, 0x02, 0xd5, 0x32, 0xbb, 0x2a, 0x39, 0xba, 0x73, 0x59, 0x80, 0xdf,
+	0x00, 0x9d, 0x33, 0x26, 0x54, 0x60, 0x6a, 0xc3, 0xfe, 0x8a, 0xdf, 0x97, 0x31, 0x4d, 0x6d, 0xf3,
+	0x11, 0xe1, 0xb3, 0x90, 0x38, 0x8c, 0x15, 0xbe, 0x2b, 0x16, 0xfc, 0x02, 0xec, 0x45, 0xe4, 0x4c,
+	0xa0, 0x14, 0x87, 0xd4, 0xc3, 0x82, 0xf1, 0x04, 0x05, 0x38, 0x09, 0x54, 0xa8, 0xea, 0x0e, 0x94,
+	0xbd, 0x27, 0x65, 0xeb, 0x08, 0x27, 0x41, 0x7e, 0xcd, 0x9f, 0x35, 0x50, 0x7f, 0x44, 0x93, 0x09,
+	0x09, 0x70, 0x4a, 0xd9, 0x82, 0xc3, 0x43, 0xb0, 0x13, 0x10, 0xec, 0x11, 0x8e, 0x6c, 0x75, 0xc7,
+	0xda, 0xf0, 0x8e, 0x79, 0xf5, 0x57, 0x6a, 0x1e, 0x29, 0x8e, 0xb3, 0x9d, 0x71, 0xed, 0x15, 0x99,
+	0xa1, 0xb1, 0xf1, 0xae, 0x32, 0xc3, 0xfe, 0x9f, 0x1a, 0xa8, 0x9d, 0x48, 0x70, 0xd6, 0x80, 0x1f,
+	0x80, 0xad, 0x3c, 0x96, 0xf2, 0x6e, 0x9b, 0x4e, 0xbe, 0x83, 0x5f, 0x01, 0x5d, 0x3a, 0x99, 0x1f,
+	0xd5, 0x36, 0xb3, 0x37, 0xc3, 0x2c, 0xde, 0x0c, 0x73, 0x5c, 0xd8, 0xbc, 0xbf, 0x23, 0x4d, 0x7b,
+	0xf1, 0x4f, 0x57, 0x73, 0x14, 0x43, 0x7e, 0xb2, 0x6f, 0xf7, 0xac, 0x99, 0xbe, 0xe1, 0xd7, 0x5a,
+	0x87, 0xf5, 0x75, 0x0e, 0xcb, 0x87, 0x02, 0xc7, 0x71, 0x86, 0xba, 0xa1, 0x50, 0xdb, 0x38, 0x8e,
+	0x65, 0xab, 0xff, 0xeb, 0x06, 0xd8, 0xca, 0x47, 0x1a, 0x83, 0x46, 0x42, 0xfd, 0x88, 0x78, 0x28,
+	0x1b, 0x3a, 0x77, 0xdd, 0xba, 0x8e, 0x5d, 0x2b, 0xd6, 0x38, 0xf5, 0x4c, 0x25, 0x57, 0xfd, 0x16,
+	0x64, 0x4f, 0x8e, 0x92, 0x55, 0x86, 0x6d, 0x5c, 0xf5, 0x1d, 0x3b, 0x8d, 0x9c, 0x91, 0x6d, 0xe5,
+	0xc0, 0x4f, 0x09, 0x67, 0x68, 0x16, 0xb1, 0x9f, 0x42, 0xe2, 0xf9, 0x04, 0xc5, 0x9c, 0xb1, 0x69,
+	0x11, 0x29, 0xd9, 0x3b, 0x2e, 0x5a, 0x23, 0xd9, 0x81, 0x27, 0x00, 0x28, 0x08, 0x12, 0xcb, 0x98,
+	0x28, 0x63, 0x9a, 0xc3, 0xcf, 0xaf, 0x33, 0x87, 0xa2, 0x8f, 0x97, 0x31, 0x71, 0xaa, 0x71, 0xb1,
+	0xec, 0x3f, 0x06, 0xb5, 0x7d, 0x2c, 0xdc, 0x20, 0x9f, 0xe8, 0x3e, 0xc8, 0x53, 0x91, 0x18, 0x5a,
+	0x6f, 0xf3, 0xdd, 0x02, 0x95, 0xdc, 0xa1, 0xa0, 0x5a, 0x1e, 0x06, 0x3f, 0x03, 0x70, 0xe4, 0x9c,
+	0x9e, 0x3e, 0x40, 0xe3, 0x1f, 0x46, 0x87, 0xe8, 0xa1, 0x73, 0x3a, 0x3e, 0xb2, 0xef, 0xb6, 0x2a,
+	0xed, 0xbd, 0xe7, 0x2f, 0x7b, 0xad, 0x12, 0xf6, 0x90, 0x33, 0x11, 0xd8, 0x77, 0xe1, 0x00, 0xb4,
+	0x56, 0xd0, 0xa3, 0x93, 0xd3, 0xef, 0x8f, 0x5b, 0x5a, 0x1b, 0x3e, 0x7f, 0xd9, 0x6b, 0x96, 0xd8,
+	0x91, 0x7c, 0x0d, 0xda, 0xfa, 0xb3, 0xdf, 0x3a, 0x95, 0xfd, 0xaf, 0x5f, 0x9d, 0x77, 0xb4, 0xd7,
+	0xe7, 0x1d, 0xed, 0xdf, 0xf3, 0x8e, 0xf6, 0xe2, 0xa2, 0x53, 0x79, 0x7d, 0xd1, 0xa9, 0xfc, 0x75,
+	0xd1, 0xa9, 0xfc, 0xd8, 0xbd, 0xe2, 0x4f, 0x39, 0xd9, 0x52, 0xc1, 0xfd, 0xf2, 0xbf, 0x01, 0x00,
+	0x47, 0xb1, 0x4a, 0xa1, 0x53, 0x07, 0x00, 0x00,
 }
 
 func (m *ClientState) Marshal() (dAtA []byte, err error) {
@@ -386,6 +510,43 @@ func (m *ClientState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.PlonkVerifyingKey) > 0 {
+		i -= len(m.PlonkVerifyingKey)
+		copy(dAtA[i:], m.PlonkVerifyingKey)
+		i = encodeVarintCometbls(dAtA, i, uint64(len(m.PlonkVerifyingKey)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.PlonkVerifyingKeyFingerprint) > 0 {
+		i -= len(m.PlonkVerifyingKeyFingerprint)
+		copy(dAtA[i:], m.PlonkVerifyingKeyFingerprint)
+		i = encodeVarintCometbls(dAtA, i, uint64(len(m.PlonkVerifyingKeyFingerprint)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.VerifyingKey) > 0 {
+		i -= len(m.VerifyingKey)
+		copy(dAtA[i:], m.VerifyingKey)
+		i = encodeVarintCometbls(dAtA, i, uint64(len(m.VerifyingKey)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.UpgradePath) > 0 {
+		for iNdEx := len(m.UpgradePath) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.UpgradePath[iNdEx])
+			copy(dAtA[i:], m.UpgradePath[iNdEx])
+			i = encodeVarintCometbls(dAtA, i, uint64(len(m.UpgradePath[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.VerifyingKeyFingerprint) > 0 {
+		i -= len(m.VerifyingKeyFingerprint)
+		copy(dAtA[i:], m.VerifyingKeyFingerprint)
+		i = encodeVarintCometbls(dAtA, i, uint64(len(m.VerifyingKeyFingerprint)))
+		i--
+		dAtA[i] = 0x3a
+	}
 	{
 		size, err := m.LatestHeight.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -600,6 +761,11 @@ func (m *Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ProofType != 0 {
+		i = encodeVarintCometbls(dAtA, i, uint64(m.ProofType))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.ZeroKnowledgeProof) > 0 {
 		i -= len(m.ZeroKnowledgeProof)
 		copy(dAtA[i:], m.ZeroKnowledgeProof)
@@ -634,6 +800,43 @@ func (m *Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *BatchHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchHeader) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Headers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCometbls(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintCometbls(dAtA []byte, offset int, v uint64) int {
 	offset -= sovCometbls(v)
 	base := offset
@@ -668,6 +871,28 @@ func (m *ClientState) Size() (n int) {
 	n += 1 + l + sovCometbls(uint64(l))
 	l = m.LatestHeight.Size()
 	n += 1 + l + sovCometbls(uint64(l))
+	l = len(m.VerifyingKeyFingerprint)
+	if l > 0 {
+		n += 1 + l + sovCometbls(uint64(l))
+	}
+	if len(m.UpgradePath) > 0 {
+		for _, s := range m.UpgradePath {
+			l = len(s)
+			n += 1 + l + sovCometbls(uint64(l))
+		}
+	}
+	l = len(m.VerifyingKey)
+	if l > 0 {
+		n += 1 + l + sovCometbls(uint64(l))
+	}
+	l = len(m.PlonkVerifyingKeyFingerprint)
+	if l > 0 {
+		n += 1 + l + sovCometbls(uint64(l))
+	}
+	l = len(m.PlonkVerifyingKey)
+	if l > 0 {
+		n += 1 + l + sovCometbls(uint64(l))
+	}
 	return n
 }
 
@@ -750,6 +975,24 @@ func (m *Header) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCometbls(uint64(l))
 	}
+	if m.ProofType != 0 {
+		n += 1 + sovCometbls(uint64(m.ProofType))
+	}
+	return n
+}
+
+func (m *BatchHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovCometbls(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -943,6 +1186,174 @@ func (m *ClientState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyingKeyFingerprint", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCometbls
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VerifyingKeyFingerprint = append(m.VerifyingKeyFingerprint[:0], dAtA[iNdEx:postIndex]...)
+			if m.VerifyingKeyFingerprint == nil {
+				m.VerifyingKeyFingerprint = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpgradePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCometbls
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UpgradePath = append(m.UpgradePath, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyingKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCometbls
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VerifyingKey = append(m.VerifyingKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.VerifyingKey == nil {
+				m.VerifyingKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlonkVerifyingKeyFingerprint", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCometbls
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PlonkVerifyingKeyFingerprint = append(m.PlonkVerifyingKeyFingerprint[:0], dAtA[iNdEx:postIndex]...)
+			if m.PlonkVerifyingKeyFingerprint == nil {
+				m.PlonkVerifyingKeyFingerprint = []byte{}
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlonkVerifyingKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCometbls
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PlonkVerifyingKey = append(m.PlonkVerifyingKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PlonkVerifyingKey == nil {
+				m.PlonkVerifyingKey = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCometbls(dAtA[iNdEx:])
@@ -1561,6 +1972,109 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 				m.ZeroKnowledgeProof = []byte{}
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofType", wireType)
+			}
+			m.ProofType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCometbls
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProofType |= ProofType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCometbls(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCometbls
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCometbls
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCometbls
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Headers = append(m.Headers, &Header{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCometbls(dAtA[iNdEx:])
@@ -20,4 +20,7 @@ var (
 	ErrInvalidProofSpecs       = errorsmod.Register(ModuleName, 13, "invalid proof specs")
 	ErrInvalidValidatorSet     = errorsmod.Register(ModuleName, 14, "invalid validator set")
 	ErrInvalidHeaderTimestamp  = errorsmod.Register(ModuleName, 15, "invalid header timestamp")
+	ErrInvalidProof            = errorsmod.Register(ModuleName, 16, "invalid zero-knowledge proof")
+	ErrUnknownVerifyingKey     = errorsmod.Register(ModuleName, 17, "unknown verifying key")
+	ErrInvalidVerifyingKey     = errorsmod.Register(ModuleName, 18, "invalid verifying key")
 )
@@ -7,14 +7,17 @@ import (
 	"github.com/cosmos/ibc-go/v8/modules/core/exported"
 )
 
-// ExportMetadata exports all the consensus metadata in the client store so they can be included in clients genesis
-// and imported by a ClientKeeper
+// ExportMetadata exports all the consensus metadata and the verifying keys in the client store so they can be
+// included in clients genesis and imported by a ClientKeeper
 func (ClientState) ExportMetadata(store storetypes.KVStore) []exported.GenesisMetadata {
 	gm := make([]exported.GenesisMetadata, 0)
-	IterateConsensusMetadata(store, func(key, val []byte) bool {
+	export := func(key, val []byte) bool {
 		gm = append(gm, clienttypes.NewGenesisMetadata(key, val))
 		return false
-	})
+	}
+	IterateConsensusMetadata(store, export)
+	IterateVerifyingKeys(store, KeyVerifyingKeyPrefix, export)
+	IterateVerifyingKeys(store, KeyPlonkVerifyingKeyPrefix, export)
 	if len(gm) == 0 {
 		return nil
 	}
@@ -0,0 +1,3 @@
+module github.com/unionlabs/union/11-cometbls/inputshash
+
+go 1.21
@@ -0,0 +1,92 @@
+/*
+Package inputshash implements the encoding of the sole public input of the
+CometBLS non-adjacent circuit, the inputs hash. It is shared by the prover
+(galoisd) and the light client so that both hash the light header alike. It
+only depends on the standard library to be importable from either module.
+*/
+package inputshash
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// The light header fields committed to by the inputs hash
+type LightHeader struct {
+	ChainId            string
+	Height             int64
+	Time               time.Time
+	ValidatorsHash     []byte
+	NextValidatorsHash []byte
+	AppHash            []byte
+}
+
+// A field of the inputs hash preimage along with its encoding
+type Field struct {
+	Name    string
+	Value   string
+	Encoded []byte
+}
+
+// Check that the chain id and the hashes fit in the 32 bytes words the circuit
+// packs them in, hashes being expected to be exactly 32 bytes long.
+func Validate(header LightHeader, trustedValidatorsHash []byte) error {
+	if len(header.ChainId) > 31 {
+		return fmt.Errorf("chain id length cannot be larger than 31, got: %d", len(header.ChainId))
+	}
+	for _, hash := range []struct {
+		name  string
+		value []byte
+	}{
+		{"validators hash", header.ValidatorsHash},
+		{"next validators hash", header.NextValidatorsHash},
+		{"app hash", header.AppHash},
+		{"trusted validators hash", trustedValidatorsHash},
+	} {
+		if len(hash.value) != 32 {
+			return fmt.Errorf("the %s must be 32 bytes, got: %d", hash.name, len(hash.value))
+		}
+	}
+	return nil
+}
+
+// The preimage of the inputs hash field by field, in the order the circuit
+// hashes them. The integers and the MiMC hashes are left padded to 32 bytes,
+// see Validate for the lengths the circuit expects.
+func Fields(header LightHeader, trustedValidatorsHash []byte) []Field {
+	var fields []Field
+	writeI64 := func(name string, x int64) {
+		var padded [32]byte
+		big.NewInt(x).FillBytes(padded[:])
+		fields = append(fields, Field{name, fmt.Sprint(x), padded[:]})
+	}
+	writeMiMCHash := func(name string, value string, b []byte) {
+		var padded [32]byte
+		big.NewInt(0).SetBytes(b).FillBytes(padded[:])
+		fields = append(fields, Field{name, value, padded[:]})
+	}
+	writeHash := func(name string, b []byte) {
+		fields = append(fields, Field{name, fmt.Sprintf("%X", b), b})
+	}
+	writeMiMCHash("chain_id", header.ChainId, []byte(header.ChainId))
+	writeI64("height", header.Height)
+	writeI64("time_seconds", header.Time.Unix())
+	writeI64("time_nanos", int64(header.Time.Nanosecond()))
+	writeMiMCHash("validators_hash", fmt.Sprintf("%X", header.ValidatorsHash), header.ValidatorsHash)
+	writeMiMCHash("next_validators_hash", fmt.Sprintf("%X", header.NextValidatorsHash), header.NextValidatorsHash)
+	writeHash("app_hash", header.AppHash)
+	writeMiMCHash("trusted_validators_hash", fmt.Sprintf("%X", trustedValidatorsHash), trustedValidatorsHash)
+	return fields
+}
+
+// The sha256 of the preimage with the most significant byte truncated to fit
+// the scalar field, i.e. the 31 bytes big endian inputs hash.
+func Hash(header LightHeader, trustedValidatorsHash []byte) []byte {
+	hasher := sha256.New()
+	for _, field := range Fields(header, trustedValidatorsHash) {
+		hasher.Write(field.Encoded)
+	}
+	return hasher.Sum(nil)[1:]
+}
@@ -0,0 +1,70 @@
+package inputshash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestHash(t *testing.T) {
+	valHash, _ := hex.DecodeString("1B7EA0F1B3E574F8D50A12827CCEA43CFF858C2716AE05370CC40AE8EC521FD8")
+	appHash, _ := hex.DecodeString("3A34FC963EEFAAE9B7C0D3DFF89180D91F3E31073E654F732340CEEDD77DD25B")
+	header := LightHeader{
+		ChainId:            "union-devnet-1337",
+		Height:             3405691582,
+		Time:               time.Unix(1710783278, 499600406),
+		ValidatorsHash:     valHash,
+		NextValidatorsHash: valHash,
+		AppHash:            appHash,
+	}
+
+	// Must match testdata/vectors.json of the light client
+	expected, _ := hex.DecodeString("70D84799585303329A86E75218A78BB67EB3F1851ECB39E824FD84CFC6C31D")
+	hash := Hash(header, valHash)
+	if !bytes.Equal(hash, expected) {
+		t.Fatalf("expected inputs hash %X, got %X", expected, hash)
+	}
+
+	fields := Fields(header, valHash)
+	names := []string{"chain_id", "height", "time_seconds", "time_nanos", "validators_hash", "next_validators_hash", "app_hash", "trusted_validators_hash"}
+	if len(fields) != len(names) {
+		t.Fatalf("expected %d fields, got %d", len(names), len(fields))
+	}
+	for i, field := range fields {
+		if field.Name != names[i] {
+			t.Errorf("expected field %d to be %s, got %s", i, names[i], field.Name)
+		}
+		if len(field.Encoded) != 32 {
+			t.Errorf("expected %s to be encoded on 32 bytes, got %d", field.Name, len(field.Encoded))
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	hash := make([]byte, 32)
+	header := LightHeader{
+		ChainId:            "union-devnet-1337",
+		ValidatorsHash:     hash,
+		NextValidatorsHash: hash,
+		AppHash:            hash,
+	}
+	if err := Validate(header, hash); err != nil {
+		t.Fatalf("expected a valid header, got: %s", err)
+	}
+
+	tooLong := header
+	tooLong.ChainId = fmt.Sprintf("%-32s", header.ChainId)
+	if err := Validate(tooLong, hash); err == nil {
+		t.Error("expected a chain id longer than 31 bytes to be rejected")
+	}
+	truncated := header
+	truncated.AppHash = hash[1:]
+	if err := Validate(truncated, hash); err == nil {
+		t.Error("expected a truncated app hash to be rejected")
+	}
+	if err := Validate(header, nil); err == nil {
+		t.Error("expected a missing trusted validators hash to be rejected")
+	}
+}
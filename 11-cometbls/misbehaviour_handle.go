@@ -14,40 +14,26 @@ import (
 )
 
 // CheckForMisbehaviour detects duplicate height misbehaviour and BFT time violation misbehaviour
-// in a submitted Header message and verifies the correctness of a submitted Misbehaviour ClientMessage
+// in a submitted Header or BatchHeader message and verifies the correctness of a submitted Misbehaviour ClientMessage
 func (cs ClientState) CheckForMisbehaviour(ctx sdk.Context, cdc codec.BinaryCodec, clientStore storetypes.KVStore, msg exported.ClientMessage) bool {
 	switch msg := msg.(type) {
 	case *Header:
-		tmHeader := msg
-		consState := tmHeader.ConsensusState()
-
-		// Check if the Client store already has a consensus state for the header's height
-		// If the consensus state exists, and it matches the header then we return early
-		// since header has already been submitted in a previous UpdateClient.
-		if existingConsState, found := GetConsensusState(clientStore, cdc, tmHeader.GetHeight()); found {
-			// This header has already been submitted and the necessary state is already stored
-			// in client store, thus we can return early without further validation.
-			if reflect.DeepEqual(existingConsState, tmHeader.ConsensusState()) { //nolint:gosimple
-				return false
+		return checkHeaderForMisbehaviour(cdc, clientStore, msg)
+	case *BatchHeader:
+		for _, header := range msg.Headers {
+			if checkHeaderForMisbehaviour(cdc, clientStore, header) {
+				return true
 			}
-
-			// A consensus state already exists for this height, but it does not match the provided header.
-			// The assumption is that Header has already been validated. Thus we can return true as misbehaviour is present
-			return true
 		}
 
-		// Check that consensus state timestamps are monotonic
-		prevCons, prevOk := GetPreviousConsensusState(clientStore, cdc, tmHeader.GetHeight())
-		nextCons, nextOk := GetNextConsensusState(clientStore, cdc, tmHeader.GetHeight())
-		// if previous consensus state exists, check consensus state time is greater than previous consensus state time
-		// if previous consensus state is not before current consensus state return true
-		if prevOk && prevCons.Timestamp >= consState.Timestamp {
-			return true
-		}
-		// if next consensus state exists, check consensus state time is less than next consensus state time
-		// if next consensus state is not after current consensus state return true
-		if nextOk && nextCons.Timestamp <= consState.Timestamp {
-			return true
+		// The headers of the batch aren't stored yet, their timestamps must
+		// also be monotonic among themselves
+		for _, header1 := range msg.Headers {
+			for _, header2 := range msg.Headers {
+				if header1.GetHeight().LT(header2.GetHeight()) && !header1.GetTime().Before(header2.GetTime()) {
+					return true
+				}
+			}
 		}
 	case *Misbehaviour:
 		// we don't do anything here since we already verified any possible misbehaviour in `verifyMisbehaviour`
@@ -57,6 +43,43 @@ func (cs ClientState) CheckForMisbehaviour(ctx sdk.Context, cdc codec.BinaryCode
 	return false
 }
 
+// checkHeaderForMisbehaviour detects whether the header conflicts with a stored consensus state at its height
+// or breaks the monotonicity of the stored consensus state timestamps.
+func checkHeaderForMisbehaviour(cdc codec.BinaryCodec, clientStore storetypes.KVStore, tmHeader *Header) bool {
+	consState := tmHeader.ConsensusState()
+
+	// Check if the Client store already has a consensus state for the header's height
+	// If the consensus state exists, and it matches the header then we return early
+	// since header has already been submitted in a previous UpdateClient.
+	if existingConsState, found := GetConsensusState(clientStore, cdc, tmHeader.GetHeight()); found {
+		// This header has already been submitted and the necessary state is already stored
+		// in client store, thus we can return early without further validation.
+		if reflect.DeepEqual(existingConsState, tmHeader.ConsensusState()) { //nolint:gosimple
+			return false
+		}
+
+		// A consensus state already exists for this height, but it does not match the provided header.
+		// The assumption is that Header has already been validated. Thus we can return true as misbehaviour is present
+		return true
+	}
+
+	// Check that consensus state timestamps are monotonic
+	prevCons, prevOk := GetPreviousConsensusState(clientStore, cdc, tmHeader.GetHeight())
+	nextCons, nextOk := GetNextConsensusState(clientStore, cdc, tmHeader.GetHeight())
+	// if previous consensus state exists, check consensus state time is greater than previous consensus state time
+	// if previous consensus state is not before current consensus state return true
+	if prevOk && prevCons.Timestamp >= consState.Timestamp {
+		return true
+	}
+	// if next consensus state exists, check consensus state time is less than next consensus state time
+	// if next consensus state is not after current consensus state return true
+	if nextOk && nextCons.Timestamp <= consState.Timestamp {
+		return true
+	}
+
+	return false
+}
+
 // verifyMisbehaviour determines whether or not two conflicting
 // headers at the same height would have convinced the light client.
 //
@@ -65,7 +88,19 @@ func (cs ClientState) CheckForMisbehaviour(ctx sdk.Context, cdc codec.BinaryCode
 // Similarly, consensusState2 is the trusted consensus state that corresponds
 // to misbehaviour.Header_2
 // Misbehaviour sets frozen height to {0, 1} since it is only used as a boolean value (zero or non-zero).
+// Misbehaviour which is older than the trusting period is rejected.
 func (cs *ClientState) verifyMisbehaviour(ctx sdk.Context, clientStore storetypes.KVStore, cdc codec.BinaryCodec, misbehaviour *Misbehaviour) error {
+	// Misbehaviour older than the trusting period can't be proven against
+	// trusted consensus states, which is checked for each header below, the
+	// age of the misbehaviour is checked beforehand to reject it cheaply.
+	if cs.IsExpired(uint64(misbehaviour.GetTime().UnixNano()), uint64(ctx.BlockTime().UnixNano())) {
+		return errorsmod.Wrapf(
+			ErrTrustingPeriodExpired,
+			"misbehaviour at %d is older than the trusting period %d at current time %d",
+			misbehaviour.GetTime().UnixNano(), cs.TrustingPeriod, ctx.BlockTime().UnixNano(),
+		)
+	}
+
 	// Regardless of the type of misbehaviour, ensure that both headers are valid and would have been accepted by light-client
 
 	if err := cs.verifyHeader(ctx, clientStore, cdc, misbehaviour.Header_1); err != nil {
@@ -0,0 +1,40 @@
+package cometbls
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+)
+
+func TestVerifyMisbehaviourAge(t *testing.T) {
+	cs, clientStore, cdc, header := newTestClient(t)
+	// Both headers are valid updates, the same one stands for both as
+	// misbehaviour is only rejected once they have been verified.
+	misbehaviour := NewMisbehaviour("", header, header)
+	trustedExpiry := header.GetTime().Add(-testHeaderDelay).Add(testTrustingPeriod)
+	misbehaviourExpiry := misbehaviour.GetTime().Add(testTrustingPeriod)
+
+	tests := []struct {
+		description string
+		blockTime   time.Time
+		err         error
+		message     string
+	}{
+		{"within the trusting period", trustedExpiry.Add(-time.Nanosecond), clienttypes.ErrInvalidMisbehaviour, "headers are the same"},
+		{"trusted consensus state expired", trustedExpiry, ErrTrustingPeriodExpired, "verifying Header_1 in Misbehaviour failed"},
+		{"just before the misbehaviour expiry", misbehaviourExpiry.Add(-time.Nanosecond), ErrTrustingPeriodExpired, "trusted consensus state at height"},
+		{"at the misbehaviour expiry", misbehaviourExpiry, ErrTrustingPeriodExpired, "is older than the trusting period"},
+		{"after the misbehaviour expiry", misbehaviourExpiry.Add(time.Nanosecond), ErrTrustingPeriodExpired, "is older than the trusting period"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := cs.VerifyClientMessage(atTime(tt.blockTime), cdc, clientStore, misbehaviour)
+			require.ErrorIs(t, err, tt.err)
+			require.ErrorContains(t, err, tt.message)
+		})
+	}
+}
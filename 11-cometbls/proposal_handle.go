@@ -1,6 +1,7 @@
 package cometbls
 
 import (
+	"bytes"
 	"reflect"
 
 	errorsmod "cosmossdk.io/errors"
@@ -21,7 +22,11 @@ import (
 //
 // The following must always be true:
 //   - The substitute client is the same type as the subject client
-//   - The subject and substitute client states match in all parameters (expect frozen height, latest height, and chain-id)
+//   - The subject and substitute client states match in all parameters (expect frozen height, latest height, chain-id
+//     and verifying key)
+//
+// The subject takes over the verifying key of the substitute, which is how governance
+// migrates a client to the key of a new ceremony.
 //
 // In case 1) before updating the client, the client will be unfrozen by resetting
 // the FrozenHeight to the zero Height.
@@ -73,6 +78,25 @@ func (cs ClientState) CheckSubstituteAndUpdateState(
 	// set new trusting period based on the substitute client state
 	cs.TrustingPeriod = substituteClientState.TrustingPeriod
 
+	// trust the verifying key of the substitute client state
+	cs.VerifyingKeyFingerprint = substituteClientState.VerifyingKeyFingerprint
+	if !bytes.Equal(cs.GetVerifyingKeyFingerprint(), DefaultVerifyingKeyFingerprint) {
+		cs.VerifyingKey, found = getVerifyingKey(substituteClientStore, KeyVerifyingKeyPrefix, cs.VerifyingKeyFingerprint)
+		if !found {
+			return errorsmod.Wrapf(ErrUnknownVerifyingKey, "unable to retrieve the verifying key of fingerprint %X for substitute client", cs.VerifyingKeyFingerprint)
+		}
+	}
+	cs.PlonkVerifyingKeyFingerprint = substituteClientState.PlonkVerifyingKeyFingerprint
+	if len(cs.PlonkVerifyingKeyFingerprint) != 0 {
+		cs.PlonkVerifyingKey, found = getVerifyingKey(substituteClientStore, KeyPlonkVerifyingKeyPrefix, cs.PlonkVerifyingKeyFingerprint)
+		if !found {
+			return errorsmod.Wrapf(ErrUnknownVerifyingKey, "unable to retrieve the plonk verifying key of fingerprint %X for substitute client", cs.PlonkVerifyingKeyFingerprint)
+		}
+	}
+	if err := cs.storeVerifyingKeys(subjectClientStore); err != nil {
+		return err
+	}
+
 	// no validation is necessary since the substitute is verified to be Active
 	// in 02-client.
 	setClientState(subjectClientStore, cdc, &cs)
@@ -81,7 +105,7 @@ func (cs ClientState) CheckSubstituteAndUpdateState(
 }
 
 // IsMatchingClientState returns true if all the client state parameters match
-// except for frozen height, latest height, trusting period, chain-id.
+// except for frozen height, latest height, trusting period, chain-id and verifying keys.
 func IsMatchingClientState(subject, substitute ClientState) bool {
 	// zero out parameters which do not need to match
 	subject.LatestHeight = clienttypes.ZeroHeight()
@@ -92,6 +116,14 @@ func IsMatchingClientState(subject, substitute ClientState) bool {
 	substitute.TrustingPeriod = 0
 	subject.ChainId = ""
 	substitute.ChainId = ""
+	subject.VerifyingKeyFingerprint = nil
+	substitute.VerifyingKeyFingerprint = nil
+	subject.VerifyingKey = nil
+	substitute.VerifyingKey = nil
+	subject.PlonkVerifyingKeyFingerprint = nil
+	substitute.PlonkVerifyingKeyFingerprint = nil
+	subject.PlonkVerifyingKey = nil
+	substitute.PlonkVerifyingKey = nil
 	// sets both sets of flags to true as these flags have been DEPRECATED, see ADR-026 for more information
 
 	return reflect.DeepEqual(subject, substitute)
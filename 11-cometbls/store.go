@@ -3,6 +3,7 @@ package cometbls
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"strings"
 
 	"cosmossdk.io/store/prefix"
@@ -33,7 +34,15 @@ Iterators to iterate over the consensus states in ascending/descending order by
 A future version of IBC may choose to replace the ICS24 ConsensusState path with the more efficient format and make this indirection unnecessary.
 */
 
-const KeyIterateConsensusStatePrefix = "iterateConsensusStates"
+const (
+	KeyIterateConsensusStatePrefix = "iterateConsensusStates"
+	// KeyVerifyingKeyPrefix prefixes the groth16 verifying keys stored under
+	// their fingerprint
+	KeyVerifyingKeyPrefix = "verifyingKeys"
+	// KeyPlonkVerifyingKeyPrefix prefixes the PLONK verifying keys stored
+	// under their fingerprint
+	KeyPlonkVerifyingKeyPrefix = "plonkVerifyingKeys"
+)
 
 var (
 	// KeyProcessedTime is appended to consensus state key to store the processed time
@@ -51,6 +60,41 @@ func setClientState(clientStore storetypes.KVStore, cdc codec.BinaryCodec, clien
 	clientStore.Set(key, val)
 }
 
+// VerifyingKeyKey returns the key under which the verifying key of the given fingerprint is
+// stored in the client store, the prefix telling its proving system.
+func VerifyingKeyKey(prefix string, fingerprint []byte) []byte {
+	return []byte(fmt.Sprintf("%s/%X", prefix, fingerprint))
+}
+
+// setVerifyingKey stores the encoding of a verifying key under its fingerprint. The client
+// states only name the key, which is hence stored once whatever the number of states.
+func setVerifyingKey(clientStore storetypes.KVStore, prefix string, verifyingKey []byte) {
+	key := VerifyingKeyKey(prefix, fingerprintOf(verifyingKey))
+	clientStore.Set(key, verifyingKey)
+}
+
+// getVerifyingKey retrieves the encoding of the verifying key of the given fingerprint from
+// the client store.
+func getVerifyingKey(clientStore storetypes.KVStore, prefix string, fingerprint []byte) ([]byte, bool) {
+	bz := clientStore.Get(VerifyingKeyKey(prefix, fingerprint))
+	if len(bz) == 0 {
+		return nil, false
+	}
+	return bz, true
+}
+
+// IterateVerifyingKeys iterates through the verifying keys of the given proving system in
+// the client store. If the cb returns true, then iterator will close and stop.
+func IterateVerifyingKeys(store storetypes.KVStore, prefix string, cb func(key, val []byte) bool) {
+	iterator := storetypes.KVStorePrefixIterator(store, []byte(prefix+"/"))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		if cb(iterator.Key(), iterator.Value()) {
+			break
+		}
+	}
+}
+
 // setConsensusState stores the consensus state at the given height.
 func setConsensusState(clientStore storetypes.KVStore, cdc codec.BinaryCodec, consensusState *ConsensusState, height exported.Height) {
 	key := host.ConsensusStateKey(height)
@@ -15,7 +15,7 @@ import (
 	"github.com/cosmos/ibc-go/v8/modules/core/exported"
 )
 
-// VerifyClientMessage checks if the clientMessage is of type Header or Misbehaviour and verifies the message
+// VerifyClientMessage checks if the clientMessage is of type Header, BatchHeader or Misbehaviour and verifies the message
 func (cs *ClientState) VerifyClientMessage(
 	ctx sdk.Context, cdc codec.BinaryCodec, clientStore storetypes.KVStore,
 	clientMsg exported.ClientMessage,
@@ -23,6 +23,8 @@ func (cs *ClientState) VerifyClientMessage(
 	switch msg := clientMsg.(type) {
 	case *Header:
 		return cs.verifyHeader(ctx, clientStore, cdc, msg)
+	case *BatchHeader:
+		return cs.verifyBatchHeader(ctx, clientStore, cdc, msg)
 	case *Misbehaviour:
 		return cs.verifyMisbehaviour(ctx, clientStore, cdc, msg)
 	default:
@@ -36,6 +38,7 @@ func (cs *ClientState) VerifyClientMessage(
 // - header height is less than or equal to the trusted header height
 // - header revision is not equal to trusted header revision
 // - header valset commit verification fails
+// - the trusted consensus state is past the trusting period in relation to the block time
 // - header timestamp is past the trusting period in relation to the consensus state
 // - header timestamp is less than or equal to the consensus state timestamp
 func (cs *ClientState) verifyHeader(
@@ -48,6 +51,60 @@ func (cs *ClientState) verifyHeader(
 		return errorsmod.Wrapf(clienttypes.ErrConsensusStateNotFound, "could not get trusted consensus state from clientStore for Header at TrustedHeight: %s", header.TrustedHeight)
 	}
 
+	return cs.verifyHeaderWithConsensusState(ctx, clientStore, consState, header)
+}
+
+// verifyBatchHeader verifies each header of the batch in order, against the
+// consensus state at its trusted height, either stored or the one of an
+// earlier header of the batch.
+func (cs *ClientState) verifyBatchHeader(
+	ctx sdk.Context, clientStore storetypes.KVStore, cdc codec.BinaryCodec,
+	batch *BatchHeader,
+) error {
+	if err := batch.ValidateBasic(); err != nil {
+		return err
+	}
+
+	batchConsStates := make(map[exported.Height]*ConsensusState, len(batch.Headers))
+	for i, header := range batch.Headers {
+		ctx.GasMeter().ConsumeGas(BatchHeaderVerificationGas, "verify 11-cometbls batch header")
+
+		consState, found := batchConsStates[*header.TrustedHeight]
+		if !found {
+			consState, found = GetConsensusState(clientStore, cdc, header.TrustedHeight)
+		}
+		if !found {
+			return errorsmod.Wrapf(
+				clienttypes.ErrConsensusStateNotFound,
+				"header %d of the batch: no consensus state at TrustedHeight %s in clientStore nor in an earlier header", i, header.TrustedHeight,
+			)
+		}
+
+		if err := cs.verifyHeaderWithConsensusState(ctx, clientStore, consState, header); err != nil {
+			return errorsmod.Wrapf(err, "verifying header %d of the batch failed", i)
+		}
+
+		batchConsStates[header.GetHeight()] = header.ConsensusState()
+	}
+
+	return nil
+}
+
+// verifyHeaderWithConsensusState verifies the header against the consensus
+// state at its trusted height.
+func (cs *ClientState) verifyHeaderWithConsensusState(
+	ctx sdk.Context, clientStore storetypes.KVStore, consState *ConsensusState, header *Header,
+) error {
+	// An expired consensus state can't be trusted anymore, the validators it
+	// commits to may have unbonded since then
+	if cs.IsExpired(consState.GetTimestamp(), uint64(ctx.BlockTime().UnixNano())) {
+		return errorsmod.Wrapf(
+			ErrTrustingPeriodExpired,
+			"trusted consensus state at height %s expired: timestamp %d + trusting period %d <= current time %d",
+			header.TrustedHeight, consState.GetTimestamp(), cs.TrustingPeriod, ctx.BlockTime().UnixNano(),
+		)
+	}
+
 	// UpdateClient only accepts updates with a header at the same revision
 	// as the trusted consensus state
 	if header.GetHeight().GetRevisionNumber() != header.TrustedHeight.RevisionNumber {
@@ -89,20 +146,41 @@ func (cs *ClientState) verifyHeader(
 		)
 	}
 
-	zkp, err := ParseZKP(header.ZeroKnowledgeProof)
-
-	if err != nil {
-		return err
-	}
-
-	return zkp.Verify(consState.NextValidatorsHash, ProverLightHeader{
+	lightHeader := ProverLightHeader{
 		ChainId:            cs.ChainId,
 		Height:             header.SignedHeader.Height,
 		Time:               header.GetTime(),
 		ValidatorsHash:     header.SignedHeader.ValidatorsHash,
 		NextValidatorsHash: header.SignedHeader.NextValidatorsHash,
 		AppHash:            header.SignedHeader.AppHash,
-	})
+	}
+
+	// proofs are only parsed against the key the client trusts for their
+	// proving system
+	switch header.ProofType {
+	case ProofTypeGroth16:
+		vk, err := cs.GetVerifyingKey(clientStore)
+		if err != nil {
+			return err
+		}
+		zkp, err := ParseZKP(header.ZeroKnowledgeProof)
+		if err != nil {
+			return err
+		}
+		return zkp.VerifyWithKey(vk, consState.NextValidatorsHash, lightHeader)
+	case ProofTypePlonk:
+		vk, err := cs.GetPlonkVerifyingKey(clientStore)
+		if err != nil {
+			return err
+		}
+		zkp, err := ParsePlonkZKP(vk, header.ZeroKnowledgeProof)
+		if err != nil {
+			return err
+		}
+		return zkp.VerifyWithKey(vk, consState.NextValidatorsHash, lightHeader)
+	default:
+		return errorsmod.Wrapf(ErrInvalidProof, "unknown proof type: %d", header.ProofType)
+	}
 }
 
 // UpdateState may be used to either create a consensus state for:
@@ -111,14 +189,19 @@ func (cs *ClientState) verifyHeader(
 // If we are updating to a past height, a consensus state is created for that height to be persisted in client store
 // If we are updating to a future height, the consensus state is created and the client state is updated to reflect
 // the new latest height
-// A list containing the updated consensus height is returned.
+// A list containing the updated consensus height is returned, with the heights of all the headers for a BatchHeader.
 // UpdateState must only be used to update within a single revision, thus header revision number and trusted height's revision
 // number must be the same. To update to a new revision, use a separate upgrade path
 // UpdateState will prune the oldest consensus state if it is expired.
-// If the provided clientMsg is not of type of Header then the handler will noop and empty slice is returned.
+// If the provided clientMsg is not of type of Header or BatchHeader then the handler will noop and empty slice is returned.
 func (cs ClientState) UpdateState(ctx sdk.Context, cdc codec.BinaryCodec, clientStore storetypes.KVStore, clientMsg exported.ClientMessage) []exported.Height {
-	header, ok := clientMsg.(*Header)
-	if !ok {
+	var headers []*Header
+	switch msg := clientMsg.(type) {
+	case *Header:
+		headers = []*Header{msg}
+	case *BatchHeader:
+		headers = msg.Headers
+	default:
 		// clientMsg is invalid Misbehaviour, no update necessary
 		return []exported.Height{}
 	}
@@ -129,29 +212,40 @@ func (cs ClientState) UpdateState(ctx sdk.Context, cdc codec.BinaryCodec, client
 		cs.pruneOldestConsensusState(ctx, cdc, clientStore)
 	}
 
-	// check for duplicate update
-	if _, found := GetConsensusState(clientStore, cdc, header.GetHeight()); found {
-		// perform no-op
-		return []exported.Height{header.GetHeight()}
-	}
+	heights := make([]exported.Height, 0, len(headers))
+	updated := false
+	for _, header := range headers {
+		// check for duplicate update
+		if _, found := GetConsensusState(clientStore, cdc, header.GetHeight()); found {
+			// perform no-op
+			heights = append(heights, header.GetHeight())
+			continue
+		}
 
-	height := header.GetHeight().(clienttypes.Height)
-	if height.GT(cs.LatestHeight) {
-		cs.LatestHeight = height
-	}
+		height := header.GetHeight().(clienttypes.Height)
+		if height.GT(cs.LatestHeight) {
+			cs.LatestHeight = height
+		}
 
-	consensusState := &ConsensusState{
-		Timestamp:          uint64(header.GetTime().UnixNano()),
-		Root:               commitmenttypes.NewMerkleRoot(header.SignedHeader.GetAppHash()),
-		NextValidatorsHash: header.SignedHeader.NextValidatorsHash,
+		consensusState := &ConsensusState{
+			Timestamp:          uint64(header.GetTime().UnixNano()),
+			Root:               commitmenttypes.NewMerkleRoot(header.SignedHeader.GetAppHash()),
+			NextValidatorsHash: header.SignedHeader.NextValidatorsHash,
+		}
+
+		// set consensus state and associated metadata
+		setConsensusState(clientStore, cdc, consensusState, header.GetHeight())
+		setConsensusMetadata(ctx, clientStore, header.GetHeight())
+
+		heights = append(heights, height)
+		updated = true
 	}
 
-	// set client state, consensus state and associated metadata
-	setClientState(clientStore, cdc, &cs)
-	setConsensusState(clientStore, cdc, consensusState, header.GetHeight())
-	setConsensusMetadata(ctx, clientStore, header.GetHeight())
+	if updated {
+		setClientState(clientStore, cdc, &cs)
+	}
 
-	return []exported.Height{height}
+	return heights
 }
 
 // pruneOldestConsensusState will retrieve the earliest consensus state for this clientID and check if it is expired. If it is,
@@ -0,0 +1,101 @@
+package cometbls
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v8/modules/core/23-commitment/types"
+)
+
+const (
+	testTrustingPeriod = time.Hour
+	// Age of the trusted consensus state when the header is produced
+	testHeaderDelay = 30 * time.Minute
+)
+
+func newTestCodec() codec.BinaryCodec {
+	registry := codectypes.NewInterfaceRegistry()
+	clienttypes.RegisterInterfaces(registry)
+	RegisterInterfaces(registry)
+	return codec.NewProtoCodec(registry)
+}
+
+// A client trusting a consensus state from which the header of TestVerifier
+// is a valid update.
+func newTestClient(t *testing.T) (*ClientState, storetypes.KVStore, codec.BinaryCodec, *Header) {
+	cdc := newTestCodec()
+	validatorsHash, err := hex.DecodeString("1B7EA0F1B3E574F8D50A12827CCEA43CFF858C2716AE05370CC40AE8EC521FD8")
+	require.NoError(t, err)
+	appHash, err := hex.DecodeString("3A34FC963EEFAAE9B7C0D3DFF89180D91F3E31073E654F732340CEEDD77DD25B")
+	require.NoError(t, err)
+	zkp, err := hex.DecodeString(testZKP)
+	require.NoError(t, err)
+
+	trustedHeight := clienttypes.NewHeight(1337, 3405691500)
+	header := &Header{
+		SignedHeader: &LightHeader{
+			Height:             3405691582,
+			Time:               time.Unix(1710783278, 499600406),
+			ValidatorsHash:     validatorsHash,
+			NextValidatorsHash: validatorsHash,
+			AppHash:            appHash,
+		},
+		TrustedHeight:      &trustedHeight,
+		ZeroKnowledgeProof: zkp,
+	}
+
+	cs := NewClientState("union-devnet-1337", uint64(testTrustingPeriod), uint64(2*testTrustingPeriod), uint64(10*time.Minute), trustedHeight, nil, nil, nil)
+	consState := NewConsensusState(
+		uint64(header.GetTime().Add(-testHeaderDelay).UnixNano()),
+		commitmenttypes.NewMerkleRoot([]byte("app_hash")),
+		validatorsHash,
+	)
+	ibcKey := storetypes.NewKVStoreKey("ibc")
+	ctx := testutil.DefaultContext(ibcKey, storetypes.NewTransientStoreKey("transient"))
+	clientStore := ctx.KVStore(ibcKey)
+	require.NoError(t, cs.Initialize(ctx, cdc, clientStore, consState))
+	return cs, clientStore, cdc, header
+}
+
+func atTime(blockTime time.Time) sdk.Context {
+	return sdk.Context{}.WithBlockTime(blockTime)
+}
+
+func TestVerifyHeaderTrustingPeriod(t *testing.T) {
+	cs, clientStore, cdc, header := newTestClient(t)
+	expiry := header.GetTime().Add(-testHeaderDelay).Add(testTrustingPeriod)
+
+	tests := []struct {
+		description string
+		blockTime   time.Time
+		expired     bool
+	}{
+		{"header time", header.GetTime(), false},
+		{"just before the expiry", expiry.Add(-time.Nanosecond), false},
+		{"at the expiry", expiry, true},
+		{"just after the expiry", expiry.Add(time.Nanosecond), true},
+		{"long after the expiry", expiry.Add(365 * 24 * time.Hour), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := cs.VerifyClientMessage(atTime(tt.blockTime), cdc, clientStore, header)
+			if tt.expired {
+				require.ErrorIs(t, err, ErrTrustingPeriodExpired)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
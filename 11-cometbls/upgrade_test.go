@@ -0,0 +1,284 @@
+package cometbls
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+	upgradetypes "cosmossdk.io/x/upgrade/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v8/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	ibcerrors "github.com/cosmos/ibc-go/v8/modules/core/errors"
+)
+
+// The upgrade store of the counterparty chain, committing to the upgraded
+// client and consensus state as x/upgrade does.
+type upgradeStore struct {
+	cms *rootmulti.Store
+	key *storetypes.KVStoreKey
+}
+
+func newUpgradeStore(t *testing.T) *upgradeStore {
+	cms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	key := storetypes.NewKVStoreKey(upgradetypes.StoreKey)
+	cms.MountStoreWithDB(key, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, cms.LoadLatestVersion())
+	return &upgradeStore{cms: cms, key: key}
+}
+
+// Commit the values and return the app hash with the proofs of their keys.
+func (s *upgradeStore) commit(t *testing.T, values map[string][]byte) ([]byte, map[string][]byte) {
+	store := s.cms.GetKVStore(s.key)
+	for key, value := range values {
+		store.Set([]byte(key), value)
+	}
+	commitID := s.cms.Commit()
+	proofs := make(map[string][]byte)
+	for key := range values {
+		res, err := s.cms.Query(&storetypes.RequestQuery{
+			Path:   fmt.Sprintf("/%s/key", upgradetypes.StoreKey),
+			Data:   []byte(key),
+			Height: commitID.Version,
+			Prove:  true,
+		})
+		require.NoError(t, err)
+		merkleProof, err := commitmenttypes.ConvertProofs(res.ProofOps)
+		require.NoError(t, err)
+		proofs[key], err = merkleProof.Marshal()
+		require.NoError(t, err)
+	}
+	return commitID.Hash, proofs
+}
+
+func storedClientState(t *testing.T, clientStore storetypes.KVStore, cdc codec.BinaryCodec) *ClientState {
+	bz := clientStore.Get(host.ClientStateKey())
+	require.NotNil(t, bz)
+	clientState, ok := clienttypes.MustUnmarshalClientState(cdc, bz).(*ClientState)
+	require.True(t, ok)
+	return clientState
+}
+
+func upgradeKey(lastHeight uint64, key string) string {
+	return fmt.Sprintf("%s/%d/%s", upgradetypes.KeyUpgradedIBCState, lastHeight, key)
+}
+
+func TestVerifyUpgradeAndUpdateState(t *testing.T) {
+	cdc := newTestCodec()
+
+	const (
+		trustingPeriod  = uint64(10 * time.Hour)
+		unbondingPeriod = uint64(20 * time.Hour)
+		maxClockDrift   = uint64(time.Minute)
+	)
+	lastHeight := clienttypes.NewHeight(1, 100)
+	nextValidatorsHash := bytes.Repeat([]byte{0x42}, 32)
+
+	var (
+		cs                    *ClientState
+		upgradedClient        *ClientState
+		upgradedConsState     *ConsensusState
+		committedClient       []byte
+		committedConsState    []byte
+		upgradeClientProof    []byte
+		upgradeConsStateProof []byte
+	)
+
+	// The counterparty commits the upgraded client and consensus state at the
+	// last height of the current revision, the client is at this height.
+	setup := func(t *testing.T, malleate func()) (sdk.Context, storetypes.KVStore) {
+		ibcKey := storetypes.NewKVStoreKey("ibc")
+		ctx := testutil.DefaultContext(ibcKey, storetypes.NewTransientStoreKey("transient"))
+		ctx = ctx.WithBlockTime(time.Unix(1710783278, 0)).WithBlockHeight(42).WithChainID("union-1")
+		clientStore := ctx.KVStore(ibcKey)
+
+		cs = NewClientState("union-devnet-1", trustingPeriod, unbondingPeriod, maxClockDrift, lastHeight, nil, nil, nil)
+		upgradedClient = NewClientState("union-devnet-2", trustingPeriod, 2*unbondingPeriod, maxClockDrift, clienttypes.NewHeight(2, 1), nil, nil, nil)
+		upgradedConsState = NewConsensusState(uint64(ctx.BlockTime().UnixNano()), commitmenttypes.NewMerkleRoot([]byte("app_hash")), nextValidatorsHash)
+
+		var err error
+		committedClient, err = cdc.MarshalInterface(upgradedClient.ZeroCustomFields())
+		require.NoError(t, err)
+		committedConsState, err = cdc.MarshalInterface(upgradedConsState)
+		require.NoError(t, err)
+
+		if malleate != nil {
+			malleate()
+		}
+
+		clientKey := upgradeKey(lastHeight.RevisionHeight, upgradetypes.KeyUpgradedClient)
+		consStateKey := upgradeKey(lastHeight.RevisionHeight, upgradetypes.KeyUpgradedConsState)
+		appHash, proofs := newUpgradeStore(t).commit(t, map[string][]byte{
+			clientKey:    committedClient,
+			consStateKey: committedConsState,
+		})
+		upgradeClientProof, upgradeConsStateProof = proofs[clientKey], proofs[consStateKey]
+
+		consState := NewConsensusState(uint64(ctx.BlockTime().Add(-time.Hour).UnixNano()), commitmenttypes.NewMerkleRoot(appHash), nextValidatorsHash)
+		require.NoError(t, cs.Initialize(ctx, cdc, clientStore, consState))
+		return ctx, clientStore
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctx, clientStore := setup(t, nil)
+		// Relayer chosen parameters are ignored
+		upgradedClient.TrustingPeriod = 1
+		upgradedClient.MaxClockDrift = 1
+
+		err := cs.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, upgradedClient, upgradedConsState, upgradeClientProof, upgradeConsStateProof)
+		require.NoError(t, err)
+
+		newClientState := storedClientState(t, clientStore, cdc)
+		require.Equal(t, "union-devnet-2", newClientState.ChainId)
+		require.Equal(t, clienttypes.NewHeight(2, 1), newClientState.LatestHeight)
+		require.Equal(t, 2*unbondingPeriod, newClientState.UnbondingPeriod)
+		require.Equal(t, trustingPeriod, newClientState.TrustingPeriod)
+		require.Equal(t, maxClockDrift, newClientState.MaxClockDrift)
+		require.True(t, newClientState.FrozenHeight.IsZero())
+
+		newConsState, found := GetConsensusState(clientStore, cdc, newClientState.LatestHeight)
+		require.True(t, found)
+		require.Equal(t, []byte(SentinelRoot), newConsState.Root.GetHash())
+		require.Equal(t, upgradedConsState.Timestamp, newConsState.Timestamp)
+		require.Equal(t, nextValidatorsHash, []byte(newConsState.NextValidatorsHash))
+		processedTime, found := GetProcessedTime(clientStore, newClientState.LatestHeight)
+		require.True(t, found)
+		require.Equal(t, uint64(ctx.BlockTime().UnixNano()), processedTime)
+	})
+
+	t.Run("verifying key and upgrade path of the upgraded client", func(t *testing.T) {
+		ctx, clientStore := setup(t, func() {
+			cs.UpgradePath = []string{upgradetypes.StoreKey, upgradetypes.KeyUpgradedIBCState}
+			upgradedClient.VerifyingKey = otherVerifyingKey(t, 1)
+			fingerprint := sha256.Sum256(upgradedClient.VerifyingKey)
+			upgradedClient.VerifyingKeyFingerprint = fingerprint[:]
+			upgradedClient.UpgradePath = []string{upgradetypes.StoreKey, "newUpgradedIBCState"}
+			var err error
+			committedClient, err = cdc.MarshalInterface(upgradedClient.ZeroCustomFields())
+			require.NoError(t, err)
+		})
+
+		err := cs.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, upgradedClient, upgradedConsState, upgradeClientProof, upgradeConsStateProof)
+		require.NoError(t, err)
+
+		newClientState := storedClientState(t, clientStore, cdc)
+		require.Equal(t, upgradedClient.VerifyingKeyFingerprint, newClientState.VerifyingKeyFingerprint)
+		// The key is moved to the client store
+		require.Nil(t, newClientState.VerifyingKey)
+		require.Equal(t, upgradedClient.VerifyingKey, clientStore.Get(VerifyingKeyKey(KeyVerifyingKeyPrefix, upgradedClient.VerifyingKeyFingerprint)))
+		_, err = newClientState.GetVerifyingKey(clientStore)
+		require.NoError(t, err)
+		require.Equal(t, upgradedClient.UpgradePath, newClientState.UpgradePath)
+	})
+
+	tests := []struct {
+		description string
+		malleate    func()
+		// Run after the commitment, to alter what the relayer submits
+		submit func()
+		err    error
+	}{
+		{
+			description: "upgraded height not greater than the client height",
+			submit: func() {
+				upgradedClient.LatestHeight = lastHeight
+			},
+			err: ibcerrors.ErrInvalidHeight,
+		},
+		{
+			description: "client state not committed",
+			submit: func() {
+				upgradedClient.UnbondingPeriod++
+			},
+			err: commitmenttypes.ErrInvalidProof,
+		},
+		{
+			description: "consensus state not committed",
+			submit: func() {
+				upgradedConsState.Timestamp++
+			},
+			err: commitmenttypes.ErrInvalidProof,
+		},
+		{
+			description: "proofs swapped",
+			submit: func() {
+				upgradeClientProof, upgradeConsStateProof = upgradeConsStateProof, upgradeClientProof
+			},
+			err: commitmenttypes.ErrInvalidProof,
+		},
+		{
+			description: "malformed proof",
+			submit: func() {
+				upgradeClientProof = []byte("proof")
+			},
+			err: commitmenttypes.ErrInvalidProof,
+		},
+		{
+			description: "committed under another upgrade path",
+			malleate: func() {
+				cs.UpgradePath = []string{upgradetypes.StoreKey, "otherUpgradedIBCState"}
+			},
+			err: commitmenttypes.ErrInvalidProof,
+		},
+		{
+			description: "upgraded unbonding period below the trusting period",
+			malleate: func() {
+				upgradedClient.UnbondingPeriod = trustingPeriod
+				var err error
+				committedClient, err = cdc.MarshalInterface(upgradedClient.ZeroCustomFields())
+				require.NoError(t, err)
+			},
+			err: ErrInvalidTrustingPeriod,
+		},
+		{
+			description: "upgraded client with an unknown verifying key",
+			malleate: func() {
+				upgradedClient.VerifyingKeyFingerprint = bytes.Repeat([]byte{0xAB}, VerifyingKeyFingerprintSize)
+				var err error
+				committedClient, err = cdc.MarshalInterface(upgradedClient.ZeroCustomFields())
+				require.NoError(t, err)
+			},
+			err: ErrUnknownVerifyingKey,
+		},
+		{
+			description: "upgraded client with a verifying key not matching its fingerprint",
+			malleate: func() {
+				upgradedClient.VerifyingKey = otherVerifyingKey(t, 1)
+				upgradedClient.VerifyingKeyFingerprint = DefaultVerifyingKeyFingerprint
+				var err error
+				committedClient, err = cdc.MarshalInterface(upgradedClient.ZeroCustomFields())
+				require.NoError(t, err)
+			},
+			err: ErrInvalidVerifyingKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			ctx, clientStore := setup(t, tt.malleate)
+			if tt.submit != nil {
+				tt.submit()
+			}
+
+			err := cs.VerifyUpgradeAndUpdateState(ctx, cdc, clientStore, upgradedClient, upgradedConsState, upgradeClientProof, upgradeConsStateProof)
+			require.ErrorIs(t, err, tt.err)
+
+			// The client is left untouched
+			require.Equal(t, cs, storedClientState(t, clientStore, cdc))
+		})
+	}
+}
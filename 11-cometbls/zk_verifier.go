@@ -8,13 +8,14 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"time"
 
+	errorsmod "cosmossdk.io/errors"
 	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
-	backend "github.com/consensys/gnark/backend/groth16"
 	backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
 	"github.com/holiman/uint256"
+	"github.com/unionlabs/union/11-cometbls/inputshash"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -22,6 +23,7 @@ const (
 	FQ_SIZE         = 32
 	G1_SIZE         = 2 * FQ_SIZE
 	G2_SIZE         = 2 * G1_SIZE
+	ZKP_SIZE        = G1_SIZE + G2_SIZE + G1_SIZE + G1_SIZE + G1_SIZE
 	CometblsHMACKey = "CometBLS"
 )
 
@@ -41,16 +43,8 @@ type ZKP struct {
 	ProofCommitmentPoK curve.G1Affine
 }
 
-type ProverLightHeader struct {
-	ChainId            string
-	Height             int64
-	Time               time.Time
-	ValidatorsHash     []byte
-	NextValidatorsHash []byte
-	AppHash            []byte
-}
-
-var verifyingKey backend_bn254.VerifyingKey
+// The light header the inputs hash is computed from, shared with the prover
+type ProverLightHeader = inputshash.LightHeader
 
 func init() {
 	vkHex := "8967072901cc7ab63357f1ddc4196c7c1feda50540d8026d7f6f0167c118a899d923def15f75234f2a6d53b566a2528441e98050b38803673e9179b834fc39a499355fd270b7601d5d88408b7e9e53d260512e2180cd260017dc941f2fc96d65153f0344c6bf2d8a891b979bc61d39a98fb11155fcd57418f30ea018ea842874a0e76be91a3148e2f8ef644222b3ce5b939a73bd2e0a40814f7f92a79c483acf2216bbe0c289e07936b4d9653b91521a24c570c808fa46dfd12ec4429e71b61999fcfb245459d63a4923b8f8c488d1e6af7ca358867b88eb0cdefe896c221f09e95e4c18d1e0475de4549b2547611d8301e1afff1047a6f5a288c9314af0b9fc05d403c8c91820a385a72c18d6a4962cef41a3ab93daa7ed289b1e95db4d04eb00000003e71843e52743864f4bb67ce94a2ce8fe82c8f61042c4c1ced8531d94305392818b0dbe71f4d60e02e9160ec2b015cae3a09cbe4f437226e2c02e1a5e5d124bcac29e93d5f47c0c7671350398ed8c40f5bc5c2f5b00363c7e2eb18a91a1c490c70000000100000000a57df6f8132cb0037f7dfdf1a29b04c1ff92ba082eda513996ba2bfa9fbd198713f0d8d8879885ca567ef99298c30c397e6fba584658f4127713a814c06de55aefbfe141a7555cf7e3e86b092660b81cfb68a025ad817e45cec0b0f2e2ca636802a104df1c015f2307fa2859627098cdf9fdb521d61d323943343a12304e5baf"
@@ -59,36 +53,112 @@ func init() {
 		panic(fmt.Sprintf("could not decode the hex verifying key: '%s'", vkHex))
 	}
 
-	_, err = backend.VerifyingKey(&verifyingKey).ReadFrom(bytes.NewReader(vk))
-	if err != nil {
+	defaultVerifyingKey = &backend_bn254.VerifyingKey{}
+	if _, err := defaultVerifyingKey.ReadFrom(bytes.NewReader(vk)); err != nil {
 		panic(fmt.Sprintf("could not read the verifying key: '%s'", vkHex))
 	}
+
+	DefaultVerifyingKeyFingerprint, err = VerifyingKeyFingerprint(defaultVerifyingKey)
+	if err != nil {
+		panic(fmt.Sprintf("could not fingerprint the verifying key: '%s'", vkHex))
+	}
+}
+
+// Field elements are big-endian and must be reduced.
+func parseFq(data []byte) (fp.Element, error) {
+	var e fp.Element
+	err := e.SetBytesCanonical(data)
+	return e, err
+}
+
+// Points are raw (X, Y) coordinates as expected by the EVM precompiles, which
+// encode the point at infinity as (0, 0). Unlike SetBytes, no bits are
+// interpreted as gnark compression flags.
+func parseG1(data []byte) (curve.G1Affine, error) {
+	var p curve.G1Affine
+	var err error
+	if p.X, err = parseFq(data[0:FQ_SIZE]); err != nil {
+		return p, err
+	}
+	if p.Y, err = parseFq(data[FQ_SIZE:G1_SIZE]); err != nil {
+		return p, err
+	}
+	if !p.IsOnCurve() {
+		return p, errors.New("point is not on the curve")
+	}
+	if !p.IsInSubGroup() {
+		return p, errors.New("point is not in the subgroup")
+	}
+	return p, nil
+}
+
+// The imaginary part of the coordinates comes first.
+func parseG2(data []byte) (curve.G2Affine, error) {
+	var p curve.G2Affine
+	coordinates := []*fp.Element{&p.X.A1, &p.X.A0, &p.Y.A1, &p.Y.A0}
+	for i, coordinate := range coordinates {
+		var err error
+		if *coordinate, err = parseFq(data[i*FQ_SIZE : (i+1)*FQ_SIZE]); err != nil {
+			return p, err
+		}
+	}
+	if !p.IsOnCurve() {
+		return p, errors.New("point is not on the curve")
+	}
+	if !p.IsInSubGroup() {
+		return p, errors.New("point is not in the subgroup")
+	}
+	return p, nil
 }
 
 func ParseZKP(data []byte) (*ZKP, error) {
+	if len(data) != ZKP_SIZE {
+		return nil, errorsmod.Wrapf(ErrInvalidProof, "expected %d bytes, got %d", ZKP_SIZE, len(data))
+	}
 
 	zkp := ZKP{}
 
 	cursor := 0
 
-	zkp.Proof.A.SetBytes(data[0:G1_SIZE])
+	var err error
+	zkp.Proof.A, err = parseG1(data[cursor : cursor+G1_SIZE])
+	if err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidProof, "A: %v", err)
+	}
 	cursor += G1_SIZE
 
-	zkp.Proof.B.SetBytes(data[cursor : cursor+G2_SIZE])
+	zkp.Proof.B, err = parseG2(data[cursor : cursor+G2_SIZE])
+	if err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidProof, "B: %v", err)
+	}
 	cursor += G2_SIZE
 
-	zkp.Proof.C.SetBytes(data[cursor : cursor+G1_SIZE])
+	zkp.Proof.C, err = parseG1(data[cursor : cursor+G1_SIZE])
+	if err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidProof, "C: %v", err)
+	}
 	cursor += G1_SIZE
 
-	zkp.ProofCommitment.SetBytes(data[cursor : cursor+G1_SIZE])
+	zkp.ProofCommitment, err = parseG1(data[cursor : cursor+G1_SIZE])
+	if err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidProof, "commitment: %v", err)
+	}
 	cursor += G1_SIZE
 
-	zkp.ProofCommitmentPoK.SetBytes(data[cursor : cursor+G1_SIZE])
+	zkp.ProofCommitmentPoK, err = parseG1(data[cursor : cursor+G1_SIZE])
+	if err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidProof, "commitment PoK: %v", err)
+	}
 
 	return &zkp, nil
 }
 
 func (zkp ZKP) Verify(trustedValidatorsHash []byte, header ProverLightHeader) error {
+	return zkp.VerifyWithKey(defaultVerifyingKey, trustedValidatorsHash, header)
+}
+
+// Verify the proof against the given verifying key, the one the client trusts.
+func (zkp ZKP) VerifyWithKey(verifyingKey *backend_bn254.VerifyingKey, trustedValidatorsHash []byte, header ProverLightHeader) error {
 	if len(header.ChainId) > 31 {
 		return errors.New("chain id length cannot be larger than 31")
 	}
@@ -188,30 +258,7 @@ func commitmentsHash(proofCommitment curve.G1Affine) fr.Element {
 }
 
 func inputsHash(header ProverLightHeader, trustedValidatorsHash []byte) fr.Element {
-	buff := []byte{}
-	var padded [32]byte
-	writeI64 := func(x int64) {
-		big.NewInt(x).FillBytes(padded[:])
-		buff = append(buff, padded[:]...)
-	}
-	writeMiMCHash := func(b []byte) {
-		big.NewInt(0).SetBytes(b).FillBytes(padded[:])
-		buff = append(buff, padded[:]...)
-	}
-	writeHash := func(b []byte) {
-		buff = append(buff, b...)
-	}
-	writeMiMCHash([]byte(header.ChainId))
-	writeI64(header.Height)
-	writeI64(header.Time.Unix())
-	writeI64(int64(header.Time.Nanosecond()))
-	writeMiMCHash(header.ValidatorsHash)
-	writeMiMCHash(header.NextValidatorsHash)
-	writeHash(header.AppHash)
-	writeMiMCHash(trustedValidatorsHash)
-	hash := sha256.Sum256(buff)
-
 	var e fr.Element
-	e.SetBytes(hash[1:])
+	e.SetBytes(inputshash.Hash(header, trustedValidatorsHash))
 	return e
 }
@@ -0,0 +1,139 @@
+package cometbls
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// The groth16 verifying key a client trusts is named by its state, hence the
+// same on all the nodes whatever their binary, and only changes by
+// governance, through a client upgrade or substitution. The key is stored in
+// the client store under its fingerprint, the clients which don't name one
+// trust the key embedded in the module.
+var (
+	defaultVerifyingKey *backend_bn254.VerifyingKey
+	// Fingerprint of the verifying key embedded in the client, trusted by the
+	// clients which don't name one.
+	DefaultVerifyingKeyFingerprint []byte
+)
+
+const VerifyingKeyFingerprintSize = sha256.Size
+
+// The sha256 of the compressed encoding of the key. It matches the verifying
+// key hash of the manifest produced by galoisd when extracting the keys.
+func VerifyingKeyFingerprint(vk *backend_bn254.VerifyingKey) ([]byte, error) {
+	hasher := sha256.New()
+	if _, err := vk.WriteTo(hasher); err != nil {
+		return nil, fmt.Errorf("could not encode the verifying key: %w", err)
+	}
+	return hasher.Sum(nil), nil
+}
+
+// Walks the compressed gnark encoding of a key before it's decoded. The
+// decoder allocates the slices from their length prefix before reading them,
+// which must hence be bounded by the remaining data. All the points must be
+// compressed, so that the decoder follows the same layout.
+type compressedLayout struct {
+	data   []byte
+	cursor int
+	err    error
+}
+
+func (l *compressedLayout) fail(format string, args ...any) {
+	if l.err == nil {
+		l.err = fmt.Errorf(format, args...)
+	}
+}
+
+func (l *compressedLayout) skip(size int) {
+	if l.err != nil {
+		return
+	}
+	if size > len(l.data)-l.cursor {
+		l.fail("expected %d bytes at offset %d, got %d", size, l.cursor, len(l.data)-l.cursor)
+		return
+	}
+	l.cursor += size
+}
+
+func (l *compressedLayout) points(n int, size int) {
+	for i := 0; i < n && l.err == nil; i++ {
+		// The two most significant bits are the compression flags, both unset
+		// for an uncompressed point
+		if l.cursor < len(l.data) && l.data[l.cursor]>>6 == 0 {
+			l.fail("point at offset %d is not compressed", l.cursor)
+			return
+		}
+		l.skip(size)
+	}
+}
+
+// The length prefix of a slice, whose elements are at least of the given size.
+func (l *compressedLayout) length(elementSize int) int {
+	offset := l.cursor
+	l.skip(4)
+	if l.err != nil {
+		return 0
+	}
+	n := binary.BigEndian.Uint32(l.data[offset:l.cursor])
+	if uint64(n)*uint64(elementSize) > uint64(len(l.data)-l.cursor) {
+		l.fail("length %d at offset %d exceeds the %d remaining bytes", n, offset, len(l.data)-l.cursor)
+		return 0
+	}
+	return int(n)
+}
+
+func (l *compressedLayout) done() error {
+	if l.err == nil && l.cursor != len(l.data) {
+		l.fail("trailing bytes after the key: %d", len(l.data)-l.cursor)
+	}
+	return l.err
+}
+
+// Parse a groth16 verifying key as exported by galoisd. Only the compressed
+// encoding is accepted, so that the fingerprint of the key is the sha256 of
+// its bytes.
+func ParseVerifyingKey(data []byte) (*backend_bn254.VerifyingKey, error) {
+	// [α]1,[β]1,[β]2,[γ]2,[δ]1,[δ]2,[K]1,public and commitment committed,
+	// then the pedersen key g,gRootSigmaNeg
+	layout := compressedLayout{data: data}
+	layout.points(2, curve.SizeOfG1AffineCompressed)
+	layout.points(2, curve.SizeOfG2AffineCompressed)
+	layout.points(1, curve.SizeOfG1AffineCompressed)
+	layout.points(1, curve.SizeOfG2AffineCompressed)
+	layout.points(layout.length(curve.SizeOfG1AffineCompressed), curve.SizeOfG1AffineCompressed)
+	for i := layout.length(4); i > 0; i-- {
+		layout.skip(8 * layout.length(8))
+	}
+	layout.points(2, curve.SizeOfG2AffineCompressed)
+	if err := layout.done(); err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidVerifyingKey, "could not read the verifying key: %v", err)
+	}
+
+	var vk backend_bn254.VerifyingKey
+	n, err := vk.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidVerifyingKey, "could not read the verifying key: %v", err)
+	}
+	if n != int64(len(data)) {
+		return nil, errorsmod.Wrapf(ErrInvalidVerifyingKey, "trailing bytes after the verifying key: %d", int64(len(data))-n)
+	}
+	// The constant, the inputs hash and the commitment hash
+	if len(vk.G1.K) != 3 {
+		return nil, errorsmod.Wrapf(ErrInvalidVerifyingKey, "the verifying key must have a single public input and a commitment, got %d points", len(vk.G1.K))
+	}
+	var compressed bytes.Buffer
+	if _, err := vk.WriteTo(&compressed); err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidVerifyingKey, "could not encode the verifying key: %v", err)
+	}
+	if !bytes.Equal(compressed.Bytes(), data) {
+		return nil, errorsmod.Wrap(ErrInvalidVerifyingKey, "the verifying key must be in the compressed encoding")
+	}
+	return &vk, nil
+}
@@ -0,0 +1,209 @@
+package cometbls
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	storetypes "cosmossdk.io/store/types"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v8/modules/core/23-commitment/types"
+	"github.com/stretchr/testify/require"
+)
+
+func compressedVerifyingKey(t *testing.T, vk *backend_bn254.VerifyingKey) []byte {
+	var buf bytes.Buffer
+	_, err := vk.WriteTo(&buf)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+// The embedded key with its alpha doubled i times, standing for the key of
+// another ceremony.
+func otherVerifyingKey(t *testing.T, i int) []byte {
+	var vk backend_bn254.VerifyingKey
+	_, err := vk.ReadFrom(bytes.NewReader(compressedVerifyingKey(t, defaultVerifyingKey)))
+	require.NoError(t, err)
+	for ; i > 0; i-- {
+		vk.G1.Alpha.Double(&vk.G1.Alpha)
+	}
+	return compressedVerifyingKey(t, &vk)
+}
+
+// [α]1,[β]1,[β]2,[γ]2,[δ]1,[δ]2 before the length prefix of [K]1
+const fixedVerifyingKeySize = 3*curve.SizeOfG1AffineCompressed + 3*curve.SizeOfG2AffineCompressed
+
+func TestParseVerifyingKey(t *testing.T) {
+	rawVK := compressedVerifyingKey(t, defaultVerifyingKey)
+	vk, err := ParseVerifyingKey(rawVK)
+	require.NoError(t, err)
+	fingerprint, err := VerifyingKeyFingerprint(vk)
+	require.NoError(t, err)
+	require.Equal(t, DefaultVerifyingKeyFingerprint, fingerprint)
+	// The fingerprint of a key is the hash of its encoding
+	hash := sha256.Sum256(rawVK)
+	require.Equal(t, DefaultVerifyingKeyFingerprint, hash[:])
+
+	var uncompressed bytes.Buffer
+	_, err = defaultVerifyingKey.WriteRawTo(&uncompressed)
+	require.NoError(t, err)
+	_, err = ParseVerifyingKey(uncompressed.Bytes())
+	require.ErrorIs(t, err, ErrInvalidVerifyingKey)
+	require.ErrorContains(t, err, "point at offset 0 is not compressed")
+
+	_, err = ParseVerifyingKey(append(bytes.Clone(rawVK), 0))
+	require.ErrorIs(t, err, ErrInvalidVerifyingKey)
+	require.ErrorContains(t, err, "trailing bytes after the key: 1")
+	_, err = ParseVerifyingKey(rawVK[:len(rawVK)/2])
+	require.ErrorIs(t, err, ErrInvalidVerifyingKey)
+	require.ErrorContains(t, err, "could not read the verifying key")
+
+	// The length prefixes are bounded by the data before anything is allocated
+	oversized := bytes.Clone(rawVK[:fixedVerifyingKeySize])
+	oversized = append(oversized, 0xFF, 0xFF, 0xFF, 0xFF)
+	_, err = ParseVerifyingKey(oversized)
+	require.ErrorIs(t, err, ErrInvalidVerifyingKey)
+	require.ErrorContains(t, err, "length 4294967295 at offset 288 exceeds the 0 remaining bytes")
+}
+
+// A client store of its own, with the context it's in.
+func newTestClientStore() (sdk.Context, storetypes.KVStore) {
+	ibcKey := storetypes.NewKVStoreKey("ibc")
+	ctx := testutil.DefaultContext(ibcKey, storetypes.NewTransientStoreKey("transient"))
+	return ctx, ctx.KVStore(ibcKey)
+}
+
+func TestClientStateVerifyingKey(t *testing.T) {
+	cdc := newTestCodec()
+	otherVK := otherVerifyingKey(t, 1)
+	otherFingerprint := sha256.Sum256(otherVK)
+	otherKey := VerifyingKeyKey(KeyVerifyingKeyPrefix, otherFingerprint[:])
+	newClientState := func(height uint64, verifyingKey []byte) *ClientState {
+		return NewClientState("union-devnet-1337", 10, 20, 5, clienttypes.NewHeight(1337, height), verifyingKey, nil, nil)
+	}
+	consState := NewConsensusState(uint64(time.Unix(1710783278, 0).UnixNano()), commitmenttypes.NewMerkleRoot([]byte("app_hash")), bytes.Repeat([]byte{0x42}, 32))
+	ctx, clientStore := newTestClientStore()
+
+	cs := newClientState(1, nil)
+	require.NoError(t, cs.Validate())
+	require.Equal(t, DefaultVerifyingKeyFingerprint, cs.GetVerifyingKeyFingerprint())
+	vk, err := cs.GetVerifyingKey(clientStore)
+	require.NoError(t, err)
+	require.Same(t, defaultVerifyingKey, vk)
+
+	// The embedded key can be named without being carried, and is never stored
+	cs.VerifyingKeyFingerprint = DefaultVerifyingKeyFingerprint
+	require.NoError(t, cs.Validate())
+	vk, err = cs.GetVerifyingKey(clientStore)
+	require.NoError(t, err)
+	require.Same(t, defaultVerifyingKey, vk)
+	cs.VerifyingKey = compressedVerifyingKey(t, defaultVerifyingKey)
+	require.NoError(t, cs.Validate())
+	require.NoError(t, cs.storeVerifyingKeys(clientStore))
+	require.Nil(t, cs.VerifyingKey)
+	require.Nil(t, clientStore.Get(VerifyingKeyKey(KeyVerifyingKeyPrefix, DefaultVerifyingKeyFingerprint)))
+
+	// Another key is moved to the client store, only its fingerprint is kept
+	// in the client state
+	cs = newClientState(1, otherVK)
+	require.NoError(t, cs.Validate())
+	require.Equal(t, otherFingerprint[:], cs.GetVerifyingKeyFingerprint())
+	_, err = cs.GetVerifyingKey(clientStore)
+	require.ErrorIs(t, err, ErrUnknownVerifyingKey)
+	require.NoError(t, cs.Initialize(ctx, cdc, clientStore, consState))
+	require.Equal(t, otherVK, clientStore.Get(otherKey))
+	stored := storedClientState(t, clientStore, cdc)
+	require.Nil(t, stored.VerifyingKey)
+	require.Equal(t, otherFingerprint[:], stored.VerifyingKeyFingerprint)
+	require.Contains(t, stored.ExportMetadata(clientStore), clienttypes.NewGenesisMetadata(otherKey, otherVK))
+	vk, err = stored.GetVerifyingKey(clientStore)
+	require.NoError(t, err)
+	fingerprint, err := VerifyingKeyFingerprint(vk)
+	require.NoError(t, err)
+	require.Equal(t, otherFingerprint[:], fingerprint)
+
+	// A key named without being carried must already be stored
+	require.NoError(t, stored.Validate())
+	require.NoError(t, stored.storeVerifyingKeys(clientStore))
+	_, emptyStore := newTestClientStore()
+	require.ErrorIs(t, stored.storeVerifyingKeys(emptyStore), ErrUnknownVerifyingKey)
+	_, err = stored.GetVerifyingKey(emptyStore)
+	require.ErrorIs(t, err, ErrUnknownVerifyingKey)
+
+	tests := []struct {
+		description string
+		malleate    func(cs *ClientState)
+	}{
+		{"truncated fingerprint", func(cs *ClientState) {
+			cs.VerifyingKey, cs.VerifyingKeyFingerprint = nil, DefaultVerifyingKeyFingerprint[1:]
+		}},
+		{"key without fingerprint", func(cs *ClientState) { cs.VerifyingKeyFingerprint = nil }},
+		{"fingerprint of another key", func(cs *ClientState) { cs.VerifyingKeyFingerprint = DefaultVerifyingKeyFingerprint }},
+		{"invalid key", func(cs *ClientState) { cs.VerifyingKey = otherVK[1:] }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cs := newClientState(1, otherVK)
+			tt.malleate(cs)
+			require.ErrorIs(t, cs.Validate(), ErrInvalidVerifyingKey)
+		})
+	}
+
+	t.Run("substitute with another key", func(t *testing.T) {
+		substituteCtx, substituteStore := newTestClientStore()
+		require.NoError(t, newClientState(2, otherVK).Initialize(substituteCtx, cdc, substituteStore, consState))
+		substitute := storedClientState(t, substituteStore, cdc)
+
+		ctx, subjectStore := newTestClientStore()
+		subject := newClientState(1, nil)
+		require.NoError(t, subject.Initialize(ctx, cdc, subjectStore, consState))
+		require.True(t, IsMatchingClientState(*subject, *substitute))
+		require.NoError(t, subject.CheckSubstituteAndUpdateState(ctx, cdc, subjectStore, substituteStore, substitute))
+
+		// The subject takes over the key, stored once in its own store
+		substituted := storedClientState(t, subjectStore, cdc)
+		require.Nil(t, substituted.VerifyingKey)
+		require.Equal(t, otherFingerprint[:], substituted.VerifyingKeyFingerprint)
+		require.Equal(t, otherVK, subjectStore.Get(otherKey))
+		_, err := substituted.GetVerifyingKey(subjectStore)
+		require.NoError(t, err)
+
+		// The key must be in the store of the substitute
+		ctx, subjectStore = newTestClientStore()
+		require.NoError(t, subject.Initialize(ctx, cdc, subjectStore, consState))
+		substituteStore.Delete(otherKey)
+		err = subject.CheckSubstituteAndUpdateState(ctx, cdc, subjectStore, substituteStore, substitute)
+		require.ErrorIs(t, err, ErrUnknownVerifyingKey)
+		require.Equal(t, subject, storedClientState(t, subjectStore, cdc))
+
+		substitute.MaxClockDrift++
+		require.False(t, IsMatchingClientState(*subject, *substitute))
+	})
+}
+
+func TestVerifyHeaderWithClientVerifyingKey(t *testing.T) {
+	cs, clientStore, cdc, header := newTestClient(t)
+	ctx := atTime(header.GetTime())
+
+	// The embedded key, carried by the client
+	withKey := *cs
+	withKey.VerifyingKey = compressedVerifyingKey(t, defaultVerifyingKey)
+	withKey.VerifyingKeyFingerprint = DefaultVerifyingKeyFingerprint
+	require.NoError(t, withKey.Validate())
+	require.NoError(t, withKey.VerifyClientMessage(ctx, cdc, clientStore, header))
+
+	// The proof doesn't verify with the key of another ceremony
+	withOtherKey := NewClientState(cs.ChainId, cs.TrustingPeriod, cs.UnbondingPeriod, cs.MaxClockDrift, cs.LatestHeight, otherVerifyingKey(t, 1), nil, nil)
+	require.NoError(t, withOtherKey.Validate())
+	require.ErrorIs(t, withOtherKey.VerifyClientMessage(ctx, cdc, clientStore, header), ErrUnknownVerifyingKey)
+	require.NoError(t, withOtherKey.storeVerifyingKeys(clientStore))
+	err := withOtherKey.VerifyClientMessage(ctx, cdc, clientStore, header)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrUnknownVerifyingKey)
+}
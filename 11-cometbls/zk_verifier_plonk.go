@@ -0,0 +1,171 @@
+package cometbls
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	errorsmod "cosmossdk.io/errors"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	plonk_bn254 "github.com/consensys/gnark/backend/plonk/bn254"
+)
+
+// The PLONK circuit shares the single public input of the groth16 one, the
+// inputs hash, but has no verifying key embedded: it depends on the universal
+// SRS and the circuit compiled by the prover. Only the clients carrying a PLONK
+// verifying key accept PLONK proofs.
+const (
+	FR_SIZE = 32
+	// The opened polynomials before the BSB22 commitments, in the order of
+	// the claimed values: the linearized polynomial, l, r, o, s1 and s2. The
+	// quotient isn't opened by the gnark fork galoisd proves with
+	plonkOpenedPolynomials = 6
+)
+
+type PlonkZKP struct {
+	Proof plonk_bn254.Proof
+}
+
+// Parse a PLONK verifying key as exported by galoisd for the plonk backend.
+func ParsePlonkVerifyingKey(data []byte) (*plonk_bn254.VerifyingKey, error) {
+	// Size,SizeInv,Generator,NbPublicVariables,CosetShift,[S₁]1,[S₂]1,[S₃]1,
+	// [Ql]1,[Qr]1,[Qm]1,[Qo]1,[Qk]1,[Qcp]1, the KZG key [1]1,[1]2,[α]2 and its
+	// lines, then the commitment constraint indexes
+	layout := compressedLayout{data: data}
+	layout.skip(8 + 2*FR_SIZE + 8 + FR_SIZE)
+	layout.points(8, curve.SizeOfG1AffineCompressed)
+	layout.points(layout.length(curve.SizeOfG1AffineCompressed), curve.SizeOfG1AffineCompressed)
+	layout.points(1, curve.SizeOfG1AffineCompressed)
+	layout.points(2, curve.SizeOfG2AffineCompressed)
+	layout.skip(binary.Size(kzg.VerifyingKey{}.Lines))
+	layout.skip(8 * layout.length(8))
+	if err := layout.done(); err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidVerifyingKey, "could not read the plonk verifying key: %v", err)
+	}
+
+	var vk plonk_bn254.VerifyingKey
+	if _, err := vk.ReadFrom(bytes.NewReader(data)); err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidVerifyingKey, "could not read the plonk verifying key: %v", err)
+	}
+	if vk.NbPublicVariables != 1 {
+		return nil, errorsmod.Wrapf(ErrInvalidVerifyingKey, "the plonk verifying key must have a single public input, got %d", vk.NbPublicVariables)
+	}
+	if len(vk.CommitmentConstraintIndexes) != len(vk.Qcp) {
+		return nil, errorsmod.Wrapf(ErrInvalidVerifyingKey, "the plonk verifying key has %d commitments but %d commitment constraints", len(vk.Qcp), len(vk.CommitmentConstraintIndexes))
+	}
+	var encoded bytes.Buffer
+	if _, err := vk.WriteTo(&encoded); err != nil {
+		return nil, errorsmod.Wrapf(ErrInvalidVerifyingKey, "could not encode the plonk verifying key: %v", err)
+	}
+	if !bytes.Equal(encoded.Bytes(), data) {
+		return nil, errorsmod.Wrap(ErrInvalidVerifyingKey, "the plonk verifying key must be in its canonical encoding")
+	}
+	return &vk, nil
+}
+
+// Size of the raw encoding of a proof for a key with the given number of
+// BSB22 commitments.
+func PlonkZKPSize(nbCommitments int) int {
+	nbClaimedValues := plonkOpenedPolynomials + nbCommitments
+	return 8*G1_SIZE + 4 + nbClaimedValues*FR_SIZE + G1_SIZE + FR_SIZE + 4 + nbCommitments*G1_SIZE
+}
+
+// Parse a PLONK proof in the raw encoding produced by galoisd,
+// [L]1,[R]1,[O]1,[Z]1,[H₁]1,[H₂]1,[H₃]1, the batched opening proof and its
+// claimed values, the opening proof of Z at ζω and its claimed value, then the
+// BSB22 commitments. Its layout is fixed by the key it's verified with.
+func ParsePlonkZKP(vk *plonk_bn254.VerifyingKey, data []byte) (*PlonkZKP, error) {
+	nbCommitments := len(vk.Qcp)
+	if len(data) != PlonkZKPSize(nbCommitments) {
+		return nil, errorsmod.Wrapf(ErrInvalidProof, "expected %d bytes, got %d", PlonkZKPSize(nbCommitments), len(data))
+	}
+
+	zkp := PlonkZKP{}
+	proof := &zkp.Proof
+
+	cursor := 0
+	readG1 := func(name string, p *kzg.Digest) error {
+		point, err := parseG1(data[cursor : cursor+G1_SIZE])
+		if err != nil {
+			return errorsmod.Wrapf(ErrInvalidProof, "%s: %v", name, err)
+		}
+		*p = point
+		cursor += G1_SIZE
+		return nil
+	}
+	readFr := func(name string, e *fr.Element) error {
+		if err := e.SetBytesCanonical(data[cursor : cursor+FR_SIZE]); err != nil {
+			return errorsmod.Wrapf(ErrInvalidProof, "%s: %v", name, err)
+		}
+		cursor += FR_SIZE
+		return nil
+	}
+	readLength := func(name string, expected int) error {
+		n := binary.BigEndian.Uint32(data[cursor : cursor+4])
+		if uint64(n) != uint64(expected) {
+			return errorsmod.Wrapf(ErrInvalidProof, "expected %d %s, got %d", expected, name, n)
+		}
+		cursor += 4
+		return nil
+	}
+
+	points := []struct {
+		name  string
+		point *kzg.Digest
+	}{
+		{"L", &proof.LRO[0]},
+		{"R", &proof.LRO[1]},
+		{"O", &proof.LRO[2]},
+		{"Z", &proof.Z},
+		{"H1", &proof.H[0]},
+		{"H2", &proof.H[1]},
+		{"H3", &proof.H[2]},
+		{"batched opening", &proof.BatchedProof.H},
+	}
+	for _, p := range points {
+		if err := readG1(p.name, p.point); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := readLength("claimed values", plonkOpenedPolynomials+nbCommitments); err != nil {
+		return nil, err
+	}
+	proof.BatchedProof.ClaimedValues = make([]fr.Element, plonkOpenedPolynomials+nbCommitments)
+	for i := range proof.BatchedProof.ClaimedValues {
+		if err := readFr("claimed value", &proof.BatchedProof.ClaimedValues[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := readG1("shifted opening", &proof.ZShiftedOpening.H); err != nil {
+		return nil, err
+	}
+	if err := readFr("shifted claimed value", &proof.ZShiftedOpening.ClaimedValue); err != nil {
+		return nil, err
+	}
+
+	if err := readLength("commitments", nbCommitments); err != nil {
+		return nil, err
+	}
+	proof.Bsb22Commitments = make([]kzg.Digest, nbCommitments)
+	for i := range proof.Bsb22Commitments {
+		if err := readG1("commitment", &proof.Bsb22Commitments[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &zkp, nil
+}
+
+func (zkp PlonkZKP) VerifyWithKey(verifyingKey *plonk_bn254.VerifyingKey, trustedValidatorsHash []byte, header ProverLightHeader) error {
+	if len(header.ChainId) > 31 {
+		return errors.New("chain id length cannot be larger than 31")
+	}
+
+	inpHash := inputsHash(header, trustedValidatorsHash)
+
+	return plonk_bn254.Verify(&zkp.Proof, verifyingKey, fr.Vector{inpHash})
+}
@@ -0,0 +1,247 @@
+package cometbls
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/backend/plonk"
+	plonk_bn254 "github.com/consensys/gnark/backend/plonk/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test/unsafekzg"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Stands for the light client circuit, only the public input matters here.
+type inputsHashCircuit struct {
+	Preimage   frontend.Variable
+	InputsHash frontend.Variable `gnark:",public"`
+}
+
+func (c *inputsHashCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.Preimage, 1), c.InputsHash)
+	return nil
+}
+
+// A PLONK verifying key and the raw proof of the header of the test client.
+func plonkProof(t *testing.T, header ProverLightHeader, trustedValidatorsHash []byte) ([]byte, []byte) {
+	inpHash := inputsHash(header, trustedValidatorsHash)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &inputsHashCircuit{})
+	require.NoError(t, err)
+	srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+	require.NoError(t, err)
+	pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+	require.NoError(t, err)
+
+	witness, err := frontend.NewWitness(&inputsHashCircuit{
+		Preimage:   inpHash,
+		InputsHash: inpHash,
+	}, ecc.BN254.ScalarField())
+	require.NoError(t, err)
+	proof, err := plonk.Prove(ccs, pk, witness)
+	require.NoError(t, err)
+
+	var rawVk bytes.Buffer
+	_, err = vk.WriteTo(&rawVk)
+	require.NoError(t, err)
+	var rawProof bytes.Buffer
+	_, err = proof.(*plonk_bn254.Proof).WriteRawTo(&rawProof)
+	require.NoError(t, err)
+	return rawVk.Bytes(), rawProof.Bytes()
+}
+
+// 8 compressed points at infinity followed by a huge length prefix, which
+// gnark's decoder would allocate before reading anything.
+func oversizedPlonkProof() []byte {
+	infinity := make([]byte, curve.SizeOfG1AffineCompressed)
+	infinity[0] = 0b01 << 6
+	data := bytes.Repeat(infinity, 8)
+	return append(data, 0xFF, 0xFF, 0xFF, 0xFF)
+}
+
+func TestPlonkVerifier(t *testing.T) {
+	cs, clientStore, cdc, header := newTestClient(t)
+	consState, found := GetConsensusState(clientStore, cdc, header.TrustedHeight)
+	require.True(t, found)
+	lightHeader := ProverLightHeader{
+		ChainId:            cs.ChainId,
+		Height:             header.SignedHeader.Height,
+		Time:               header.GetTime(),
+		ValidatorsHash:     header.SignedHeader.ValidatorsHash,
+		NextValidatorsHash: header.SignedHeader.NextValidatorsHash,
+		AppHash:            header.SignedHeader.AppHash,
+	}
+	rawVk, rawProof := plonkProof(t, lightHeader, consState.NextValidatorsHash)
+
+	vk, err := ParsePlonkVerifyingKey(rawVk)
+	require.NoError(t, err)
+	require.Len(t, rawProof, PlonkZKPSize(len(vk.Qcp)))
+	zkp, err := ParsePlonkZKP(vk, rawProof)
+	require.NoError(t, err)
+	require.NoError(t, zkp.VerifyWithKey(vk, consState.NextValidatorsHash, lightHeader))
+
+	lightHeader.Height++
+	require.Error(t, zkp.VerifyWithKey(vk, consState.NextValidatorsHash, lightHeader))
+
+	t.Run("proof layout", func(t *testing.T) {
+		_, err := ParsePlonkZKP(vk, append(bytes.Clone(rawProof), 0))
+		require.ErrorIs(t, err, ErrInvalidProof)
+		require.ErrorContains(t, err, "expected 808 bytes, got 809")
+
+		_, err = ParsePlonkZKP(vk, oversizedPlonkProof())
+		require.ErrorIs(t, err, ErrInvalidProof)
+		require.ErrorContains(t, err, "expected 808 bytes, got 260")
+
+		// The length prefixes are fixed by the key
+		tampered := bytes.Clone(rawProof)
+		binary.BigEndian.PutUint32(tampered[8*G1_SIZE:], 8)
+		_, err = ParsePlonkZKP(vk, tampered)
+		require.ErrorIs(t, err, ErrInvalidProof)
+		require.ErrorContains(t, err, "expected 6 claimed values, got 8")
+
+		tampered = bytes.Clone(rawProof)
+		tampered[0] |= 0b01 << 6
+		_, err = ParsePlonkZKP(vk, tampered)
+		require.ErrorIs(t, err, ErrInvalidProof)
+		require.ErrorContains(t, err, "L:")
+	})
+
+	t.Run("verifying key", func(t *testing.T) {
+		_, err := ParsePlonkVerifyingKey(append(bytes.Clone(rawVk), 0))
+		require.ErrorIs(t, err, ErrInvalidVerifyingKey)
+		require.ErrorContains(t, err, "trailing bytes after the key: 1")
+
+		// Size,SizeInv,Generator,NbPublicVariables,CosetShift and 8 points
+		// before the length prefix of Qcp
+		qcpOffset := 8 + 2*FR_SIZE + 8 + FR_SIZE + 8*curve.SizeOfG1AffineCompressed
+		oversized := append(bytes.Clone(rawVk[:qcpOffset]), 0xFF, 0xFF, 0xFF, 0xFF)
+		_, err = ParsePlonkVerifyingKey(oversized)
+		require.ErrorIs(t, err, ErrInvalidVerifyingKey)
+		require.ErrorContains(t, err, "exceeds the 0 remaining bytes")
+
+		twoInputs := bytes.Clone(rawVk)
+		binary.BigEndian.PutUint64(twoInputs[8+2*FR_SIZE:], 2)
+		_, err = ParsePlonkVerifyingKey(twoInputs)
+		require.ErrorIs(t, err, ErrInvalidVerifyingKey)
+		require.ErrorContains(t, err, "the plonk verifying key must have a single public input, got 2")
+	})
+
+	t.Run("client verifying key", func(t *testing.T) {
+		plonkHeader := *header
+		plonkHeader.ProofType = ProofTypePlonk
+		plonkHeader.ZeroKnowledgeProof = rawProof
+		ctx := atTime(header.GetTime())
+
+		// PLONK proofs are rejected before being parsed by the clients
+		// without a PLONK key
+		err := cs.VerifyClientMessage(ctx, cdc, clientStore, &plonkHeader)
+		require.ErrorIs(t, err, ErrUnknownVerifyingKey)
+		_, err = cs.GetPlonkVerifyingKey(clientStore)
+		require.ErrorIs(t, err, ErrUnknownVerifyingKey)
+		plonkHeader.ZeroKnowledgeProof = oversizedPlonkProof()
+		require.ErrorIs(t, cs.VerifyClientMessage(ctx, cdc, clientStore, &plonkHeader), ErrUnknownVerifyingKey)
+
+		withKey := NewClientState(cs.ChainId, cs.TrustingPeriod, cs.UnbondingPeriod, cs.MaxClockDrift, cs.LatestHeight, nil, rawVk, nil)
+		require.NoError(t, withKey.Validate())
+		fingerprint := sha256.Sum256(rawVk)
+		require.Equal(t, fingerprint[:], withKey.PlonkVerifyingKeyFingerprint)
+
+		// The carried key is moved to the client store, only its fingerprint
+		// is kept in the client state
+		ibcKey := storetypes.NewKVStoreKey("ibc")
+		storeCtx := testutil.DefaultContext(ibcKey, storetypes.NewTransientStoreKey("transient"))
+		store := storeCtx.KVStore(ibcKey)
+		consState, found := GetConsensusState(clientStore, cdc, header.TrustedHeight)
+		require.True(t, found)
+		require.NoError(t, withKey.Initialize(storeCtx, cdc, store, consState))
+		require.Equal(t, rawVk, store.Get(VerifyingKeyKey(KeyPlonkVerifyingKeyPrefix, fingerprint[:])))
+		stored := clienttypes.MustUnmarshalClientState(cdc, store.Get(host.ClientStateKey())).(*ClientState)
+		require.Nil(t, stored.PlonkVerifyingKey)
+		require.Equal(t, fingerprint[:], stored.PlonkVerifyingKeyFingerprint)
+		require.Contains(t, stored.ExportMetadata(store), clienttypes.NewGenesisMetadata(VerifyingKeyKey(KeyPlonkVerifyingKeyPrefix, fingerprint[:]), rawVk))
+
+		require.ErrorIs(t, stored.VerifyClientMessage(ctx, cdc, store, &plonkHeader), ErrInvalidProof)
+		plonkHeader.ZeroKnowledgeProof = rawProof
+		require.NoError(t, stored.VerifyClientMessage(ctx, cdc, store, &plonkHeader))
+		// The groth16 proof of the header is still verified with the groth16 key
+		require.NoError(t, stored.VerifyClientMessage(ctx, cdc, store, header))
+		// The key is looked up in the store of the client
+		require.ErrorIs(t, stored.VerifyClientMessage(ctx, cdc, clientStore, &plonkHeader), ErrUnknownVerifyingKey)
+
+		plonkHeader.ProofType = ProofType(2)
+		require.ErrorContains(t, stored.VerifyClientMessage(ctx, cdc, store, &plonkHeader), "unknown proof type: 2")
+
+		// A key named without being carried must already be stored
+		require.NoError(t, stored.Validate())
+		require.NoError(t, stored.storeVerifyingKeys(store))
+		require.ErrorIs(t, stored.storeVerifyingKeys(clientStore), ErrUnknownVerifyingKey)
+
+		mismatch := *withKey
+		mismatch.PlonkVerifyingKeyFingerprint = DefaultVerifyingKeyFingerprint
+		require.ErrorIs(t, mismatch.Validate(), ErrInvalidVerifyingKey)
+		invalid := *withKey
+		invalid.PlonkVerifyingKey = rawVk[1:]
+		invalid.PlonkVerifyingKeyFingerprint = fingerprintOf(invalid.PlonkVerifyingKey)
+		require.ErrorIs(t, invalid.Validate(), ErrInvalidVerifyingKey)
+		truncated := *stored
+		truncated.PlonkVerifyingKeyFingerprint = fingerprint[1:]
+		require.ErrorIs(t, truncated.Validate(), ErrInvalidVerifyingKey)
+	})
+}
+
+// Generated by `galoisd gen-vectors --backend plonk --stand-in`, the proofs of
+// a circuit exposing the inputs hash like the light client one does.
+func TestPlonkVectors(t *testing.T) {
+	corpus := readTestVectors(t, "testdata/plonk_vectors.json")
+	require.Equal(t, "plonk", corpus.Backend)
+
+	decode := func(t *testing.T, s string) []byte {
+		b, err := hex.DecodeString(s)
+		require.NoError(t, err)
+		return b
+	}
+	vk, err := ParsePlonkVerifyingKey(decode(t, corpus.VerifyingKey))
+	require.NoError(t, err)
+	require.Len(t, vk.Qcp, 1)
+
+	for _, vector := range corpus.Vectors {
+		t.Run(vector.Description, func(t *testing.T) {
+			zkp, err := ParsePlonkZKP(vk, decode(t, vector.Proof))
+			require.NoError(t, err)
+
+			trustedValidatorsHash := decode(t, vector.TrustedValidatorsHash)
+			header := ProverLightHeader{
+				ChainId:            vector.ChainID,
+				Height:             vector.Height,
+				Time:               time.Unix(vector.TimeSeconds, vector.TimeNanos),
+				ValidatorsHash:     decode(t, vector.ValidatorsHash),
+				NextValidatorsHash: decode(t, vector.NextValidatorsHash),
+				AppHash:            decode(t, vector.AppHash),
+			}
+
+			inpHash := inputsHash(header, trustedValidatorsHash)
+			inpHashBytes := inpHash.Bytes()
+			assert.Equal(t, vector.InputsHash, hex.EncodeToString(inpHashBytes[:]))
+
+			err = zkp.VerifyWithKey(vk, trustedValidatorsHash, header)
+			if vector.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
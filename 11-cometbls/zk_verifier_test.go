@@ -1,14 +1,25 @@
 package cometbls
 
 import (
+	"bytes"
 	"encoding/hex"
-	"github.com/stretchr/testify/assert"
+	"encoding/json"
+	"os"
 	"testing"
 	"time"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	backend "github.com/consensys/gnark/backend/groth16"
+	backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+const testZKP = "294A48A750D5C2CF926516752FF484EEBE55FF26CF8A8A7536D98794CF062DB6214D0C9E5C6B164111927A1630889619DBBB40149D8E2D32898E7ACB765542CD0EB8A8E04CCC254C3BFDC2FCE627D59C3C05E2AC76E03977855DD889C1C9BA432FF7FF4DEFCB5286555D36D22DD073A859140508AF9B977F38EB9A604E99A5F6109D43A4AFA0AB161DA2B261DED80FBC0C36E57DE2001338941C834E3262CF751BC1BFC6EC27BB8E106BAAB976285BAC1D4AC38D1B759C8A2852D65CE239974F1275CC6765B3D174FD1122EFDE86137D19F07483FEF5244B1D74B2D9DC598AC32A5CA10E8837FBC89703F4D0D46912CF4AF82341C30C2A1F3941849CC011A56E18AD2162EEB71289B8821CC01875BC1E35E5FC1EBD9114C0B2C0F0D9A96C394001468C70A1716CA98EBE82B1E614D4D9B07292EBAD5B60E0C76FD1D58B485E7D1FB1E07F51A0C68E4CA59A399FCF0634D9585BE478E37480423681B984E96C0A1698D8FCB1DF51CAE023B045E114EED9CB233A5742D9E60E1097206EB20A5058"
+
 func TestVerifier(t *testing.T) {
-	rawZKP, _ := hex.DecodeString("294A48A750D5C2CF926516752FF484EEBE55FF26CF8A8A7536D98794CF062DB6214D0C9E5C6B164111927A1630889619DBBB40149D8E2D32898E7ACB765542CD0EB8A8E04CCC254C3BFDC2FCE627D59C3C05E2AC76E03977855DD889C1C9BA432FF7FF4DEFCB5286555D36D22DD073A859140508AF9B977F38EB9A604E99A5F6109D43A4AFA0AB161DA2B261DED80FBC0C36E57DE2001338941C834E3262CF751BC1BFC6EC27BB8E106BAAB976285BAC1D4AC38D1B759C8A2852D65CE239974F1275CC6765B3D174FD1122EFDE86137D19F07483FEF5244B1D74B2D9DC598AC32A5CA10E8837FBC89703F4D0D46912CF4AF82341C30C2A1F3941849CC011A56E18AD2162EEB71289B8821CC01875BC1E35E5FC1EBD9114C0B2C0F0D9A96C394001468C70A1716CA98EBE82B1E614D4D9B07292EBAD5B60E0C76FD1D58B485E7D1FB1E07F51A0C68E4CA59A399FCF0634D9585BE478E37480423681B984E96C0A1698D8FCB1DF51CAE023B045E114EED9CB233A5742D9E60E1097206EB20A5058")
+	rawZKP, _ := hex.DecodeString(testZKP)
 
 	zkp, err := ParseZKP(rawZKP)
 
@@ -21,7 +32,7 @@ func TestVerifier(t *testing.T) {
 	err = zkp.Verify(
 
 		trustedValHash,
-		LightHeader{
+		ProverLightHeader{
 			ChainId:            "union-devnet-1337",
 			Height:             3405691582,
 			Time:               time.Unix(1710783278, 499600406),
@@ -33,3 +44,251 @@ func TestVerifier(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+// Generated by `galoisd gen-vectors`, shared with the other verifier implementations.
+type testVectors struct {
+	Backend      string `json:"backend"`
+	VerifyingKey string `json:"verifying_key"`
+	Vectors      []struct {
+		Description           string `json:"description"`
+		ChainID               string `json:"chain_id"`
+		Height                int64  `json:"height"`
+		TimeSeconds           int64  `json:"time_seconds"`
+		TimeNanos             int64  `json:"time_nanos"`
+		ValidatorsHash        string `json:"validators_hash"`
+		NextValidatorsHash    string `json:"next_validators_hash"`
+		AppHash               string `json:"app_hash"`
+		TrustedValidatorsHash string `json:"trusted_validators_hash"`
+		EvmProof              string `json:"evm_proof"`
+		Proof                 string `json:"proof"`
+		InputsHash            string `json:"inputs_hash"`
+		CommitmentsHash       string `json:"commitments_hash"`
+		Valid                 bool   `json:"valid"`
+	} `json:"vectors"`
+}
+
+func readTestVectors(t *testing.T, path string) testVectors {
+	corpusJSON, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var corpus testVectors
+	require.NoError(t, json.Unmarshal(corpusJSON, &corpus))
+	require.NotEmpty(t, corpus.Vectors)
+	return corpus
+}
+
+func TestVectors(t *testing.T) {
+	corpus := readTestVectors(t, "testdata/vectors.json")
+
+	rawVK, err := hex.DecodeString(corpus.VerifyingKey)
+	require.NoError(t, err)
+	var vk backend_bn254.VerifyingKey
+	_, err = backend.VerifyingKey(&vk).ReadFrom(bytes.NewReader(rawVK))
+	require.NoError(t, err)
+
+	decode := func(t *testing.T, s string) []byte {
+		b, err := hex.DecodeString(s)
+		require.NoError(t, err)
+		return b
+	}
+
+	for _, vector := range corpus.Vectors {
+		t.Run(vector.Description, func(t *testing.T) {
+			zkp, err := ParseZKP(decode(t, vector.EvmProof))
+			require.NoError(t, err)
+
+			trustedValidatorsHash := decode(t, vector.TrustedValidatorsHash)
+			header := ProverLightHeader{
+				ChainId:            vector.ChainID,
+				Height:             vector.Height,
+				Time:               time.Unix(vector.TimeSeconds, vector.TimeNanos),
+				ValidatorsHash:     decode(t, vector.ValidatorsHash),
+				NextValidatorsHash: decode(t, vector.NextValidatorsHash),
+				AppHash:            decode(t, vector.AppHash),
+			}
+
+			inpHash := inputsHash(header, trustedValidatorsHash)
+			inpHashBytes := inpHash.Bytes()
+			assert.Equal(t, vector.InputsHash, hex.EncodeToString(inpHashBytes[:]))
+
+			commHash := commitmentsHash(zkp.ProofCommitment)
+			commHashBytes := commHash.Bytes()
+			assert.Equal(t, vector.CommitmentsHash, hex.EncodeToString(commHashBytes[:]))
+
+			err = zkp.VerifyWithKey(&vk, trustedValidatorsHash, header)
+			if vector.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+// The EVM encoding ParseZKP decodes
+func encodeZKP(zkp *ZKP) []byte {
+	var buffer bytes.Buffer
+	for _, e := range []fp.Element{
+		zkp.Proof.A.X, zkp.Proof.A.Y,
+		zkp.Proof.B.X.A1, zkp.Proof.B.X.A0, zkp.Proof.B.Y.A1, zkp.Proof.B.Y.A0,
+		zkp.Proof.C.X, zkp.Proof.C.Y,
+		zkp.ProofCommitment.X, zkp.ProofCommitment.Y,
+		zkp.ProofCommitmentPoK.X, zkp.ProofCommitmentPoK.Y,
+	} {
+		b := e.Bytes()
+		buffer.Write(b[:])
+	}
+	return buffer.Bytes()
+}
+
+// A point of the twist which isn't in the subgroup of G2
+func twistPointNotInSubGroup(t *testing.T) curve.G2Affine {
+	var p curve.G2Affine
+	// b' = 3 / (9 + u)
+	b := p.X
+	b.A0.SetUint64(9)
+	b.A1.SetUint64(1)
+	b.Inverse(&b)
+	var three fp.Element
+	three.SetUint64(3)
+	b.MulByElement(&b, &three)
+	for i := uint64(1); ; i++ {
+		p.X.A0.SetUint64(i)
+		rhs := p.X
+		rhs.Square(&rhs).Mul(&rhs, &p.X).Add(&rhs, &b)
+		if rhs.Legendre() != 1 {
+			continue
+		}
+		p.Y.Sqrt(&rhs)
+		require.True(t, p.IsOnCurve())
+		if !p.IsInSubGroup() {
+			return p
+		}
+	}
+}
+
+func TestParseZKPInvalid(t *testing.T) {
+	rawZKP, err := hex.DecodeString(testZKP)
+	require.NoError(t, err)
+	zkp, err := ParseZKP(rawZKP)
+	require.NoError(t, err)
+	require.Equal(t, rawZKP, encodeZKP(zkp))
+
+	modulus := fp.Modulus().FillBytes(make([]byte, FQ_SIZE))
+
+	tests := []struct {
+		description string
+		data        func() []byte
+		message     string
+	}{
+		{
+			"empty",
+			func() []byte { return nil },
+			"expected 384 bytes, got 0",
+		},
+		{
+			"truncated",
+			func() []byte { return rawZKP[:ZKP_SIZE-1] },
+			"expected 384 bytes, got 383",
+		},
+		{
+			"trailing bytes",
+			func() []byte { return append(bytes.Clone(rawZKP), 0) },
+			"expected 384 bytes, got 385",
+		},
+		{
+			"non-canonical coordinate",
+			func() []byte {
+				data := bytes.Clone(rawZKP)
+				copy(data[0:FQ_SIZE], modulus)
+				return data
+			},
+			"A:",
+		},
+		{
+			"A not on the curve",
+			func() []byte {
+				data := bytes.Clone(rawZKP)
+				data[G1_SIZE-1] ^= 1
+				return data
+			},
+			"A: point is not on the curve",
+		},
+		{
+			"B not on the curve",
+			func() []byte {
+				data := bytes.Clone(rawZKP)
+				data[G1_SIZE+G2_SIZE-1] ^= 1
+				return data
+			},
+			"B: point is not on the curve",
+		},
+		{
+			"B not in the subgroup",
+			func() []byte {
+				invalid := *zkp
+				invalid.Proof.B = twistPointNotInSubGroup(t)
+				return encodeZKP(&invalid)
+			},
+			"B: point is not in the subgroup",
+		},
+		{
+			"C not on the curve",
+			func() []byte {
+				data := bytes.Clone(rawZKP)
+				data[2*G1_SIZE+G2_SIZE-1] ^= 1
+				return data
+			},
+			"C: point is not on the curve",
+		},
+		{
+			"commitment not on the curve",
+			func() []byte {
+				data := bytes.Clone(rawZKP)
+				data[3*G1_SIZE+G2_SIZE-1] ^= 1
+				return data
+			},
+			"commitment: point is not on the curve",
+		},
+		{
+			"commitment PoK not on the curve",
+			func() []byte {
+				data := bytes.Clone(rawZKP)
+				data[ZKP_SIZE-1] ^= 1
+				return data
+			},
+			"commitment PoK: point is not on the curve",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			_, err := ParseZKP(tt.data())
+			require.ErrorIs(t, err, ErrInvalidProof)
+			require.ErrorContains(t, err, tt.message)
+		})
+	}
+}
+
+func FuzzParseZKP(f *testing.F) {
+	rawZKP, err := hex.DecodeString(testZKP)
+	require.NoError(f, err)
+	f.Add(rawZKP)
+	f.Add(rawZKP[:G1_SIZE])
+	f.Add(make([]byte, ZKP_SIZE))
+	flagged := bytes.Clone(rawZKP)
+	flagged[0] |= 0xC0
+	f.Add(flagged)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		zkp, err := ParseZKP(data)
+		if err != nil {
+			require.ErrorIs(t, err, ErrInvalidProof)
+			return
+		}
+		require.Equal(t, data, encodeZKP(zkp))
+		for _, p := range []curve.G1Affine{zkp.Proof.A, zkp.Proof.C, zkp.ProofCommitment, zkp.ProofCommitmentPoK} {
+			require.True(t, p.IsInSubGroup())
+		}
+		require.True(t, zkp.Proof.B.IsInSubGroup())
+	})
+}
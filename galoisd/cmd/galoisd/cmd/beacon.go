@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// ChaCha20 generator seeded by snarkjs (and the original powersoftau) to
+// derive points from hashes.
+type chacha struct {
+	state [16]uint32
+	buff  [16]uint32
+	idx   int
+}
+
+func newChaCha(seed [32]byte) *chacha {
+	rng := &chacha{idx: 16}
+	rng.state[0], rng.state[1], rng.state[2], rng.state[3] = 0x61707865, 0x3320646E, 0x79622D32, 0x6B206574
+	for i := 0; i < 8; i++ {
+		rng.state[4+i] = binary.BigEndian.Uint32(seed[4*i:])
+	}
+	return rng
+}
+
+func chachaQuarterRound(st *[16]uint32, a, b, c, d int) {
+	st[a] += st[b]
+	st[d] = bits.RotateLeft32(st[d]^st[a], 16)
+	st[c] += st[d]
+	st[b] = bits.RotateLeft32(st[b]^st[c], 12)
+	st[a] += st[b]
+	st[d] = bits.RotateLeft32(st[d]^st[a], 8)
+	st[c] += st[d]
+	st[b] = bits.RotateLeft32(st[b]^st[c], 7)
+}
+
+func (rng *chacha) update() {
+	rng.buff = rng.state
+	for i := 0; i < 10; i++ {
+		chachaQuarterRound(&rng.buff, 0, 4, 8, 12)
+		chachaQuarterRound(&rng.buff, 1, 5, 9, 13)
+		chachaQuarterRound(&rng.buff, 2, 6, 10, 14)
+		chachaQuarterRound(&rng.buff, 3, 7, 11, 15)
+		chachaQuarterRound(&rng.buff, 0, 5, 10, 15)
+		chachaQuarterRound(&rng.buff, 1, 6, 11, 12)
+		chachaQuarterRound(&rng.buff, 2, 7, 8, 13)
+		chachaQuarterRound(&rng.buff, 3, 4, 9, 14)
+	}
+	for i := range rng.buff {
+		rng.buff[i] += rng.state[i]
+	}
+	rng.idx = 0
+	for i := 12; i < 16; i++ {
+		rng.state[i]++
+		if rng.state[i] != 0 {
+			break
+		}
+	}
+}
+
+func (rng *chacha) nextU32() uint32 {
+	if rng.idx == 16 {
+		rng.update()
+	}
+	rng.idx++
+	return rng.buff[rng.idx-1]
+}
+
+func (rng *chacha) nextU64() uint64 {
+	hi := rng.nextU32()
+	return uint64(hi)<<32 | uint64(rng.nextU32())
+}
+
+func (rng *chacha) nextBool() bool {
+	return rng.nextU32()&1 == 1
+}
+
+// Random limbs below the modulus, interpreted in Montgomery form
+func limbsFromRng(rng *chacha, limbs []uint64, modulus *big.Int) {
+	for {
+		for i := range limbs {
+			limbs[i] = rng.nextU64()
+		}
+		// Both moduli are 254 bits
+		limbs[len(limbs)-1] &= (1 << 62) - 1
+		var v big.Int
+		for i := len(limbs) - 1; i >= 0; i-- {
+			v.Lsh(&v, 64).Or(&v, new(big.Int).SetUint64(limbs[i]))
+		}
+		if v.Cmp(modulus) < 0 {
+			return
+		}
+	}
+}
+
+func fpFromRng(rng *chacha) fp.Element {
+	var z fp.Element
+	limbsFromRng(rng, z[:], fp.Modulus())
+	return z
+}
+
+func frFromRng(rng *chacha) fr.Element {
+	var z fr.Element
+	limbsFromRng(rng, z[:], fr.Modulus())
+	return z
+}
+
+// Generator of a beacon contribution, seeded with 2^numIterationsExp sha256
+// of the beacon as snarkjs does.
+func rngFromBeacon(beaconHash []byte, numIterationsExp uint8) (*chacha, error) {
+	if numIterationsExp == 0 || numIterationsExp > 63 {
+		return nil, fmt.Errorf("invalid number of beacon iterations: 2^%d", numIterationsExp)
+	}
+	curHash := beaconHash
+	for i := uint64(0); i < uint64(1)<<numIterationsExp; i++ {
+		h := sha256.Sum256(curHash)
+		curHash = h[:]
+	}
+	return newChaCha([32]byte(curHash)), nil
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	provergrpc "galois/grpc"
+	provergrpcapi "galois/grpc/api/v3"
+	"galois/pkg/lightclient"
+	"galois/pkg/lightclient/nonadjacent"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagValidators = "validators"
+	flagRuns       = "runs"
+	flagSeed       = "seed"
+	flagDev        = "dev"
+	flagProfile    = "profile"
+)
+
+type benchReport struct {
+	Backend      provergrpc.Backend            `json:"backend"`
+	SetupSeconds float64                       `json:"setup_seconds"`
+	Stats        *provergrpcapi.VariableStats  `json:"stats"`
+	Runs         []*provergrpc.BenchRun        `json:"runs"`
+	Profile      *provergrpc.ConstraintProfile `json:"profile,omitempty"`
+}
+
+// Prove and verify synthetic validator sets against the loaded keys (or a
+// throwaway setup with --dev) and print the measurements as JSON.
+func BenchCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Benchmark the prover on synthetic validator sets and optionally profile the circuit constraints.",
+		Use:   "bench",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sizes, err := cmd.Flags().GetIntSlice(flagValidators)
+			if err != nil {
+				return err
+			}
+			for _, size := range sizes {
+				if size < 1 || size > lightclient.MaxVal {
+					return fmt.Errorf("the number of validators must be between 1 and %d, got: %d", lightclient.MaxVal, size)
+				}
+			}
+			runs, err := cmd.Flags().GetInt(flagRuns)
+			if err != nil {
+				return err
+			}
+			seed, err := cmd.Flags().GetInt64(flagSeed)
+			if err != nil {
+				return err
+			}
+			dev, err := cmd.Flags().GetBool(flagDev)
+			if err != nil {
+				return err
+			}
+			profilePath, err := cmd.Flags().GetString(flagProfile)
+			if err != nil {
+				return err
+			}
+			r1csPath, err := cmd.Flags().GetString(flagR1CS)
+			if err != nil {
+				return err
+			}
+			pkPath, err := cmd.Flags().GetString(flagPK)
+			if err != nil {
+				return err
+			}
+			vkPath, err := cmd.Flags().GetString(flagVK)
+			if err != nil {
+				return err
+			}
+			backend, err := cmd.Flags().GetString(flagBackend)
+			if err != nil {
+				return err
+			}
+			srsPath, err := cmd.Flags().GetString(flagSRS)
+			if err != nil {
+				return err
+			}
+
+			// Keep stdout for the report
+			logger.Disable()
+
+			config := provergrpc.BundleConfig{
+				Backend: provergrpc.Backend(backend),
+				CsPath:  r1csPath,
+				PkPath:  pkPath,
+				VkPath:  vkPath,
+				SrsPath: srsPath,
+			}
+			if dev {
+				// Throwaway keys, never meant to be deployed
+				dir, err := os.MkdirTemp("", "galoisd-bench")
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(dir)
+				config.CsPath = filepath.Join(dir, "cs.bin")
+				config.PkPath = filepath.Join(dir, "pk.bin")
+				config.VkPath = filepath.Join(dir, "vk.bin")
+			} else {
+				for _, path := range []string{config.CsPath, config.PkPath, config.VkPath} {
+					if _, err := os.Stat(path); err != nil {
+						return fmt.Errorf("Could not find the circuit bundle, use --%s for a throwaway setup: %w", flagDev, err)
+					}
+				}
+			}
+
+			start := time.Now()
+			server, err := provergrpc.NewProverServer(1, config)
+			if err != nil {
+				return err
+			}
+			report := benchReport{
+				Backend:      config.Backend,
+				SetupSeconds: time.Since(start).Seconds(),
+				Stats:        server.Stats().VariableStats,
+			}
+
+			rng := rand.New(rand.NewSource(seed))
+			for _, size := range sizes {
+				for i := 0; i < runs; i++ {
+					req, _, _, err := syntheticProveRequest(rng, size)
+					if err != nil {
+						return err
+					}
+					run, err := server.Bench(req)
+					if err != nil {
+						return err
+					}
+					report.Runs = append(report.Runs, run)
+				}
+			}
+
+			if profilePath != "" {
+				report.Profile, err = provergrpc.ProfileConstraints(config.Backend, &nonadjacent.Circuit{}, profilePath)
+				if err != nil {
+					return err
+				}
+			}
+
+			reportJSON, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(reportJSON))
+			return nil
+		},
+	}
+	cmd.Flags().IntSlice(flagValidators, []int{4, 32, lightclient.MaxVal}, "Sizes of the synthetic validator sets to prove.")
+	cmd.Flags().Int(flagRuns, 1, "Number of prove/verify runs for each size.")
+	cmd.Flags().Int64(flagSeed, 0, "Seed of the synthetic validator sets.")
+	cmd.Flags().Bool(flagDev, false, "Use a throwaway setup instead of the deployed keys, which is removed afterwards.")
+	cmd.Flags().String(flagProfile, "", "Path to write a gnark constraint profile (pprof) of the circuit to.")
+	cmd.Flags().String(flagR1CS, "r1cs.bin", "Path to the compiled circuit (R1CS for groth16, SCS for plonk).")
+	cmd.Flags().String(flagPK, "pk.bin", "Path to the proving key.")
+	cmd.Flags().String(flagVK, "vk.bin", "Path to the verifying key.")
+	cmd.Flags().String(flagBackend, string(provergrpc.BackendGroth16), "Proving backend of the circuit, either groth16 or plonk.")
+	cmd.Flags().String(flagSRS, "", "Path to the universal KZG SRS, required to setup a plonk circuit.")
+	return cmd
+}
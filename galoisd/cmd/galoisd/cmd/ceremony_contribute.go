@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/spf13/cobra"
+)
+
+const flagInvitation = "invitation"
+
+func ceremonyCall(method string, url string, header http.Header, body io.Reader) (*http.Response, error) {
+	request, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1<<12))
+		return nil, fmt.Errorf("the coordinator replied %s: %s", response.Status, strings.TrimSpace(string(message)))
+	}
+	return response, nil
+}
+
+func ceremonyCallJSON(method string, url string, header http.Header, body io.Reader, out any) error {
+	response, err := ceremonyCall(method, url, header, body)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	return json.NewDecoder(response.Body).Decode(out)
+}
+
+// Wait in the queue until the lock is granted.
+func waitCeremonyLock(queueURL string) (CeremonyStatus, error) {
+	position := -1
+	for {
+		var status CeremonyStatus
+		err := ceremonyCallJSON(http.MethodGet, queueURL, nil, nil, &status)
+		if err != nil {
+			return status, err
+		}
+		if status.Position == 0 && status.LockExpiry != nil {
+			return status, nil
+		}
+		if status.Position != position {
+			position = status.Position
+			fmt.Printf("position %d in the queue\n", position)
+		}
+		time.Sleep(CEREMONY_POLL_INTERVAL)
+	}
+}
+
+// Keep polling while holding the lock, for the coordinator not to drop the
+// contributor, until the returned function is called.
+func keepCeremonyLock(queueURL string) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(CEREMONY_POLL_INTERVAL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				var status CeremonyStatus
+				if err := ceremonyCallJSON(http.MethodGet, queueURL, nil, nil, &status); err != nil {
+					fmt.Printf("failed to poll the coordinator: %v\n", err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func CeremonyContributeCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Contribute to the phase 2 of the groth16 multi-party computation through a ceremony coordinator.",
+		Use:   "ceremony-contribute [url]",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			invitation, err := cmd.Flags().GetString(flagInvitation)
+			if err != nil {
+				return err
+			}
+			name, err := cmd.Flags().GetString(flagContributor)
+			if err != nil {
+				return err
+			}
+			contact, err := cmd.Flags().GetString(flagContact)
+			if err != nil {
+				return err
+			}
+			entropyPath, err := cmd.Flags().GetString(flagEntropyFile)
+			if err != nil {
+				return err
+			}
+			var entropy []byte
+			if entropyPath != "" {
+				entropy, err = os.ReadFile(entropyPath)
+				if err != nil {
+					return fmt.Errorf("failed to read entropy: %w", err)
+				}
+			}
+			output, err := cmd.Flags().GetString(flagOutput)
+			if err != nil {
+				return err
+			}
+			url := strings.TrimSuffix(args[0], "/")
+
+			contributor, err := json.Marshal(Phase2Contributor{Name: name, Contact: contact})
+			if err != nil {
+				return err
+			}
+			var join CeremonyJoinResponse
+			header := http.Header{
+				"Content-Type":  {"application/json"},
+				"Authorization": {"Bearer " + invitation},
+			}
+			err = ceremonyCallJSON(http.MethodPost, url+"/queue", header, bytes.NewReader(contributor), &join)
+			if err != nil {
+				return fmt.Errorf("failed to join the queue: %w", err)
+			}
+			queueURL := url + "/queue/" + join.Token
+			status, err := waitCeremonyLock(queueURL)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("contributing on top of %d contributions, the lock expires at %s\n", status.Contributions, status.LockExpiry.Format(time.RFC3339))
+			release := keepCeremonyLock(queueURL)
+			defer release()
+
+			response, err := ceremonyCall(http.MethodGet, queueURL+"/phase2", nil, nil)
+			if err != nil {
+				return fmt.Errorf("failed to download the phase2: %w", err)
+			}
+			var srs2 mpc.Phase2
+			_, err = srs2.ReadFrom(bufio.NewReader(response.Body))
+			response.Body.Close()
+			if err != nil {
+				return fmt.Errorf("failed to read the phase2: %w", err)
+			}
+			if hex.EncodeToString(srs2.Hash) != status.Hash {
+				return fmt.Errorf("the coordinator sent the phase2 %x instead of %s", srs2.Hash, status.Hash)
+			}
+			err = contributePhase2WithEntropy(&srs2, entropy)
+			if err != nil {
+				return err
+			}
+			if output != "" {
+				err = saveTo(output, &srs2)
+				if err != nil {
+					return err
+				}
+			}
+
+			// The contribution is encoded while being uploaded
+			reader, writer := io.Pipe()
+			go func() {
+				w := bufio.NewWriter(writer)
+				_, err := srs2.WriteTo(w)
+				if err == nil {
+					err = w.Flush()
+				}
+				writer.CloseWithError(err)
+			}()
+			var contribution CeremonyContributionResponse
+			err = ceremonyCallJSON(http.MethodPost, queueURL+"/contribution", http.Header{"Content-Type": {"application/octet-stream"}}, reader, &contribution)
+			if err != nil {
+				return fmt.Errorf("failed to upload the contribution: %w", err)
+			}
+			fmt.Printf("contribution #%d accepted, hash: %s\n", contribution.Index, contribution.Hash)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagInvitation, "", "Invitation given by the coordinator to join the queue.")
+	cmd.MarkFlagRequired(flagInvitation)
+	cmd.Flags().String(flagContributor, "", "Name of the contributor, recorded in the transcript, the one of the invitation if empty.")
+	cmd.Flags().String(flagContact, "", "Contact of the contributor, recorded in the transcript.")
+	cmd.Flags().String(flagEntropyFile, "", "Path to a file of user entropy, mixed with the system randomness to sample the contribution.")
+	cmd.Flags().String(flagOutput, "", "Path to keep a copy of the contribution at.")
+	return cmd
+}
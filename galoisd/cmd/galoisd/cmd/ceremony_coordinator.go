@@ -0,0 +1,558 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagCeremonyDir = "dir"
+	flagLockTimeout = "lock-timeout"
+	flagInvitations = "invitations"
+)
+
+const (
+	// A contributor waiting in the queue must poll its status more often
+	CEREMONY_QUEUE_TIMEOUT = time.Minute
+	CEREMONY_POLL_INTERVAL = 5 * time.Second
+)
+
+type CeremonyStatus struct {
+	// Number of contributors ahead, 0 once holding the lock
+	Position   int        `json:"position"`
+	LockExpiry *time.Time `json:"lock_expiry,omitempty"`
+	// Number of contributions and hash of the current phase2
+	Contributions int    `json:"contributions"`
+	Hash          string `json:"hash"`
+}
+
+type CeremonyJoinResponse struct {
+	Token string `json:"token"`
+}
+
+type CeremonyContributionResponse struct {
+	Index int    `json:"index"`
+	Hash  string `json:"hash"`
+}
+
+var errCeremonyUnauthorized = errors.New("unknown invitation")
+
+type ceremonyContributor struct {
+	token       string
+	invitation  string
+	contributor Phase2Contributor
+	lastSeen    time.Time
+	// Set once at the head of the queue
+	lockExpiry time.Time
+}
+
+func (contributor *ceremonyContributor) holdsLock() bool {
+	return !contributor.lockExpiry.IsZero()
+}
+
+// Holds the current phase2 and lets the queued contributors extend it one
+// at a time, each of them with a time-limited lock.
+type CeremonyCoordinator struct {
+	sync.Mutex
+	dir         string
+	initPath    string
+	lockTimeout time.Duration
+	// Name of the contributor each invitation is for
+	invitations map[string]string
+	current     *mpc.Phase2
+	currentPath string
+	currentSize int64
+	transcript  Phase2Transcript
+	queue       []*ceremonyContributor
+	// The lock can't expire while its contribution is being verified
+	verifying bool
+}
+
+func (c *CeremonyCoordinator) phase2Path(index int) string {
+	if index == 0 {
+		return c.initPath
+	}
+	return filepath.Join(c.dir, fmt.Sprintf("phase2_%04d.bin", index))
+}
+
+func (c *CeremonyCoordinator) transcriptPath() string {
+	return filepath.Join(c.dir, "transcript.json")
+}
+
+// Read the invitations of the contributors, a JSON object mapping each
+// invitation to the name of the contributor it's for.
+func readCeremonyInvitations(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var invitations map[string]string
+	err = json.Unmarshal(content, &invitations)
+	if err != nil {
+		return nil, err
+	}
+	for invitation, name := range invitations {
+		if len(invitation) < 16 || name == "" {
+			return nil, fmt.Errorf("the invitations must be at least 16 characters long and name their contributor")
+		}
+	}
+	return invitations, nil
+}
+
+// Resume the ceremony stored in dir, or start it from the phase2 init. Only
+// the invited contributors can join the queue.
+func NewCeremonyCoordinator(dir string, initPath string, lockTimeout time.Duration, invitations map[string]string) (*CeremonyCoordinator, error) {
+	c := &CeremonyCoordinator{dir: dir, initPath: initPath, lockTimeout: lockTimeout, invitations: invitations}
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	var init mpc.Phase2
+	err = readFrom(initPath, &init)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read phase2 init: %v", err)
+	}
+	c.transcript, err = readPhase2Transcript(c.transcriptPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		c.transcript = Phase2Transcript{InitHash: hex.EncodeToString(init.Hash)}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	if c.transcript.InitHash != hex.EncodeToString(init.Hash) {
+		return nil, fmt.Errorf("the transcript starts from %s, not from the phase2 init %x", c.transcript.InitHash, init.Hash)
+	}
+	current := &init
+	if n := len(c.transcript.Contributions); n > 0 {
+		current = new(mpc.Phase2)
+		err = readFrom(c.phase2Path(n), current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read contribution #%d: %v", n, err)
+		}
+		if tip := c.transcript.Tip(); tip != hex.EncodeToString(current.Hash) {
+			return nil, fmt.Errorf("the transcript ends with %s, not with the contribution %x", tip, current.Hash)
+		}
+	}
+	return c, c.setCurrent(current, c.phase2Path(len(c.transcript.Contributions)))
+}
+
+func (c *CeremonyCoordinator) setCurrent(current *mpc.Phase2, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	c.current = current
+	c.currentPath = path
+	c.currentSize = info.Size()
+	return nil
+}
+
+// Drop the contributors that left the queue or let their lock expire, and
+// grant the lock to the head of the queue. The lock holder must keep polling
+// too, until its contribution is being verified.
+func (c *CeremonyCoordinator) update(now time.Time) {
+	queue := c.queue[:0]
+	for _, contributor := range c.queue {
+		if contributor.holdsLock() && c.verifying {
+			queue = append(queue, contributor)
+			continue
+		}
+		if contributor.holdsLock() && now.After(contributor.lockExpiry) {
+			log.Info().Str("contributor", contributor.contributor.Name).Msg("lock expired")
+			continue
+		}
+		if now.Sub(contributor.lastSeen) > CEREMONY_QUEUE_TIMEOUT {
+			log.Info().Str("contributor", contributor.contributor.Name).Msg("left the queue")
+			continue
+		}
+		queue = append(queue, contributor)
+	}
+	c.queue = queue
+	if len(c.queue) > 0 && !c.queue[0].holdsLock() {
+		c.queue[0].lockExpiry = now.Add(c.lockTimeout)
+		log.Info().Str("contributor", c.queue[0].contributor.Name).Time("expiry", c.queue[0].lockExpiry).Msg("lock granted")
+	}
+}
+
+func (c *CeremonyCoordinator) find(token string) int {
+	for i, contributor := range c.queue {
+		if contributor.token == token {
+			return i
+		}
+	}
+	return -1
+}
+
+// The contributor at the head of the queue, if it's the one of the token.
+func (c *CeremonyCoordinator) lockHolder(token string) (*ceremonyContributor, error) {
+	now := time.Now()
+	c.update(now)
+	if c.find(token) != 0 {
+		return nil, fmt.Errorf("the lock isn't held by this contributor")
+	}
+	c.queue[0].lastSeen = now
+	return c.queue[0], nil
+}
+
+// Queue the contributor of the invitation, once at a time and as long as it
+// didn't contribute yet.
+func (c *CeremonyCoordinator) Join(invitation string, contributor Phase2Contributor) (string, error) {
+	name, found := c.invitations[invitation]
+	if !found {
+		return "", errCeremonyUnauthorized
+	}
+	if contributor.Name == "" {
+		contributor.Name = name
+	} else if contributor.Name != name {
+		return "", fmt.Errorf("the invitation is for %q", name)
+	}
+	var token [16]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return "", err
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.update(time.Now())
+	for _, queued := range c.queue {
+		if queued.invitation == invitation {
+			return "", fmt.Errorf("%q is already in the queue", name)
+		}
+	}
+	for _, entry := range c.transcript.Contributions {
+		if entry.Contributor.Name == name {
+			return "", fmt.Errorf("%q already contributed", name)
+		}
+	}
+	c.queue = append(c.queue, &ceremonyContributor{
+		token:       hex.EncodeToString(token[:]),
+		invitation:  invitation,
+		contributor: contributor,
+		lastSeen:    time.Now(),
+	})
+	log.Info().Str("contributor", contributor.Name).Int("position", len(c.queue)-1).Msg("joined the queue")
+	c.update(time.Now())
+	return hex.EncodeToString(token[:]), nil
+}
+
+func (c *CeremonyCoordinator) Status(token string) (CeremonyStatus, error) {
+	c.Lock()
+	defer c.Unlock()
+	now := time.Now()
+	c.update(now)
+	i := c.find(token)
+	if i < 0 {
+		return CeremonyStatus{}, fmt.Errorf("not in the queue")
+	}
+	contributor := c.queue[i]
+	contributor.lastSeen = now
+	status := CeremonyStatus{
+		Position:      i,
+		Contributions: len(c.transcript.Contributions),
+		Hash:          hex.EncodeToString(c.current.Hash),
+	}
+	if contributor.holdsLock() {
+		status.LockExpiry = &contributor.lockExpiry
+	}
+	return status, nil
+}
+
+// Verify the contribution of the lock holder and advance the ceremony with
+// it. The lock is released whether it's valid or not.
+func (c *CeremonyCoordinator) Contribute(token string, contrib *mpc.Phase2) (CeremonyContributionResponse, error) {
+	c.Lock()
+	holder, err := c.lockHolder(token)
+	if err == nil && c.verifying {
+		err = fmt.Errorf("the contribution is already being verified")
+	}
+	if err != nil {
+		c.Unlock()
+		return CeremonyContributionResponse{}, err
+	}
+	c.verifying = true
+	prev := c.current
+	c.Unlock()
+
+	err = verifyCeremonyContribution(prev, contrib)
+
+	c.Lock()
+	defer c.Unlock()
+	c.verifying = false
+	c.queue = c.queue[1:]
+	defer c.update(time.Now())
+	if err != nil {
+		log.Info().Str("contributor", holder.contributor.Name).Err(err).Msg("invalid contribution")
+		return CeremonyContributionResponse{}, fmt.Errorf("invalid contribution: %w", err)
+	}
+
+	index := len(c.transcript.Contributions) + 1
+	path := c.phase2Path(index)
+	err = saveTo(path, contrib)
+	if err != nil {
+		return CeremonyContributionResponse{}, err
+	}
+	// Only recorded once written
+	transcript := c.transcript
+	transcript.Contributions = slices.Clone(transcript.Contributions)
+	transcript.Append(contrib.Hash, holder.contributor)
+	err = writePhase2Transcript(c.transcriptPath(), &transcript)
+	if err != nil {
+		return CeremonyContributionResponse{}, err
+	}
+	c.transcript = transcript
+	err = c.setCurrent(contrib, path)
+	if err != nil {
+		return CeremonyContributionResponse{}, err
+	}
+	log.Info().Str("contributor", holder.contributor.Name).Int("index", index).Hex("hash", contrib.Hash).Msg("contribution accepted")
+	return CeremonyContributionResponse{Index: index, Hash: hex.EncodeToString(contrib.Hash)}, nil
+}
+
+// The points of a contribution come from the network, mpc.VerifyPhase2
+// panics on the ones it can't pair.
+func verifyCeremonyContribution(prev, contrib *mpc.Phase2) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return verifyPhase2Contribution(prev, contrib, nil)
+}
+
+// Check the encoding of a contribution against the current phase2 before
+// decoding it. The decoder allocates the points from the length prefixes of
+// the encoding, which must hence be the ones of the current phase2, and every
+// point must be compressed for the prefixes to be where they're expected.
+func checkPhase2Encoding(data []byte, current *mpc.Phase2) error {
+	cursor := 0
+	take := func(size int) ([]byte, error) {
+		if size > len(data)-cursor {
+			return nil, fmt.Errorf("the contribution is truncated at %d bytes", len(data))
+		}
+		cursor += size
+		return data[cursor-size : cursor], nil
+	}
+	points := func(n int, size int) error {
+		for i := 0; i < n; i++ {
+			point, err := take(size)
+			if err != nil {
+				return err
+			}
+			// The two most significant bits are the compression flags
+			if point[0]>>6 == 0 {
+				return fmt.Errorf("the point at offset %d isn't compressed", cursor-size)
+			}
+		}
+		return nil
+	}
+	slice := func(name string, n int) error {
+		prefix, err := take(4)
+		if err != nil {
+			return err
+		}
+		if length := binary.BigEndian.Uint32(prefix); uint64(length) != uint64(n) {
+			return fmt.Errorf("%s has %d points instead of %d", name, length, n)
+		}
+		return points(n, bn254.SizeOfG1AffineCompressed)
+	}
+
+	// Public key, [δ]1, L, Z, [δ]2, [-1/σ]2 and the commitment bases
+	if err := points(2, bn254.SizeOfG1AffineCompressed); err != nil {
+		return err
+	}
+	if err := points(1, bn254.SizeOfG2AffineCompressed); err != nil {
+		return err
+	}
+	if err := points(1, bn254.SizeOfG1AffineCompressed); err != nil {
+		return err
+	}
+	if err := slice("L", len(current.Parameters.G1.L)); err != nil {
+		return err
+	}
+	if err := slice("Z", len(current.Parameters.G1.Z)); err != nil {
+		return err
+	}
+	if err := points(2, bn254.SizeOfG2AffineCompressed); err != nil {
+		return err
+	}
+	prefix, err := take(8)
+	if err != nil {
+		return err
+	}
+	if n := binary.BigEndian.Uint64(prefix); n != uint64(len(current.Parameters.G1.BasisExpSigma)) {
+		return fmt.Errorf("the contribution has %d commitment bases instead of %d", n, len(current.Parameters.G1.BasisExpSigma))
+	}
+	for i, basis := range current.Parameters.G1.BasisExpSigma {
+		if err := slice(fmt.Sprintf("the commitment %d basis", i), len(basis)); err != nil {
+			return err
+		}
+	}
+	if _, err := take(len(current.Hash)); err != nil {
+		return err
+	}
+	if cursor != len(data) {
+		return fmt.Errorf("trailing bytes after the contribution: %d", len(data)-cursor)
+	}
+	return nil
+}
+
+// Read a contribution on top of the current phase2, whose encoding has the
+// same size.
+func readCeremonyContribution(r io.Reader, current *mpc.Phase2, size int64) (*mpc.Phase2, error) {
+	data, err := io.ReadAll(io.LimitReader(r, size+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) != size {
+		return nil, fmt.Errorf("the contribution must be %d bytes long, got %d", size, len(data))
+	}
+	if err := checkPhase2Encoding(data, current); err != nil {
+		return nil, err
+	}
+	var contrib mpc.Phase2
+	_, err = contrib.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return &contrib, nil
+}
+
+func writeCeremonyJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (c *CeremonyCoordinator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /queue", func(w http.ResponseWriter, r *http.Request) {
+		invitation, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found {
+			http.Error(w, "an invitation is required to join the queue", http.StatusUnauthorized)
+			return
+		}
+		var contributor Phase2Contributor
+		err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<12)).Decode(&contributor)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		token, err := c.Join(invitation, contributor)
+		if errors.Is(err, errCeremonyUnauthorized) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeCeremonyJSON(w, CeremonyJoinResponse{Token: token})
+	})
+	mux.HandleFunc("GET /queue/{token}", func(w http.ResponseWriter, r *http.Request) {
+		status, err := c.Status(r.PathValue("token"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeCeremonyJSON(w, status)
+	})
+	mux.HandleFunc("GET /queue/{token}/phase2", func(w http.ResponseWriter, r *http.Request) {
+		c.Lock()
+		_, err := c.lockHolder(r.PathValue("token"))
+		path := c.currentPath
+		c.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.ServeFile(w, r, path)
+	})
+	mux.HandleFunc("POST /queue/{token}/contribution", func(w http.ResponseWriter, r *http.Request) {
+		token := r.PathValue("token")
+		c.Lock()
+		_, err := c.lockHolder(token)
+		// Same circuit, same size
+		current := c.current
+		size := c.currentSize
+		c.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		contrib, err := readCeremonyContribution(r.Body, current, size)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read contribution: %v", err), http.StatusBadRequest)
+			return
+		}
+		response, err := c.Contribute(token, contrib)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeCeremonyJSON(w, response)
+	})
+	mux.HandleFunc("GET /transcript", func(w http.ResponseWriter, r *http.Request) {
+		c.Lock()
+		defer c.Unlock()
+		writeCeremonyJSON(w, c.transcript)
+	})
+	return mux
+}
+
+func CeremonyCoordinatorCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Coordinate the phase 2 contributions of the groth16 multi-party computation over HTTP.",
+		Use:   "ceremony-coordinator [uri] [phase2Init]",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := cmd.Flags().GetString(flagCeremonyDir)
+			if err != nil {
+				return err
+			}
+			lockTimeout, err := cmd.Flags().GetDuration(flagLockTimeout)
+			if err != nil {
+				return err
+			}
+			invitationsPath, err := cmd.Flags().GetString(flagInvitations)
+			if err != nil {
+				return err
+			}
+			invitations, err := readCeremonyInvitations(invitationsPath)
+			if err != nil {
+				return fmt.Errorf("failed to read the invitations: %w", err)
+			}
+			coordinator, err := NewCeremonyCoordinator(dir, args[1], lockTimeout, invitations)
+			if err != nil {
+				return err
+			}
+			server := &http.Server{
+				Addr:              args[0],
+				Handler:           coordinator.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			log.Info().Int("contributions", len(coordinator.transcript.Contributions)).Hex("hash", coordinator.current.Hash).Msg("Coordinating...")
+			return server.ListenAndServe()
+		},
+	}
+	cmd.Flags().String(flagCeremonyDir, "ceremony", "Directory of the contributions and of the transcript, the ceremony resumes from it.")
+	cmd.Flags().Duration(flagLockTimeout, 30*time.Minute, "Time given to a contributor to download the phase2, contribute and upload the contribution.")
+	cmd.Flags().String(flagInvitations, "", "Path to a JSON object mapping the invitations given to the contributors to their name.")
+	cmd.MarkFlagRequired(flagInvitations)
+	return cmd
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+// A circuit committing to its secret, such that the phase 2 has a
+// commitment basis.
+type committedSquareCircuit struct {
+	Root   frontend.Variable
+	Square frontend.Variable `gnark:",public"`
+}
+
+func (c *committedSquareCircuit) Define(api frontend.API) error {
+	commitment, err := api.(frontend.Committer).Commit(c.Root)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(commitment, 0)
+	api.AssertIsEqual(api.Mul(c.Root, c.Root), c.Square)
+	return nil
+}
+
+type testPhase2 struct {
+	r1cs   *cs_bn254.R1CS
+	phase1 mpc.Phase1
+	init   mpc.Phase2
+	evals  mpc.Phase2Evaluations
+}
+
+func newTestPhase2(t *testing.T) testPhase2 {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &committedSquareCircuit{})
+	require.NoError(t, err)
+	setup := testPhase2{r1cs: ccs.(*cs_bn254.R1CS), phase1: mpc.InitPhase1(4)}
+	setup.phase1.Contribute()
+	setup.init, setup.evals = mpc.InitPhase2(setup.r1cs, &setup.phase1)
+	require.Len(t, setup.init.Parameters.G1.BasisExpSigma, 1)
+	return setup
+}
+
+func encodePhase2(t *testing.T, c *mpc.Phase2) []byte {
+	var buffer bytes.Buffer
+	_, err := c.WriteTo(&buffer)
+	require.NoError(t, err)
+	return buffer.Bytes()
+}
+
+func decodePhase2(t *testing.T, data []byte) *mpc.Phase2 {
+	var c mpc.Phase2
+	_, err := c.ReadFrom(bytes.NewReader(data))
+	require.NoError(t, err)
+	return &c
+}
+
+func TestCeremonyCoordinator(t *testing.T) {
+	setup := newTestPhase2(t)
+	dir := t.TempDir()
+	initPath := filepath.Join(dir, "phase2_init.bin")
+	require.NoError(t, saveTo(initPath, &setup.init))
+	ceremonyDir := filepath.Join(dir, "ceremony")
+	invitations := map[string]string{
+		"alice-invitation-0001": "alice",
+		"bob-invitation-000001": "bob",
+		"carol-invitation-0001": "carol",
+	}
+	coordinator, err := NewCeremonyCoordinator(ceremonyDir, initPath, time.Hour, invitations)
+	require.NoError(t, err)
+	server := httptest.NewServer(coordinator.Handler())
+	defer server.Close()
+
+	join := func(invitation string, contributor Phase2Contributor) (string, error) {
+		header := http.Header{"Content-Type": {"application/json"}}
+		if invitation != "" {
+			header.Set("Authorization", "Bearer "+invitation)
+		}
+		body, err := json.Marshal(contributor)
+		require.NoError(t, err)
+		var response CeremonyJoinResponse
+		err = ceremonyCallJSON(http.MethodPost, server.URL+"/queue", header, bytes.NewReader(body), &response)
+		return server.URL + "/queue/" + response.Token, err
+	}
+	status := func(queueURL string) CeremonyStatus {
+		var status CeremonyStatus
+		require.NoError(t, ceremonyCallJSON(http.MethodGet, queueURL, nil, nil, &status))
+		return status
+	}
+	download := func(queueURL string) *mpc.Phase2 {
+		response, err := ceremonyCall(http.MethodGet, queueURL+"/phase2", nil, nil)
+		require.NoError(t, err)
+		defer response.Body.Close()
+		var c mpc.Phase2
+		_, err = c.ReadFrom(bufio.NewReader(response.Body))
+		require.NoError(t, err)
+		return &c
+	}
+	upload := func(queueURL string, data []byte) (CeremonyContributionResponse, error) {
+		var response CeremonyContributionResponse
+		err := ceremonyCallJSON(http.MethodPost, queueURL+"/contribution", nil, bytes.NewReader(data), &response)
+		return response, err
+	}
+
+	_, err = join("", Phase2Contributor{Name: "alice"})
+	require.ErrorContains(t, err, "401 Unauthorized: an invitation is required to join the queue")
+	_, err = join("mallory-invitation-01", Phase2Contributor{Name: "mallory"})
+	require.ErrorContains(t, err, "401 Unauthorized: unknown invitation")
+	_, err = join("alice-invitation-0001", Phase2Contributor{Name: "mallory"})
+	require.ErrorContains(t, err, "the invitation is for \"alice\"")
+
+	alice, err := join("alice-invitation-0001", Phase2Contributor{Contact: "alice@example.com"})
+	require.NoError(t, err)
+	_, err = join("alice-invitation-0001", Phase2Contributor{})
+	require.ErrorContains(t, err, "\"alice\" is already in the queue")
+	bob, err := join("bob-invitation-000001", Phase2Contributor{Name: "bob"})
+	require.NoError(t, err)
+
+	aliceStatus := status(alice)
+	require.Equal(t, 0, aliceStatus.Position)
+	require.NotNil(t, aliceStatus.LockExpiry)
+	require.Equal(t, hex.EncodeToString(setup.init.Hash), aliceStatus.Hash)
+	bobStatus := status(bob)
+	require.Equal(t, 1, bobStatus.Position)
+	require.Nil(t, bobStatus.LockExpiry)
+	_, err = ceremonyCall(http.MethodGet, bob+"/phase2", nil, nil)
+	require.ErrorContains(t, err, "409 Conflict: the lock isn't held by this contributor")
+
+	// join → download → contribute
+	phase2 := download(alice)
+	require.Equal(t, setup.init.Hash, phase2.Hash)
+	require.NoError(t, contributePhase2WithEntropy(phase2, []byte("alice's entropy")))
+	contribution, err := upload(alice, encodePhase2(t, phase2))
+	require.NoError(t, err)
+	require.Equal(t, CeremonyContributionResponse{Index: 1, Hash: hex.EncodeToString(phase2.Hash)}, contribution)
+
+	var transcript Phase2Transcript
+	require.NoError(t, ceremonyCallJSON(http.MethodGet, server.URL+"/transcript", nil, nil, &transcript))
+	require.Len(t, transcript.Contributions, 1)
+	require.Equal(t, Phase2Contributor{Name: "alice", Contact: "alice@example.com"}, transcript.Contributions[0].Contributor)
+	_, err = join("alice-invitation-0001", Phase2Contributor{})
+	require.ErrorContains(t, err, "\"alice\" already contributed")
+
+	// The malformed uploads are rejected before being decoded, the lock is kept
+	bobStatus = status(bob)
+	require.Equal(t, 0, bobStatus.Position)
+	require.Equal(t, contribution.Hash, bobStatus.Hash)
+	phase2 = download(bob)
+	phase2.Contribute()
+	valid := encodePhase2(t, phase2)
+
+	// The public key and [δ]1 at infinity followed by a huge length prefix for L
+	infinity := make([]byte, bn254.SizeOfG1AffineCompressed)
+	infinity[0] = 0b01 << 6
+	oversized := append(bytes.Repeat(infinity, 5), 0xFF, 0xFF, 0xFF, 0xFF)
+	_, err = upload(bob, oversized)
+	require.ErrorContains(t, err, "400 Bad Request: failed to read contribution: the contribution must be")
+	padded := append(bytes.Clone(oversized), make([]byte, len(valid)-len(oversized))...)
+	_, err = upload(bob, padded)
+	require.ErrorContains(t, err, "failed to read contribution: L has 4294967295 points instead of")
+	lOffset := len(oversized) - 4
+	tampered := bytes.Clone(valid)
+	binary.BigEndian.PutUint32(tampered[lOffset:], uint32(len(phase2.Parameters.G1.L)+1))
+	_, err = upload(bob, tampered)
+	require.ErrorContains(t, err, "L has")
+	tampered = bytes.Clone(valid)
+	tampered[0] &^= 0b11 << 6
+	_, err = upload(bob, tampered)
+	require.ErrorContains(t, err, "the point at offset 0 isn't compressed")
+	_, err = upload(bob, append(bytes.Clone(valid), 0))
+	require.ErrorContains(t, err, "the contribution must be")
+	require.Equal(t, 0, status(bob).Position)
+
+	// A contribution which doesn't extend the current phase2 releases the lock
+	notExtending := decodePhase2(t, encodePhase2(t, &setup.init))
+	notExtending.Contribute()
+	_, err = upload(bob, encodePhase2(t, notExtending))
+	require.ErrorContains(t, err, "400 Bad Request: invalid contribution")
+	require.Error(t, ceremonyCallJSON(http.MethodGet, bob, nil, nil, &bobStatus))
+
+	t.Run("lock holder which stops polling", func(t *testing.T) {
+		bob, err := join("bob-invitation-000001", Phase2Contributor{})
+		require.NoError(t, err)
+		carol, err := join("carol-invitation-0001", Phase2Contributor{})
+		require.NoError(t, err)
+		require.Equal(t, 0, status(bob).Position)
+
+		// Carol keeps polling while Bob doesn't, long before the lock expires
+		coordinator.Lock()
+		coordinator.queue[1].lastSeen = coordinator.queue[1].lastSeen.Add(CEREMONY_QUEUE_TIMEOUT)
+		coordinator.update(time.Now().Add(CEREMONY_QUEUE_TIMEOUT + time.Second))
+		coordinator.Unlock()
+
+		carolStatus := status(carol)
+		require.Equal(t, 0, carolStatus.Position)
+		require.NotNil(t, carolStatus.LockExpiry)
+		_, err = ceremonyCall(http.MethodGet, bob+"/phase2", nil, nil)
+		require.ErrorContains(t, err, "409 Conflict")
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		resumed, err := NewCeremonyCoordinator(ceremonyDir, initPath, time.Hour, invitations)
+		require.NoError(t, err)
+		require.Equal(t, transcript.Contributions[0].Hash, hex.EncodeToString(resumed.current.Hash))
+		require.NoError(t, verifyPhase2Chain([]string{initPath, filepath.Join(ceremonyDir, "phase2_0001.bin")}, &resumed.transcript))
+	})
+}
+
+func TestReadCeremonyInvitations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invitations.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"alice-invitation-0001": "alice"}`), 0644))
+	invitations, err := readCeremonyInvitations(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"alice-invitation-0001": "alice"}, invitations)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"alice": "alice"}`), 0644))
+	_, err = readCeremonyInvitations(path)
+	require.ErrorContains(t, err, "the invitations must be at least 16 characters long")
+	require.NoError(t, os.WriteFile(path, []byte(`{"alice-invitation-0001": ""}`), 0644))
+	_, err = readCeremonyInvitations(path)
+	require.ErrorContains(t, err, "name their contributor")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	provergrpc "galois/grpc"
+	"galois/pkg/lightclient/misbehaviour"
+	"galois/pkg/lightclient/nonadjacent"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagCircuit = "circuit"
+)
+
+var circuits = map[string]func() frontend.Circuit{
+	"nonadjacent":  func() frontend.Circuit { return &nonadjacent.Circuit{} },
+	"misbehaviour": func() frontend.Circuit { return &misbehaviour.Circuit{} },
+}
+
+func CircuitCheckCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Compile the circuit from source and check that it matches the deployed constraint system and verifying key.",
+		Use:   "circuit-check",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			circuitName, err := cmd.Flags().GetString(flagCircuit)
+			if err != nil {
+				return err
+			}
+			newCircuit, ok := circuits[circuitName]
+			if !ok {
+				return fmt.Errorf("Unknown circuit: %s", circuitName)
+			}
+			r1csPath, err := cmd.Flags().GetString(flagR1CS)
+			if err != nil {
+				return err
+			}
+			vkPath, err := cmd.Flags().GetString(flagVK)
+			if err != nil {
+				return err
+			}
+			backend, err := cmd.Flags().GetString(flagBackend)
+			if err != nil {
+				return err
+			}
+			mismatches, err := provergrpc.CheckBundle(provergrpc.BundleConfig{
+				Backend: provergrpc.Backend(backend),
+				CsPath:  r1csPath,
+				VkPath:  vkPath,
+			}, newCircuit())
+			if err != nil {
+				return err
+			}
+			if len(mismatches) > 0 {
+				for _, mismatch := range mismatches {
+					fmt.Println(mismatch)
+				}
+				return fmt.Errorf("the %s circuit doesn't match the deployed keys: %d mismatches", circuitName, len(mismatches))
+			}
+			fmt.Printf("the %s circuit matches the deployed keys\n", circuitName)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagCircuit, "nonadjacent", "Circuit to compile from source, either nonadjacent or misbehaviour.")
+	cmd.Flags().String(flagR1CS, "r1cs.bin", "Path to the deployed compiled circuit (R1CS for groth16, SCS for plonk).")
+	cmd.Flags().String(flagVK, "vk.bin", "Path to the deployed verifying key.")
+	cmd.Flags().String(flagBackend, string(provergrpc.BackendGroth16), "Proving backend of the circuit, either groth16 or plonk.")
+	return cmd
+}
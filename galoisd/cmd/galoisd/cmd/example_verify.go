@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"crypto/rand"
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -22,6 +21,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	provergrpc "galois/grpc/api/v3"
 )
@@ -199,35 +199,22 @@ func ExampleVerifyCmd() *cobra.Command {
 			fmt.Printf("Trusted root: %X\n", proveRes.TrustedValidatorSetRoot)
 			fmt.Printf("EVM compatible ZKP: %X\n", proveRes.Proof.EvmProof)
 
-			inputsHash := func(h *types.Header) []byte {
-				buff := []byte{}
-				var padded [32]byte
-				writeI64 := func(x int64) {
-					big.NewInt(x).FillBytes(padded[:])
-					buff = append(buff, padded[:]...)
-				}
-				writeMiMCHash := func(b []byte) {
-					big.NewInt(0).SetBytes(b).FillBytes(padded[:])
-					buff = append(buff, padded[:]...)
-				}
-				writeHash := func(b []byte) {
-					buff = append(buff, b...)
-				}
-				writeMiMCHash([]byte(h.ChainID))
-				writeI64(h.Height)
-				writeI64(h.Time.Unix())
-				writeI64(int64(h.Time.Nanosecond()))
-				writeMiMCHash(h.ValidatorsHash)
-				writeMiMCHash(h.NextValidatorsHash)
-				writeHash(h.AppHash)
-				writeMiMCHash(h.ValidatorsHash)
-				hash := sha256.Sum256(buff)
-				return hash[1:]
-			}
-
+			// The prover derives the inputs hash from the light header
 			verifyRes, err := client.Verify(ctx, &provergrpc.VerifyRequest{
-				Proof:      proveRes.Proof,
-				InputsHash: inputsHash(header),
+				Proof: proveRes.Proof,
+				Inputs: &provergrpc.VerifyRequest_LightHeader{
+					LightHeader: &provergrpc.LightHeaderInputs{
+						UntrustedHeader: &provergrpc.LightHeader{
+							ChainId:            header.ChainID,
+							Height:             header.Height,
+							Time:               timestamppb.New(header.Time),
+							ValidatorsHash:     header.ValidatorsHash,
+							NextValidatorsHash: header.NextValidatorsHash,
+							AppHash:            header.AppHash,
+						},
+						TrustedValidatorSetRoot: proveRes.TrustedValidatorSetRoot,
+					},
+				},
 			})
 
 			if err != nil {
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	groth16 "github.com/consensys/gnark/backend/groth16/bn254"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/spf13/cobra"
+)
+
+// Put back the points at infinity gnark filters out of A and B
+func withInfinity[T any](points []T, infinity []bool) ([]T, error) {
+	all := make([]T, len(infinity))
+	j := 0
+	for i := range all {
+		if infinity[i] {
+			continue
+		}
+		if j == len(points) {
+			return nil, fmt.Errorf("expected more than %d points", len(points))
+		}
+		all[i] = points[j]
+		j++
+	}
+	if j != len(points) {
+		return nil, fmt.Errorf("expected %d points, got: %d", j, len(points))
+	}
+	return all, nil
+}
+
+func zkeyFromGnark(r1cs *cs.R1CS, pk *groth16.ProvingKey, vk *groth16.VerifyingKey) (*Zkey, error) {
+	if err := checkZkeyCommitments(r1cs); err != nil {
+		return nil, err
+	}
+
+	nbWires := r1cs.NbInternalVariables + r1cs.GetNbPublicVariables() + r1cs.GetNbSecretVariables()
+	nbPublic := r1cs.GetNbPublicVariables()
+	domainSize := fft.NewDomain(uint64(r1cs.GetNbConstraints())).Cardinality
+	if pk.Domain.Cardinality != domainSize || len(pk.G1.Z) != int(domainSize)-1 {
+		return nil, fmt.Errorf("the proving key domain has %d elements, expected %d", pk.Domain.Cardinality, domainSize)
+	}
+	if len(vk.G1.K) != nbPublic || len(pk.G1.K) != nbWires-nbPublic {
+		return nil, fmt.Errorf("the keys have %d public and %d private wires, expected %d and %d", len(vk.G1.K), len(pk.G1.K), nbPublic, nbWires-nbPublic)
+	}
+	if len(pk.InfinityA) != nbWires || len(pk.InfinityB) != nbWires {
+		return nil, fmt.Errorf("the proving key has %d wires, expected %d", len(pk.InfinityA), nbWires)
+	}
+
+	a, err := withInfinity(pk.G1.A, pk.InfinityA)
+	if err != nil {
+		return nil, fmt.Errorf("invalid A points: %w", err)
+	}
+	b1, err := withInfinity(pk.G1.B, pk.InfinityB)
+	if err != nil {
+		return nil, fmt.Errorf("invalid B points: %w", err)
+	}
+	b2, err := withInfinity(pk.G2.B, pk.InfinityB)
+	if err != nil {
+		return nil, fmt.Errorf("invalid B points: %w", err)
+	}
+
+	header := HeaderGroth{
+		n8q:        BN254_FIELD_ELEMENT_SIZE,
+		n8r:        BN254_FIELD_ELEMENT_SIZE,
+		nVars:      uint32(nbWires),
+		nPublic:    uint32(nbPublic - 1),
+		domainSize: uint32(domainSize),
+		power:      uint32(bits.TrailingZeros64(domainSize)),
+		alpha1:     pk.G1.Alpha,
+		beta1:      pk.G1.Beta,
+		beta2:      pk.G2.Beta,
+		gamma2:     vk.G2.Gamma,
+		delta1:     pk.G1.Delta,
+		delta2:     pk.G2.Delta,
+	}
+	return &Zkey{
+		ZkeyHeader:     ZkeyHeader{ProtocolID: GROTH_16_PROTOCOL_ID, protocolHeader: header},
+		protocolHeader: header,
+		IC:             vk.G1.K,
+		Coefs:          r1csZkeyCoefs(r1cs),
+		A:              a,
+		B1:             b1,
+		B2:             b2,
+		C:              pk.G1.K,
+		H:              zkeyZToH(pk.G1.Z, int(domainSize)),
+	}, nil
+}
+
+func ExportZkeyCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Export groth16 keys to a snarkjs zkey, to check the ceremony output with snarkjs.",
+		Use:   "export-zkey [r1cs] [provingKey] [verifyingKey] [zkeyOutput]",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r1csPath := args[0]
+			var r1cs cs.R1CS
+			err := readFrom(r1csPath, &r1cs)
+			if err != nil {
+				return fmt.Errorf("failed to read r1cs: %v", err)
+			}
+			pkPath := args[1]
+			var pk groth16.ProvingKey
+			err = readFrom(pkPath, &pk)
+			if err != nil {
+				return fmt.Errorf("failed to read pk: %v", err)
+			}
+			vkPath := args[2]
+			var vk groth16.VerifyingKey
+			err = readFrom(vkPath, &vk)
+			if err != nil {
+				return fmt.Errorf("failed to read vk: %v", err)
+			}
+			zkey, err := zkeyFromGnark(&r1cs, &pk, &vk)
+			if err != nil {
+				return err
+			}
+			zkeyOutput := args[3]
+			return saveTo(zkeyOutput, zkey)
+		},
+	}
+	return cmd
+}
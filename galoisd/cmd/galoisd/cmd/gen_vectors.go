@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	provergrpc "galois/grpc"
+	provergrpcapi "galois/grpc/api/v3"
+	"galois/pkg/lightclient"
+	"math/rand"
+	"os"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagOutput  = "output"
+	flagFrom    = "from"
+	flagStandIn = "stand-in"
+)
+
+// Generate a seeded corpus of valid and invalid light header proofs that
+// every verifier implementation must agree on.
+func GenVectorsCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Generate a seeded JSON corpus of valid and invalid proofs the verifiers must agree on.",
+		Use:   "gen-vectors",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sizes, err := cmd.Flags().GetIntSlice(flagValidators)
+			if err != nil {
+				return err
+			}
+			for _, size := range sizes {
+				if size < 1 || size > lightclient.MaxVal {
+					return fmt.Errorf("the number of validators must be between 1 and %d, got: %d", lightclient.MaxVal, size)
+				}
+			}
+			seed, err := cmd.Flags().GetInt64(flagSeed)
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString(flagOutput)
+			if err != nil {
+				return err
+			}
+			from, err := cmd.Flags().GetString(flagFrom)
+			if err != nil {
+				return err
+			}
+			r1csPath, err := cmd.Flags().GetString(flagR1CS)
+			if err != nil {
+				return err
+			}
+			pkPath, err := cmd.Flags().GetString(flagPK)
+			if err != nil {
+				return err
+			}
+			vkPath, err := cmd.Flags().GetString(flagVK)
+			if err != nil {
+				return err
+			}
+			backend, err := cmd.Flags().GetString(flagBackend)
+			if err != nil {
+				return err
+			}
+			srsPath, err := cmd.Flags().GetString(flagSRS)
+			if err != nil {
+				return err
+			}
+			standIn, err := cmd.Flags().GetBool(flagStandIn)
+			if err != nil {
+				return err
+			}
+
+			var corpus provergrpc.TestVectors
+			if from != "" {
+				// Keep the valid proofs of an existing corpus, derive the invalid cases again
+				previousJSON, err := os.ReadFile(from)
+				if err != nil {
+					return err
+				}
+				var previous provergrpc.TestVectors
+				err = json.Unmarshal(previousJSON, &previous)
+				if err != nil {
+					return fmt.Errorf("Could not parse the corpus %s", err)
+				}
+				corpus.Backend = previous.Backend
+				corpus.VerifyingKey = previous.VerifyingKey
+				for _, vector := range previous.Vectors {
+					if !vector.Valid {
+						continue
+					}
+					vectors, err := provergrpc.DeriveTestVectors(previous.Backend, vector)
+					if err != nil {
+						return err
+					}
+					corpus.Vectors = append(corpus.Vectors, vectors...)
+				}
+			} else {
+				logger.Disable()
+
+				config := provergrpc.BundleConfig{
+					Backend: provergrpc.Backend(backend),
+					CsPath:  r1csPath,
+					PkPath:  pkPath,
+					VkPath:  vkPath,
+					SrsPath: srsPath,
+				}
+				testVectors := provergrpc.StandInTestVectors
+				if !standIn {
+					server, err := provergrpc.NewProverServer(1, config)
+					if err != nil {
+						return err
+					}
+					testVectors = func(_ provergrpc.BundleConfig, req *provergrpcapi.ProveRequest, source string) ([]provergrpc.TestVector, error) {
+						return server.TestVectors(req, source)
+					}
+				}
+				if config.Backend != provergrpc.BackendGroth16 {
+					corpus.Backend = config.Backend
+				}
+
+				rng := rand.New(rand.NewSource(seed))
+				for _, size := range sizes {
+					req, _, _, err := syntheticProveRequest(rng, size)
+					if err != nil {
+						return err
+					}
+					vectors, err := testVectors(config, req, fmt.Sprintf("%d validators, seed %d", size, seed))
+					if err != nil {
+						return err
+					}
+					corpus.Vectors = append(corpus.Vectors, vectors...)
+				}
+				// Read once the bundle is setup, the stand-in one included
+				vk, err := os.ReadFile(vkPath)
+				if err != nil {
+					return err
+				}
+				corpus.VerifyingKey = hex.EncodeToString(vk)
+			}
+
+			corpusJSON, err := json.MarshalIndent(corpus, "", "  ")
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(output, append(corpusJSON, '\n'), 0644)
+		},
+	}
+	cmd.Flags().IntSlice(flagValidators, []int{1, 4, 32, lightclient.MaxVal}, "Sizes of the synthetic validator sets, a valid proof is generated for each.")
+	cmd.Flags().Int64(flagSeed, 0, "Seed of the synthetic validator sets.")
+	cmd.Flags().String(flagOutput, "vectors.json", "Path to write the corpus to.")
+	cmd.Flags().String(flagFrom, "", "Derive the invalid cases from the valid proofs of an existing corpus instead of proving.")
+	cmd.Flags().String(flagR1CS, "r1cs.bin", "Path to the compiled circuit (R1CS for groth16, SCS for plonk).")
+	cmd.Flags().String(flagPK, "pk.bin", "Path to the proving key.")
+	cmd.Flags().String(flagVK, "vk.bin", "Path to the verifying key.")
+	cmd.Flags().String(flagBackend, string(provergrpc.BackendGroth16), "Proving backend of the circuit, either groth16 or plonk.")
+	cmd.Flags().String(flagSRS, "", "Path to the universal KZG SRS, required to setup a plonk circuit.")
+	cmd.Flags().Bool(flagStandIn, false, "Prove with a stand-in circuit only exposing the inputs hash instead of the light client circuit, setup at the given paths if missing. Meant for the plonk corpus of the light clients, the light client circuit being too large to setup on a development machine.")
+	return cmd
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	groth16 "github.com/consensys/gnark/backend/groth16/bn254"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/spf13/cobra"
+)
+
+// Check that the zkey proves the given circuit, gnark recomputing the
+// constraints evaluations from its own constraint system.
+func (zkey *Zkey) checkCircuit(r1cs *cs.R1CS) error {
+	if err := checkZkeyCommitments(r1cs); err != nil {
+		return err
+	}
+
+	h := &zkey.protocolHeader
+	nbWires := r1cs.NbInternalVariables + r1cs.GetNbPublicVariables() + r1cs.GetNbSecretVariables()
+	nbPublic := r1cs.GetNbPublicVariables() - 1
+	if int(h.nVars) != nbWires || int(h.nPublic) != nbPublic {
+		return fmt.Errorf("the zkey has %d signals of which %d are public, expected %d and %d", h.nVars, h.nPublic, nbWires, nbPublic)
+	}
+	domainSize := fft.NewDomain(uint64(r1cs.GetNbConstraints())).Cardinality
+	if uint64(h.domainSize) != domainSize {
+		return fmt.Errorf("the zkey domain has %d elements, expected %d", h.domainSize, domainSize)
+	}
+
+	for _, coef := range zkey.Coefs {
+		if int(coef.Constraint) >= r1cs.GetNbConstraints() {
+			return fmt.Errorf("the zkey constrains the public signals beyond the %d constraints of the circuit, as snarkjs does for circom circuits, only zkeys exported by galoisd can be imported", r1cs.GetNbConstraints())
+		}
+	}
+	coefs := r1csZkeyCoefs(r1cs)
+	if len(coefs) != len(zkey.Coefs) {
+		return fmt.Errorf("the zkey has %d coefficients, expected %d", len(zkey.Coefs), len(coefs))
+	}
+	for i := range coefs {
+		if coefs[i] != zkey.Coefs[i] {
+			return fmt.Errorf("the coefficient %d of the zkey doesn't match the constraint system", i)
+		}
+	}
+	return nil
+}
+
+// Both δ (resp. β) must be the same scalar, e.g. after a contribution
+func checkZkeyRatios(h *HeaderGroth) error {
+	_, _, g1, g2 := bn254.Generators()
+	var g1Neg bn254.G1Affine
+	g1Neg.Neg(&g1)
+	for _, ratio := range []struct {
+		name string
+		p1   bn254.G1Affine
+		p2   bn254.G2Affine
+	}{
+		{"beta", h.beta1, h.beta2},
+		{"delta", h.delta1, h.delta2},
+	} {
+		ok, err := bn254.PairingCheck([]bn254.G1Affine{ratio.p1, g1Neg}, []bn254.G2Affine{g2, ratio.p2})
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("the zkey %s1 and %s2 don't match", ratio.name, ratio.name)
+		}
+	}
+	return nil
+}
+
+func (zkey *Zkey) toGnark(r1cs *cs.R1CS) (pk groth16.ProvingKey, vk groth16.VerifyingKey, err error) {
+	if err = zkey.checkCircuit(r1cs); err != nil {
+		return pk, vk, err
+	}
+	h := &zkey.protocolHeader
+	if err = checkZkeyRatios(h); err != nil {
+		return pk, vk, err
+	}
+	_, _, _, g2 := bn254.Generators()
+
+	pk.Domain = *fft.NewDomain(uint64(r1cs.GetNbConstraints()))
+	pk.G1.Alpha = h.alpha1
+	pk.G1.Beta = h.beta1
+	pk.G1.Delta = h.delta1
+	pk.G1.K = zkey.C
+	pk.G1.Z = zkeyHToZ(zkey.H)
+	pk.G2.Beta = h.beta2
+	pk.G2.Delta = h.delta2
+
+	// Filter out infinity points
+	nWires := len(zkey.A)
+	pk.InfinityA = make([]bool, nWires)
+	pk.InfinityB = make([]bool, nWires)
+	for i := 0; i < nWires; i++ {
+		if zkey.A[i].IsInfinity() {
+			pk.InfinityA[i] = true
+			pk.NbInfinityA++
+		} else {
+			pk.G1.A = append(pk.G1.A, zkey.A[i])
+		}
+		if zkey.B1[i].IsInfinity() != zkey.B2[i].IsInfinity() {
+			return pk, vk, fmt.Errorf("the zkey B points of the signal %d don't match", i)
+		}
+		if zkey.B1[i].IsInfinity() {
+			pk.InfinityB[i] = true
+			pk.NbInfinityB++
+		} else {
+			pk.G1.B = append(pk.G1.B, zkey.B1[i])
+			pk.G2.B = append(pk.G2.B, zkey.B2[i])
+		}
+	}
+
+	vk.G1.Alpha = h.alpha1
+	vk.G1.Beta = h.beta1
+	vk.G1.Delta = h.delta1
+	vk.G1.K = zkey.IC
+	vk.G2.Beta = h.beta2
+	vk.G2.Delta = h.delta2
+	vk.G2.Gamma = h.gamma2
+	// Unused without commitments, set as ExtractKeys would
+	vk.CommitmentKey.G = g2
+	vk.CommitmentKey.GRootSigmaNeg.Neg(&g2)
+
+	// sets e, -[δ]2, -[γ]2
+	if err = vk.Precompute(); err != nil {
+		return pk, vk, err
+	}
+	return pk, vk, nil
+}
+
+func ImportZkeyCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Import a snarkjs groth16 zkey of the circuit as groth16 keys.",
+		Use:   "import-zkey [r1cs] [zkey] [provingKeyOutput] [verifyingKeyOutput]",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r1csPath := args[0]
+			var r1cs cs.R1CS
+			err := readFrom(r1csPath, &r1cs)
+			if err != nil {
+				return fmt.Errorf("failed to read r1cs: %v", err)
+			}
+			zkeyPath := args[1]
+			zkey, err := ReadZkey(zkeyPath)
+			if err != nil {
+				return fmt.Errorf("failed to read zkey: %w", err)
+			}
+			pk, vk, err := zkey.toGnark(&r1cs)
+			if err != nil {
+				return err
+			}
+			pkOutput := args[2]
+			err = saveTo(pkOutput, &pk)
+			if err != nil {
+				return fmt.Errorf("failed to write pk: %v", err)
+			}
+			vkOutput := args[3]
+			return saveTo(vkOutput, &vk)
+		},
+	}
+	return cmd
+}
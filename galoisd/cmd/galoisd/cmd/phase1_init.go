@@ -1,15 +1,14 @@
 package cmd
 
 import (
+	"bufio"
 	"crypto/sha256"
 
 	"github.com/spf13/cobra"
 
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"io"
-	"math"
 	"math/big"
 	"os"
 
@@ -18,25 +17,45 @@ import (
 	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
 )
 
+const (
+	flagVerify = "verify"
+)
+
 func Phase1InitCmd() *cobra.Command {
 	var cmd = &cobra.Command{
 		Short: "Initialize the phase 1 of the groth16 multi-party computation.",
 		Use:   "mpc-phase1-init [ptau] [phase1FinalOutput]",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			verify, err := cmd.Flags().GetBool(flagVerify)
+			if err != nil {
+				return err
+			}
 			ptauPath := args[0]
-			ptau, err := ReadPtau(ptauPath)
+			ptauFile, err := InitPtau(ptauPath)
 			if err != nil {
 				return err
 			}
-			srs1, err := convertPtauToPhase1(ptau)
+			defer ptauFile.Close()
+			srs1, err := ptauFile.ReadPhase1()
 			if err != nil {
 				return err
 			}
+			if verify {
+				contributions, err := ptauFile.ReadContributions()
+				if err != nil {
+					return err
+				}
+				if err := verifyPtau(ptauFile.Header, contributions, &srs1); err != nil {
+					return fmt.Errorf("invalid ptau: %w", err)
+				}
+				fmt.Println("the ptau is valid")
+			}
 			phase1FinalPath := args[1]
 			return saveTo(phase1FinalPath, &srs1)
 		},
 	}
+	cmd.Flags().Bool(flagVerify, false, "Verify the powers and the contributions of the ptau before converting it.")
 	return cmd
 }
 
@@ -51,6 +70,7 @@ Header(1)
     n8
     prime
     power
+    ceremonyPower
 tauG1(2)
     {(2 ** power)*2-1} [
         G1, tau*G1, tau^2 * G1, ....
@@ -71,7 +91,7 @@ betaG2(6)
     {1}[
         beta*G2
     ]
-contributions(7) - Ignore contributions, users can verify using snarkjs
+contributions(7) - Only read by mpc-phase1-init --verify
     NContributions
     {NContributions}[
         tau*G1
@@ -91,32 +111,34 @@ contributions(7) - Ignore contributions, users can verify using snarkjs
             beta_g1spx
         partialHash (216 bytes) See https://github.com/mafintosh/blake2b-wasm/blob/23bee06945806309977af802bc374727542617c7/blake2b.wat#L9
         hashNewChallenge
+        type (0 random, 1 beacon)
+        paramsLength
+        params
+            name (1)
+            numIterationsExp (2)
+            beaconHash (3)
     ]
 */
 
 // in bytes
 const BN254_FIELD_ELEMENT_SIZE = 32
 
-type G1 [2]big.Int
-type G2 [4]big.Int
+const (
+	PTAU_G1_SIZE = 2 * BN254_FIELD_ELEMENT_SIZE
+	PTAU_G2_SIZE = 4 * BN254_FIELD_ELEMENT_SIZE
+)
 
-type PtauHeader struct {
-	N8    uint32
-	Prime big.Int
-	Power uint32
-}
+// Largest power of a snarkjs ceremony
+const PTAU_MAX_POWER = 28
 
-type Ptau struct {
-	Header     PtauHeader
-	PTauPubKey PtauPubKey
-}
+// Number of points buffered between the reader and the conversion
+const PTAU_PIPELINE_SIZE = 1 << 10
 
-type PtauPubKey struct {
-	TauG1      []G1
-	TauG2      []G2
-	AlphaTauG1 []G1
-	BetaTauG1  []G1
-	BetaG2     G2
+type PtauHeader struct {
+	N8            uint32
+	Prime         big.Int
+	Power         uint32
+	CeremonyPower uint32
 }
 
 type PtauFile struct {
@@ -127,50 +149,87 @@ type PtauFile struct {
 
 func InitPtau(path string) (*PtauFile, error) {
 	reader, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
 
+	ptauFile, err := readPtauFile(reader)
 	if err != nil {
+		reader.Close()
 		return nil, err
 	}
 
+	return ptauFile, nil
+}
+
+func readPtauFile(reader *os.File) (*PtauFile, error) {
 	var ptauStr = make([]byte, 4)
-	_, err = reader.Read(ptauStr)
+	_, err := io.ReadFull(reader, ptauStr)
+	if err != nil {
+		return nil, err
+	}
+	if string(ptauStr) != "ptau" {
+		return nil, fmt.Errorf("not a ptau file")
+	}
 
 	// version
 	_, err = readULE32(reader)
+	if err != nil {
+		return nil, err
+	}
 
 	// number of sections
-	_, err = readULE32(reader)
-
-	numSections := uint32(7)
+	numSections, err := readULE32(reader)
+	if err != nil {
+		return nil, err
+	}
 
 	// in practice, all sections have only one segment, but who knows...
-	// 1-based indexing, so we need to allocate one more than the number of sections
-	sections := make([][]SectionSegment, numSections+1)
+	// 1-based indexing, the sections of a prepared ptau (12 and above) are ignored
+	sections := make([][]SectionSegment, 7+1)
 	for i := uint32(0); i < numSections; i++ {
-		ht, _ := readULE32(reader)
-		hl, _ := readULE64(reader)
-		if sections[ht] == nil {
-			sections[ht] = make([]SectionSegment, 0)
+		ht, err := readULE32(reader)
+		if err != nil {
+			return nil, err
+		}
+		hl, err := readULE64(reader)
+		if err != nil {
+			return nil, err
+		}
+		pos, err := reader.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		if ht < uint32(len(sections)) {
+			sections[ht] = append(sections[ht], SectionSegment{pos: uint64(pos), size: hl})
+		}
+		_, err = reader.Seek(int64(hl), io.SeekCurrent)
+		if err != nil {
+			return nil, err
 		}
-		pos, _ := reader.Seek(0, io.SeekCurrent)
-		sections[ht] = append(sections[ht], SectionSegment{pos: uint64(pos), size: hl})
-		reader.Seek(int64(hl), io.SeekCurrent)
 	}
 
-	// section size
-	_, err = readBigInt(reader, 8)
+	ptauFile := &PtauFile{Sections: sections, Reader: reader}
 
 	// Header (1)
-	seekToUniqueSection(reader, sections, 1)
-
-	// Read header
-	header, err := readPtauHeader(reader)
+	section, err := ptauFile.openSection(1)
+	if err != nil {
+		return nil, err
+	}
 
+	ptauFile.Header, err = readPtauHeader(section)
 	if err != nil {
 		return nil, err
 	}
 
-	return &PtauFile{Header: header, Sections: sections, Reader: reader}, nil
+	if ptauFile.Header.N8 != BN254_FIELD_ELEMENT_SIZE {
+		return nil, fmt.Errorf("expected %d bytes field elements, got: %d", BN254_FIELD_ELEMENT_SIZE, ptauFile.Header.N8)
+	}
+	if ptauFile.Header.Power > PTAU_MAX_POWER {
+		return nil, fmt.Errorf("the ptau power %d is larger than %d", ptauFile.Header.Power, PTAU_MAX_POWER)
+	}
+
+	return ptauFile, nil
 }
 
 func (ptauFile *PtauFile) Close() error {
@@ -181,51 +240,86 @@ func (ptauFile *PtauFile) DomainSize() int {
 	return 1 << ptauFile.Header.Power
 }
 
-func (ptauFile *PtauFile) readG1s(out chan bn254.G1Affine, count int) error {
-	for i := 0; i < count; i++ {
-		g1, err := readG1(ptauFile.Reader)
-		if err != nil {
-			return err
-		}
-		g1Affine := bn254.G1Affine{}
-		x := bytesToElement(g1[0].Bytes())
-		g1Affine.X = x
-		y := bytesToElement(g1[1].Bytes())
-		g1Affine.Y = y
-		if !g1Affine.IsOnCurve() {
-			panic("g1Affine is not on curve")
-		}
-		out <- g1Affine
+// Buffered reader over the section, which must have a single segment.
+func (ptauFile *PtauFile) openSection(sectionId uint32) (*bufio.Reader, error) {
+	if len(ptauFile.Sections[sectionId]) != 1 {
+		return nil, fmt.Errorf("expected a single segment for the ptau section %d, got: %d", sectionId, len(ptauFile.Sections[sectionId]))
 	}
-	return nil
+	seekToUniqueSection(ptauFile.Reader, ptauFile.Sections, sectionId)
+	size := ptauFile.Sections[sectionId][0].size
+	return bufio.NewReader(io.LimitReader(ptauFile.Reader, int64(size))), nil
 }
 
-func (ptauFile *PtauFile) readG2() (bn254.G2Affine, error) {
-	g2, err := readG2(ptauFile.Reader)
+func (ptauFile *PtauFile) openPointsSection(sectionId uint32, count int, pointSize int) (*bufio.Reader, error) {
+	section, err := ptauFile.openSection(sectionId)
 	if err != nil {
-		return bn254.G2Affine{}, err
+		return nil, err
+	}
+	if size := ptauFile.Sections[sectionId][0].size; size != uint64(count*pointSize) {
+		return nil, fmt.Errorf("expected %d points in the ptau section %d, got %d bytes", count, sectionId, size)
+	}
+	return section, nil
+}
+
+// The coordinates are stored as their little endian montgomery limbs.
+func ptauFp(b []byte) fp.Element {
+	var z fp.Element
+	for i := range z {
+		z[i] = binary.LittleEndian.Uint64(b[i*8 : (i+1)*8])
 	}
-	g2Affine := bn254.G2Affine{}
-	x0 := bytesToElement(g2[0].Bytes())
-	x1 := bytesToElement(g2[1].Bytes())
-	g2Affine.X.A0 = x0
-	g2Affine.X.A1 = x1
-	y0 := bytesToElement(g2[2].Bytes())
-	y1 := bytesToElement(g2[3].Bytes())
-	g2Affine.Y.A0 = y0
-	g2Affine.Y.A1 = y1
-	if !g2Affine.IsOnCurve() {
+	return z
+}
+
+// The point is decoded in place from the buffer of the reader, such that
+// streaming a section doesn't allocate.
+func readPtauG1(reader *bufio.Reader) (bn254.G1Affine, error) {
+	b, err := reader.Peek(PTAU_G1_SIZE)
+	if err != nil {
+		return bn254.G1Affine{}, err
+	}
+	var g1Affine bn254.G1Affine
+	g1Affine.X = ptauFp(b[0:32])
+	g1Affine.Y = ptauFp(b[32:64])
+	reader.Discard(PTAU_G1_SIZE)
+	if !g1Affine.IsInfinity() && !g1Affine.IsOnCurve() {
+		return bn254.G1Affine{}, fmt.Errorf("g1Affine is not on curve")
+	}
+	return g1Affine, nil
+}
 
-		panic("g2Affine is not on curve")
+func readPtauG2(reader *bufio.Reader) (bn254.G2Affine, error) {
+	b, err := reader.Peek(PTAU_G2_SIZE)
+	if err != nil {
+		return bn254.G2Affine{}, err
+	}
+	var g2Affine bn254.G2Affine
+	g2Affine.X.A0 = ptauFp(b[0:32])
+	g2Affine.X.A1 = ptauFp(b[32:64])
+	g2Affine.Y.A0 = ptauFp(b[64:96])
+	g2Affine.Y.A1 = ptauFp(b[96:128])
+	reader.Discard(PTAU_G2_SIZE)
+	if !g2Affine.IsInfinity() && !g2Affine.IsOnCurve() {
+		return bn254.G2Affine{}, fmt.Errorf("g2Affine is not on curve")
 	}
 	return g2Affine, nil
 }
 
-func (ptauFile *PtauFile) readG2s(out chan bn254.G2Affine, count int) error {
+func (ptauFile *PtauFile) readG1s(section *bufio.Reader, out chan bn254.G1Affine, count int) error {
 	for i := 0; i < count; i++ {
-		g2Affine, err := ptauFile.readG2()
+		g1Affine, err := readPtauG1(section)
 		if err != nil {
-			return err
+			return fmt.Errorf("invalid point %d: %w", i, err)
+		}
+		out <- g1Affine
+	}
+	return nil
+}
+
+func (ptauFile *PtauFile) readG2s(section *bufio.Reader, out chan bn254.G2Affine, count int) error {
+	for i := 0; i < count; i++ {
+		g2Affine, err := readPtauG2(section)
+		if err != nil {
+			return fmt.Errorf("invalid point %d: %w", i, err)
 		}
 		out <- g2Affine
 	}
@@ -234,155 +328,114 @@ func (ptauFile *PtauFile) readG2s(out chan bn254.G2Affine, count int) error {
 
 func (ptauFile *PtauFile) ReadTauG1(out chan bn254.G1Affine) error {
 	defer close(out)
-	seekToUniqueSection(ptauFile.Reader, ptauFile.Sections, 2)
 	numPoints := ptauFile.DomainSize()*2 - 1
-	ptauFile.readG1s(out, numPoints)
-	return nil
+	section, err := ptauFile.openPointsSection(2, numPoints, PTAU_G1_SIZE)
+	if err != nil {
+		return err
+	}
+	return ptauFile.readG1s(section, out, numPoints)
 }
 
 func (ptauFile *PtauFile) ReadTauG2(out chan bn254.G2Affine) error {
 	defer close(out)
-	seekToUniqueSection(ptauFile.Reader, ptauFile.Sections, 3)
 	numPoints := ptauFile.DomainSize()
-	ptauFile.readG2s(out, numPoints)
-	return nil
+	section, err := ptauFile.openPointsSection(3, numPoints, PTAU_G2_SIZE)
+	if err != nil {
+		return err
+	}
+	return ptauFile.readG2s(section, out, numPoints)
 }
 
 func (ptauFile *PtauFile) ReadAlphaTauG1(out chan bn254.G1Affine) error {
 	defer close(out)
-	seekToUniqueSection(ptauFile.Reader, ptauFile.Sections, 4)
 	numPoints := ptauFile.DomainSize()
-	ptauFile.readG1s(out, numPoints)
-	return nil
+	section, err := ptauFile.openPointsSection(4, numPoints, PTAU_G1_SIZE)
+	if err != nil {
+		return err
+	}
+	return ptauFile.readG1s(section, out, numPoints)
 }
 
 func (ptauFile *PtauFile) ReadBetaTauG1(out chan bn254.G1Affine) error {
 	defer close(out)
-	seekToUniqueSection(ptauFile.Reader, ptauFile.Sections, 5)
 	numPoints := ptauFile.DomainSize()
-	ptauFile.readG1s(out, numPoints)
-	return nil
-}
-
-func (ptauFile *PtauFile) ReadBetaG2() (bn254.G2Affine, error) {
-	seekToUniqueSection(ptauFile.Reader, ptauFile.Sections, 6)
-	return ptauFile.readG2()
-}
-
-func ReadPtau(zkeyPath string) (Ptau, error) {
-	reader, err := os.Open(zkeyPath)
-	if err != nil {
-		return Ptau{}, err
-	}
-
-	defer reader.Close()
-
-	var ptauStr = make([]byte, 4)
-	_, err = reader.Read(ptauStr)
-	if err != nil {
-		return Ptau{}, err
-	}
-
-	// version
-	_, err = readULE32(reader)
+	section, err := ptauFile.openPointsSection(5, numPoints, PTAU_G1_SIZE)
 	if err != nil {
-		return Ptau{}, err
+		return err
 	}
+	return ptauFile.readG1s(section, out, numPoints)
+}
 
-	// number of sections
-	_, err = readULE32(reader)
+func (ptauFile *PtauFile) ReadBetaG2() (bn254.G2Affine, error) {
+	section, err := ptauFile.openPointsSection(6, 1, PTAU_G2_SIZE)
 	if err != nil {
-		return Ptau{}, err
-	}
-
-	numSections := uint32(7)
-
-	// in practice, all sections have only one segment, but who knows...
-	// 1-based indexing, so we need to allocate one more than the number of sections
-	sections := make([][]SectionSegment, numSections+1)
-	for i := uint32(0); i < numSections; i++ {
-		ht, err := readULE32(reader)
-		if err != nil {
-			return Ptau{}, err
-		}
-		hl, err := readULE64(reader)
-		if err != nil {
-			return Ptau{}, err
-		}
-		if sections[ht] == nil {
-			sections[ht] = make([]SectionSegment, 0)
-		}
-		pos, err := reader.Seek(0, io.SeekCurrent)
-		if err != nil {
-			return Ptau{}, err
-		}
-		sections[ht] = append(sections[ht], SectionSegment{pos: uint64(pos), size: hl})
-		reader.Seek(int64(hl), io.SeekCurrent)
+		return bn254.G2Affine{}, err
 	}
+	return readPtauG2(section)
+}
 
-	// section size
-	_, err = readBigInt(reader, 8)
-	if err != nil {
-		return Ptau{}, err
+// Store the points streamed by read, which runs concurrently so that the
+// file is decoded while the points are converted.
+func collectPoints[T any](points []T, read func(chan T) error) error {
+	out := make(chan T, PTAU_PIPELINE_SIZE)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- read(out)
+	}()
+	i := 0
+	for point := range out {
+		points[i] = point
+		i++
 	}
+	return <-errs
+}
 
-	// Header (1)
-	seekToUniqueSection(reader, sections, 1)
+// Stream the powers of the ptau into a phase 1. On top of the phase 1
+// itself, the memory is bounded by the pipeline whatever the power.
+func (ptauFile *PtauFile) ReadPhase1() (mpc.Phase1, error) {
+	// Only the public keys are kept, the parameters are the ones of the ptau
+	phase1 := mpc.InitPhase1(0)
 
-	// Read header
-	header, err := readPtauHeader(reader)
-	if err != nil {
-		return Ptau{}, err
-	}
+	N := ptauFile.DomainSize()
+	params := &phase1.Parameters
+	params.G1.Tau = make([]bn254.G1Affine, 2*N-1)
+	params.G1.AlphaTau = make([]bn254.G1Affine, N)
+	params.G1.BetaTau = make([]bn254.G1Affine, N)
+	params.G2.Tau = make([]bn254.G2Affine, N)
 
 	// TauG1 (2)
-	seekToUniqueSection(reader, sections, 2)
-
-	var PtauPubKey PtauPubKey
-
-	twoToPower := uint32(1 << header.Power)
-
-	PtauPubKey.TauG1, err = readG1Array(reader, twoToPower*2-1)
-	if err != nil {
-		return Ptau{}, err
+	if err := collectPoints(params.G1.Tau, ptauFile.ReadTauG1); err != nil {
+		return mpc.Phase1{}, fmt.Errorf("failed to read tauG1: %w", err)
 	}
-
 	// TauG2 (3)
-	seekToUniqueSection(reader, sections, 3)
-
-	PtauPubKey.TauG2, err = readG2Array(reader, twoToPower)
-	if err != nil {
-		return Ptau{}, err
+	if err := collectPoints(params.G2.Tau, ptauFile.ReadTauG2); err != nil {
+		return mpc.Phase1{}, fmt.Errorf("failed to read tauG2: %w", err)
 	}
-
 	// AlphaTauG1 (4)
-	seekToUniqueSection(reader, sections, 4)
-
-	PtauPubKey.AlphaTauG1, err = readG1Array(reader, twoToPower)
-	if err != nil {
-		return Ptau{}, err
+	if err := collectPoints(params.G1.AlphaTau, ptauFile.ReadAlphaTauG1); err != nil {
+		return mpc.Phase1{}, fmt.Errorf("failed to read alphaTauG1: %w", err)
 	}
-
 	// BetaTauG1 (5)
-	seekToUniqueSection(reader, sections, 5)
-
-	PtauPubKey.BetaTauG1, err = readG1Array(reader, twoToPower)
-	if err != nil {
-		return Ptau{}, err
+	if err := collectPoints(params.G1.BetaTau, ptauFile.ReadBetaTauG1); err != nil {
+		return mpc.Phase1{}, fmt.Errorf("failed to read betaTauG1: %w", err)
 	}
-
 	// BetaG2 (6)
-	seekToUniqueSection(reader, sections, 6)
-
-	PtauPubKey.BetaG2, err = readG2(reader)
+	betaG2, err := ptauFile.ReadBetaG2()
 	if err != nil {
-		return Ptau{}, err
+		return mpc.Phase1{}, fmt.Errorf("failed to read betaG2: %w", err)
 	}
+	params.G2.Beta = betaG2
+
+	// Same hash as gnark, without the previous one
+	phase1.Hash = nil
+	sha := sha256.New()
+	phase1.WriteTo(sha)
+	phase1.Hash = sha.Sum(nil)
 
-	return Ptau{Header: header, PTauPubKey: PtauPubKey}, nil
+	return phase1, nil
 }
 
-func readPtauHeader(reader io.ReadSeeker) (PtauHeader, error) {
+func readPtauHeader(reader io.Reader) (PtauHeader, error) {
 	var header PtauHeader
 	n8, err := readULE32(reader)
 	if err != nil {
@@ -404,110 +457,20 @@ func readPtauHeader(reader io.ReadSeeker) (PtauHeader, error) {
 	}
 
 	header.Power = power
-	return header, nil
-}
-
-func readG1Array(reader io.ReadSeeker, numPoints uint32) ([]G1, error) {
-	g1s := make([]G1, numPoints)
-	for i := uint32(0); i < numPoints; i++ {
-		g1, err := readG1(reader)
-		if err != nil {
-			return []G1{}, err
-		}
-
-		g1s[i] = g1
-	}
-	return g1s, nil
-}
-
-func readG2Array(reader io.ReadSeeker, numPoints uint32) ([]G2, error) {
-	g2s := make([]G2, numPoints)
 
-	for i := uint32(0); i < numPoints; i++ {
-		g2, err := readG2(reader)
-
-		if err != nil {
-			return []G2{}, err
-		}
-
-		g2s[i] = g2
-	}
-
-	return g2s, nil
-}
-
-func readTauG2(reader io.ReadSeeker) ([]G2, error) {
-	tauG2_s, err := readG2(reader)
-	if err != nil {
-		return []G2{}, err
-	}
-
-	tauG2_sx, err := readG2(reader)
-	if err != nil {
-		return []G2{}, err
-	}
-
-	return []G2{tauG2_s, tauG2_sx}, nil
-}
-
-func readG1(reader io.ReadSeeker) (G1, error) {
-	var g1 G1
-
-	x, err := readBigInt(reader, BN254_FIELD_ELEMENT_SIZE)
-	if err != nil {
-		return G1{}, err
-	}
-
-	g1[0] = x
-
-	y, err := readBigInt(reader, BN254_FIELD_ELEMENT_SIZE)
-	if err != nil {
-		return G1{}, err
-	}
-
-	g1[1] = y
-
-	return g1, nil
-}
-
-func readG2(reader io.ReadSeeker) (G2, error) {
-	var g2 G2
-
-	x0, err := readBigInt(reader, BN254_FIELD_ELEMENT_SIZE)
-	if err != nil {
-		return G2{}, err
-	}
-
-	g2[0] = x0
-
-	x1, err := readBigInt(reader, BN254_FIELD_ELEMENT_SIZE)
+	ceremonyPower, err := readULE32(reader)
 	if err != nil {
-		return G2{}, err
-	}
-
-	g2[1] = x1
-
-	y0, err := readBigInt(reader, BN254_FIELD_ELEMENT_SIZE)
-	if err != nil {
-		return G2{}, err
-	}
-
-	g2[2] = y0
-
-	y1, err := readBigInt(reader, BN254_FIELD_ELEMENT_SIZE)
-	if err != nil {
-		return G2{}, err
+		return PtauHeader{}, err
 	}
 
-	g2[3] = y1
-
-	return g2, nil
+	header.CeremonyPower = ceremonyPower
+	return header, nil
 }
 
 func readULE32(reader io.Reader) (uint32, error) {
 	var buffer = make([]byte, 4)
 
-	_, err := reader.Read(buffer)
+	_, err := io.ReadFull(reader, buffer)
 	if err != nil {
 		return 0, err
 	}
@@ -518,7 +481,7 @@ func readULE32(reader io.Reader) (uint32, error) {
 func readULE64(reader io.Reader) (uint64, error) {
 	var buffer = make([]byte, 8)
 
-	_, err := reader.Read(buffer)
+	_, err := io.ReadFull(reader, buffer)
 	if err != nil {
 		return 0, err
 	}
@@ -529,7 +492,7 @@ func readULE64(reader io.Reader) (uint64, error) {
 func readBigInt(reader io.Reader, n8 uint32) (big.Int, error) {
 	var buffer = make([]byte, n8)
 
-	_, err := reader.Read(buffer)
+	_, err := io.ReadFull(reader, buffer)
 	reverseSlice(buffer)
 
 	if err != nil {
@@ -549,144 +512,11 @@ func reverseSlice(slice []byte) []byte {
 	return slice
 }
 
-func bytesToElement(b []byte) fp.Element {
-	var z fp.Element
-	reverseSlice(b)
-	if len(b) < 32 {
-		b = append(b, make([]byte, 32-len(b))...)
-	}
-
-	z[0] = binary.LittleEndian.Uint64(b[0:8])
-	z[1] = binary.LittleEndian.Uint64(b[8:16])
-	z[2] = binary.LittleEndian.Uint64(b[16:24])
-	z[3] = binary.LittleEndian.Uint64(b[24:32])
-
-	return z
-}
-
-///////////////////////////////////////////////////////////////////
-///                             ZKEY                            ///
-///////////////////////////////////////////////////////////////////
-
-// Taken from the iden3/snarkjs repo, zkey_utils.js
-// (https://github.com/iden3/snarkjs/blob/fb144555d8ce4779ad79e707f269771c672a8fb7/src/zkey_utils.js#L20-L45)
-// Format
-// ======
-// 4 bytes, zket
-// 4 bytes, version
-// 4 bytes, number of sections
-// 4 bytes, section number
-// 8 bytes, section size
-// Header(1)
-// 4 bytes, Prover Type 1 Groth
-// HeaderGroth(2)
-// 4 bytes, n8q
-// n8q bytes, q
-// 4 bytes, n8r
-// n8r bytes, r
-// 4 bytes, NVars
-// 4 bytes, NPub
-// 4 bytes, DomainSize  (multiple of 2)
-//      alpha1
-//      beta1
-//      delta1
-//      beta2
-//      gamma2
-//      delta2
-
-const GROTH_16_PROTOCOL_ID = uint32(1)
-
-type NotGroth16 struct {
-	Err error
-}
-
-func (r *NotGroth16) Error() string {
-	return fmt.Sprintf("Groth16 is the only supported protocol at this time (PLONK and FFLONK are not): %v", r.Err)
-}
-
-// Incomplete (only extracts necessary fields for conversion to .ph1 format)
-type Zkey struct {
-	ZkeyHeader     ZkeyHeader
-	protocolHeader HeaderGroth
-}
-
-type ZkeyHeader struct {
-	ProtocolID     uint32
-	protocolHeader HeaderGroth
-}
-
-type HeaderGroth struct {
-	n8q        uint32
-	q          big.Int
-	n8r        uint32
-	r          big.Int
-	nVars      uint32
-	nPublic    uint32
-	domainSize uint32
-	power      uint32
-}
-
 type SectionSegment struct {
 	pos  uint64
 	size uint64
 }
 
-func ReadZkey(zkeyPath string) (Zkey, error) {
-	reader, err := os.Open(zkeyPath)
-
-	if err != nil {
-		return Zkey{}, err
-	}
-
-	defer reader.Close()
-
-	// zkey
-	var zkeyStr = make([]byte, 4)
-	_, err = reader.Read(zkeyStr)
-	if err != nil {
-		return Zkey{}, err
-	}
-
-	// version
-	_, err = readULE32(reader)
-	if err != nil {
-		return Zkey{}, err
-	}
-
-	// number of sections
-	numSections, err := readULE32(reader)
-
-	// in practice, all sections have only one segment, but who knows...
-	// 1-based indexing, so we need to allocate one more than the number of sections
-	sections := make([][]SectionSegment, numSections+1)
-	for i := uint32(0); i < numSections; i++ {
-		ht, _ := readULE32(reader)
-		hl, _ := readULE64(reader)
-		if sections[ht] == nil {
-			sections[ht] = make([]SectionSegment, 0)
-		}
-		pos, _ := reader.Seek(0, io.SeekCurrent)
-		sections[ht] = append(sections[ht], SectionSegment{pos: uint64(pos), size: hl})
-		reader.Seek(int64(hl), io.SeekCurrent)
-	}
-
-	// section size
-	_, err = readBigInt(reader, 8)
-	if err != nil {
-		return Zkey{}, err
-	}
-
-	seekToUniqueSection(reader, sections, 1)
-	header, err := readHeader(reader, sections)
-	if err != nil {
-		return Zkey{}, err
-	}
-
-	zkey := Zkey{ZkeyHeader: header, protocolHeader: header.protocolHeader}
-
-	return zkey, nil
-}
-
 func seekToUniqueSection(reader io.ReadSeeker, sections [][]SectionSegment, sectionId uint32) {
 	section := sections[sectionId]
 
@@ -696,174 +526,3 @@ func seekToUniqueSection(reader io.ReadSeeker, sections [][]SectionSegment, sect
 
 	reader.Seek(int64(section[0].pos), io.SeekStart)
 }
-
-func readHeader(reader io.ReadSeeker, sections [][]SectionSegment) (ZkeyHeader, error) {
-	var header = ZkeyHeader{}
-
-	protocolID, err := readULE32(reader)
-
-	if err != nil {
-		return header, err
-	}
-
-	// if groth16
-	if protocolID == GROTH_16_PROTOCOL_ID {
-		seekToUniqueSection(reader, sections, 2)
-		headerGroth, err := readHeaderGroth16(reader)
-
-		if err != nil {
-			return header, err
-		}
-
-		header = ZkeyHeader{ProtocolID: protocolID, protocolHeader: headerGroth}
-
-	} else {
-		return header, &NotGroth16{Err: errors.New("ProtocolID is not Groth16")}
-	}
-
-	return header, nil
-}
-
-func readHeaderGroth16(reader io.ReadSeeker) (HeaderGroth, error) {
-	var header = HeaderGroth{}
-
-	n8q, err := readULE32(reader)
-	if err != nil {
-		return header, err
-	}
-
-	q, err := readBigInt(reader, n8q)
-	if err != nil {
-		return header, err
-	}
-
-	n8r, err := readULE32(reader)
-	if err != nil {
-		return header, err
-	}
-
-	r, err := readBigInt(reader, n8r)
-	if err != nil {
-		return header, err
-	}
-
-	nVars, err := readULE32(reader)
-	if err != nil {
-		return header, err
-	}
-
-	nPublic, err := readULE32(reader)
-	if err != nil {
-		return header, err
-	}
-
-	domainSize, err := readULE32(reader)
-	if err != nil {
-		return header, err
-	}
-
-	power := math.Log2(float64(domainSize))
-
-	power_int := uint32(math.Ceil(power))
-
-	header = HeaderGroth{n8q: n8q, q: q, n8r: n8r, r: r, nVars: nVars, nPublic: nPublic, domainSize: domainSize, power: power_int}
-
-	return header, nil
-}
-
-func convertPtauToPhase1(ptau Ptau) (phase1 mpc.Phase1, err error) {
-	tauG1 := make([]bn254.G1Affine, len(ptau.PTauPubKey.TauG1))
-	for i, g1 := range ptau.PTauPubKey.TauG1 {
-		g1Affine := bn254.G1Affine{}
-		x := bytesToElement(g1[0].Bytes())
-		g1Affine.X = x
-		y := bytesToElement(g1[1].Bytes())
-		g1Affine.Y = y
-		if !g1Affine.IsOnCurve() {
-			fmt.Printf("tauG1: \n index: %v g1Affine.X: %v \n g1Affine.Y: %v \n", i, g1Affine.X.String(), g1Affine.Y.String())
-			panic("g1Affine is not on curve")
-		}
-		tauG1[i] = g1Affine
-	}
-
-	alphaTauG1 := make([]bn254.G1Affine, len(ptau.PTauPubKey.AlphaTauG1))
-	for i, g1 := range ptau.PTauPubKey.AlphaTauG1 {
-		g1Affine := bn254.G1Affine{}
-		x := bytesToElement(g1[0].Bytes())
-		g1Affine.X = x
-		y := bytesToElement(g1[1].Bytes())
-		g1Affine.Y = y
-		if !g1Affine.IsOnCurve() {
-			fmt.Printf("alphaTauG1: \n index: %v g1Affine.X: %v \n g1Affine.Y: %v \n", i, g1Affine.X.String(), g1Affine.Y.String())
-			panic("g1Affine is not on curve")
-		}
-		alphaTauG1[i] = g1Affine
-	}
-	// fmt.Printf("alphaTauG1: %v \n", alphaTauG1)
-
-	betaTauG1 := make([]bn254.G1Affine, len(ptau.PTauPubKey.BetaTauG1))
-
-	for i, g1 := range ptau.PTauPubKey.BetaTauG1 {
-		g1Affine := bn254.G1Affine{}
-		x := bytesToElement(g1[0].Bytes())
-		g1Affine.X = x
-		y := bytesToElement(g1[1].Bytes())
-		g1Affine.Y = y
-		if !g1Affine.IsOnCurve() {
-			fmt.Printf("betaTauG1: \n index: %v, g1Affine.X: %v \n g1Affine.Y: %v \n", i, g1Affine.X.String(), g1Affine.Y.String())
-			panic("g1Affine is not on curve")
-		}
-		betaTauG1[i] = g1Affine
-	}
-	tauG2 := make([]bn254.G2Affine, len(ptau.PTauPubKey.TauG2))
-	for i, g2 := range ptau.PTauPubKey.TauG2 {
-		g2Affine := bn254.G2Affine{}
-		x0 := bytesToElement(g2[0].Bytes())
-		x1 := bytesToElement(g2[1].Bytes())
-		g2Affine.X.A0 = x0
-		g2Affine.X.A1 = x1
-		y0 := bytesToElement(g2[2].Bytes())
-		y1 := bytesToElement(g2[3].Bytes())
-		g2Affine.Y.A0 = y0
-		g2Affine.Y.A1 = y1
-		if !g2Affine.IsOnCurve() {
-			fmt.Printf("tauG2: \n index: %v, g2Affine.X.A0: %v \n g2Affine.X.A1: %v \n g2Affine.Y.A0: %v \n g2Affine.Y.A1 %v \n", i, g2Affine.X.A0.String(), g2Affine.X.A1.String(), g2Affine.Y.A0.String(), g2Affine.Y.A1.String())
-			panic("g2Affine is not on curve")
-		}
-		tauG2[i] = g2Affine
-	}
-
-	betaG2 := bn254.G2Affine{}
-	{
-		g2 := ptau.PTauPubKey.BetaG2
-
-		x0 := bytesToElement(g2[0].Bytes())
-		x1 := bytesToElement(g2[1].Bytes())
-		betaG2.X.A0 = x0
-		betaG2.X.A1 = x1
-		y0 := bytesToElement(g2[2].Bytes())
-		y1 := bytesToElement(g2[3].Bytes())
-		betaG2.Y.A0 = y0
-		betaG2.Y.A1 = y1
-
-		if !betaG2.IsOnCurve() {
-			fmt.Printf("g2Affine.X.A0: %v \n g2Affine.X.A1: %v \n g2Affine.Y.A0: %v \n g2Affine.Y.A1 %v \n", betaG2.X.A0.String(), betaG2.X.String(), betaG2.Y.A0.String(), betaG2.Y.A1.String())
-			panic("g2Affine is not on curve")
-		}
-	}
-
-	phase1 = mpc.InitPhase1(int(ptau.Header.Power))
-
-	phase1.Parameters.G1.Tau = tauG1
-	phase1.Parameters.G1.AlphaTau = alphaTauG1
-	phase1.Parameters.G1.BetaTau = betaTauG1
-
-	phase1.Parameters.G2.Tau = tauG2
-	phase1.Parameters.G2.Beta = betaG2
-
-	sha := sha256.New()
-	phase1.WriteTo(sha)
-	phase1.Hash = sha.Sum(nil)
-
-	return phase1, nil
-}
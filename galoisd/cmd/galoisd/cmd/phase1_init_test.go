@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendPtauFp(b []byte, z *fp.Element) []byte {
+	for _, limb := range z {
+		b = binary.LittleEndian.AppendUint64(b, limb)
+	}
+	return b
+}
+
+func appendPtauG1(b []byte, g1 *bn254.G1Affine) []byte {
+	return appendPtauFp(appendPtauFp(b, &g1.X), &g1.Y)
+}
+
+func appendPtauG2(b []byte, g2 *bn254.G2Affine) []byte {
+	for _, z := range []*fp.Element{&g2.X.A0, &g2.X.A1, &g2.Y.A0, &g2.Y.A1} {
+		b = appendPtauFp(b, z)
+	}
+	return b
+}
+
+// Write a ptau of the given power whose i-th point of every section is
+// [i+1] times the generator, which is enough to check the conversion.
+func writeSyntheticPtau(t *testing.T, path string, power uint32) {
+	N := 1 << power
+	_, _, g1, g2 := bn254.Generators()
+	var phase1 mpc.Phase1
+	params := &phase1.Parameters
+	params.G1.Tau = make([]bn254.G1Affine, 2*N-1)
+	params.G2.Tau = make([]bn254.G2Affine, N)
+	var p1 bn254.G1Affine
+	var p2 bn254.G2Affine
+	for i := 0; i < 2*N-1; i++ {
+		p1.Add(&p1, &g1)
+		params.G1.Tau[i] = p1
+		if i < N {
+			p2.Add(&p2, &g2)
+			params.G2.Tau[i] = p2
+		}
+	}
+	params.G1.AlphaTau = params.G1.Tau[:N]
+	params.G1.BetaTau = params.G1.Tau[:N]
+	params.G2.Beta = params.G2.Tau[0]
+	writePtau(t, path, power, &phase1)
+}
+
+// Write a ptau of the given powers followed by the serialized contributions.
+func writePtau(t *testing.T, path string, power uint32, phase1 *mpc.Phase1, contributions ...[]byte) {
+	params := &phase1.Parameters
+	g1s := func(points []bn254.G1Affine) []byte {
+		b := make([]byte, 0, len(points)*PTAU_G1_SIZE)
+		for i := range points {
+			b = appendPtauG1(b, &points[i])
+		}
+		return b
+	}
+	g2s := func(points []bn254.G2Affine) []byte {
+		b := make([]byte, 0, len(points)*PTAU_G2_SIZE)
+		for i := range points {
+			b = appendPtauG2(b, &points[i])
+		}
+		return b
+	}
+
+	header := binary.LittleEndian.AppendUint32(nil, BN254_FIELD_ELEMENT_SIZE)
+	modulus := fp.Modulus().FillBytes(make([]byte, BN254_FIELD_ELEMENT_SIZE))
+	header = append(header, reverseSlice(modulus)...)
+	header = binary.LittleEndian.AppendUint32(header, power)
+	header = binary.LittleEndian.AppendUint32(header, power)
+	contributionsSection := binary.LittleEndian.AppendUint32(nil, uint32(len(contributions)))
+	for _, contribution := range contributions {
+		contributionsSection = append(contributionsSection, contribution...)
+	}
+	sections := [][]byte{
+		header,
+		g1s(params.G1.Tau),
+		g2s(params.G2.Tau),
+		g1s(params.G1.AlphaTau),
+		g1s(params.G1.BetaTau),
+		g2s([]bn254.G2Affine{params.G2.Beta}),
+		contributionsSection,
+	}
+
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	w := bufio.NewWriter(file)
+	w.WriteString("ptau")
+	w.Write(binary.LittleEndian.AppendUint32(nil, 1))
+	w.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(sections))))
+	for i, section := range sections {
+		w.Write(binary.LittleEndian.AppendUint32(nil, uint32(i+1)))
+		w.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(section))))
+		w.Write(section)
+	}
+	require.NoError(t, w.Flush())
+}
+
+func TestReadPhase1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "synthetic.ptau")
+	writeSyntheticPtau(t, path, 4)
+	ptauFile, err := InitPtau(path)
+	require.NoError(t, err)
+	defer ptauFile.Close()
+
+	phase1, err := ptauFile.ReadPhase1()
+	require.NoError(t, err)
+
+	params := &phase1.Parameters
+	require.Len(t, params.G1.Tau, 31)
+	require.Len(t, params.G1.AlphaTau, 16)
+	require.Len(t, params.G1.BetaTau, 16)
+	require.Len(t, params.G2.Tau, 16)
+	_, _, g1, g2 := bn254.Generators()
+	var p1 bn254.G1Affine
+	var p2 bn254.G2Affine
+	for i := range params.G1.Tau {
+		p1.Add(&p1, &g1)
+		assert.True(t, p1.Equal(&params.G1.Tau[i]))
+		if i < 16 {
+			p2.Add(&p2, &g2)
+			assert.True(t, p1.Equal(&params.G1.AlphaTau[i]))
+			assert.True(t, p1.Equal(&params.G1.BetaTau[i]))
+			assert.True(t, p2.Equal(&params.G2.Tau[i]))
+		}
+	}
+	assert.True(t, g2.Equal(&params.G2.Beta))
+
+	contributions, err := ptauFile.ReadContributions()
+	require.NoError(t, err)
+	assert.Empty(t, contributions)
+}
+
+func TestReadPhase1RejectsInvalidPoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "synthetic.ptau")
+	writeSyntheticPtau(t, path, 4)
+	ptauFile, err := InitPtau(path)
+	require.NoError(t, err)
+	// Flip a bit of the y coordinate of the third tauG1 point
+	offset := ptauFile.Sections[2][0].pos + 2*PTAU_G1_SIZE + BN254_FIELD_ELEMENT_SIZE
+	require.NoError(t, ptauFile.Close())
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	require.NoError(t, err)
+	b := make([]byte, 1)
+	_, err = file.ReadAt(b, int64(offset))
+	require.NoError(t, err)
+	b[0] ^= 1
+	_, err = file.WriteAt(b, int64(offset))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	ptauFile, err = InitPtau(path)
+	require.NoError(t, err)
+	defer ptauFile.Close()
+	_, err = ptauFile.ReadPhase1()
+	require.ErrorContains(t, err, "failed to read tauG1: invalid point 2")
+}
+
+// The phase 1 is allocated upfront, every other allocation of the conversion
+// must not depend on the power. The total allocated bytes bound the peak heap.
+func TestReadPhase1BoundedMemory(t *testing.T) {
+	overheads := make(map[uint32]uint64)
+	for _, power := range []uint32{8, 14} {
+		path := filepath.Join(t.TempDir(), "synthetic.ptau")
+		writeSyntheticPtau(t, path, power)
+		ptauFile, err := InitPtau(path)
+		require.NoError(t, err)
+
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		phase1, err := ptauFile.ReadPhase1()
+		runtime.ReadMemStats(&after)
+		require.NoError(t, err)
+		ptauFile.Close()
+
+		params := &phase1.Parameters
+		phase1Size := uint64(len(params.G1.Tau)+len(params.G1.AlphaTau)+len(params.G1.BetaTau))*uint64(unsafe.Sizeof(bn254.G1Affine{})) +
+			uint64(len(params.G2.Tau))*uint64(unsafe.Sizeof(bn254.G2Affine{}))
+		allocated := after.TotalAlloc - before.TotalAlloc
+		require.GreaterOrEqual(t, allocated, phase1Size)
+		overheads[power] = allocated - phase1Size
+		t.Logf("power %d: phase 1 of %d bytes, %d bytes allocated on top of it", power, phase1Size, overheads[power])
+	}
+	// 64 times more points, same overhead
+	assert.Less(t, overheads[14], uint64(1<<20))
+	assert.InDelta(t, overheads[8], overheads[14], 64<<10)
+}
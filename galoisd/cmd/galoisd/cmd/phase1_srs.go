@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/spf13/cobra"
+)
+
+func Phase1SRSCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Extract the universal KZG SRS used by the plonk backend from the phase 1 powers of tau.",
+		Use:   "mpc-phase1-srs [phase1Final] [srsOutput]",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			phase1Path := args[0]
+			var srs1 mpc.Phase1
+			err := readFrom(phase1Path, &srs1)
+			if err != nil {
+				return fmt.Errorf("failed to read phase1: %v", err)
+			}
+			srs, err := phase1ToSRS(&srs1)
+			if err != nil {
+				return err
+			}
+			srsPath := args[1]
+			return saveTo(srsPath, srs)
+		},
+	}
+	return cmd
+}
+
+// The KZG SRS is made of the {[τ⁰]₁, [τ¹]₁, …} powers and [τ]₂, all of
+// which are already part of the phase 1.
+func phase1ToSRS(srs1 *mpc.Phase1) (*kzg.SRS, error) {
+	if len(srs1.Parameters.G1.Tau) < 2 || len(srs1.Parameters.G2.Tau) < 2 {
+		return nil, fmt.Errorf("phase1 is too small to extract an SRS")
+	}
+	var srs kzg.SRS
+	srs.Pk.G1 = srs1.Parameters.G1.Tau
+	srs.Vk.G1 = srs1.Parameters.G1.Tau[0]
+	srs.Vk.G2[0] = srs1.Parameters.G2.Tau[0]
+	srs.Vk.G2[1] = srs1.Parameters.G2.Tau[1]
+	srs.Vk.Lines[0] = bn254.PrecomputeLines(srs.Vk.G2[0])
+	srs.Vk.Lines[1] = bn254.PrecomputeLines(srs.Vk.G2[1])
+	return &srs, nil
+}
@@ -0,0 +1,610 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"golang.org/x/crypto/blake2b"
+)
+
+// The powers are checked by chunks to bound the memory of the scalars
+const PTAU_VERIFY_CHUNK_SIZE = 1 << 16
+
+// Contribution types
+const (
+	PTAU_CONTRIBUTION_RANDOM = 0
+	PTAU_CONTRIBUTION_BEACON = 1
+)
+
+// Blake2b personalizations of the proof of knowledge of each secret
+const (
+	PTAU_PERSONALIZATION_TAU   = 0
+	PTAU_PERSONALIZATION_ALPHA = 1
+	PTAU_PERSONALIZATION_BETA  = 2
+)
+
+// Size of a blake2b-wasm context without the output length, see the
+// partialHash of the contributions section.
+const PTAU_PARTIAL_HASH_SIZE = 216
+
+var (
+	// Cofactor of the twist, 2p-r, by which snarkjs multiplies the random G2 points
+	g2Cofactor, _ = new(big.Int).SetString("30644e72e131a029b85045b68181585e06ceecda572a2489345f2299c0f9fa8d", 16)
+	// Coefficient b of the twist, 3/(9+u)
+	g2B bn254.E2
+)
+
+func init() {
+	var xi bn254.E2
+	xi.A0.SetUint64(9)
+	xi.A1.SetOne()
+	xi.Inverse(&xi)
+	g2B.A0.SetUint64(3)
+	g2B.Mul(&g2B, &xi)
+}
+
+// A secret s of a contributor proven with [s']₁, [s's]₁ and [s·H(s', s's)]₂
+type PtauKey struct {
+	G1S   bn254.G1Affine
+	G1SX  bn254.G1Affine
+	G2SPX bn254.G2Affine
+}
+
+type PtauContribution struct {
+	TauG1         bn254.G1Affine
+	TauG2         bn254.G2Affine
+	AlphaG1       bn254.G1Affine
+	BetaG1        bn254.G1Affine
+	BetaG2        bn254.G2Affine
+	Tau           PtauKey
+	Alpha         PtauKey
+	Beta          PtauKey
+	PartialHash   [PTAU_PARTIAL_HASH_SIZE]byte
+	NextChallenge [blake2b.Size]byte
+	Type          uint32
+	// Parameters
+	Name             string
+	NumIterationsExp uint8
+	BeaconHash       []byte
+}
+
+func readPtauContribution(reader *bufio.Reader) (PtauContribution, error) {
+	var c PtauContribution
+	var err error
+	if c.TauG1, err = readPtauG1(reader); err != nil {
+		return c, err
+	}
+	if c.TauG2, err = readPtauG2(reader); err != nil {
+		return c, err
+	}
+	for _, g1 := range []*bn254.G1Affine{&c.AlphaG1, &c.BetaG1} {
+		if *g1, err = readPtauG1(reader); err != nil {
+			return c, err
+		}
+	}
+	if c.BetaG2, err = readPtauG2(reader); err != nil {
+		return c, err
+	}
+	// The public key is [tau g1_s, tau g1_sx, alpha g1_s, alpha g1_sx, beta g1_s, beta g1_sx, tau g2_spx, alpha g2_spx, beta g2_spx]
+	for _, g1 := range []*bn254.G1Affine{&c.Tau.G1S, &c.Tau.G1SX, &c.Alpha.G1S, &c.Alpha.G1SX, &c.Beta.G1S, &c.Beta.G1SX} {
+		if *g1, err = readPtauG1(reader); err != nil {
+			return c, err
+		}
+	}
+	for _, g2 := range []*bn254.G2Affine{&c.Tau.G2SPX, &c.Alpha.G2SPX, &c.Beta.G2SPX} {
+		if *g2, err = readPtauG2(reader); err != nil {
+			return c, err
+		}
+	}
+	if _, err = io.ReadFull(reader, c.PartialHash[:]); err != nil {
+		return c, err
+	}
+	if _, err = io.ReadFull(reader, c.NextChallenge[:]); err != nil {
+		return c, err
+	}
+	if c.Type, err = readULE32(reader); err != nil {
+		return c, err
+	}
+	paramsLength, err := readULE32(reader)
+	if err != nil {
+		return c, err
+	}
+	params := make([]byte, paramsLength)
+	if _, err = io.ReadFull(reader, params); err != nil {
+		return c, err
+	}
+	// Sorted (type, value) pairs
+	lastType := byte(0)
+	for len(params) > 0 {
+		paramType := params[0]
+		if paramType <= lastType {
+			return c, fmt.Errorf("the parameters of the contribution must be sorted")
+		}
+		lastType = paramType
+		var value []byte
+		switch paramType {
+		// Name and beacon hash are length prefixed
+		case 1, 3:
+			if len(params) < 2 || len(params) < 2+int(params[1]) {
+				return c, io.ErrUnexpectedEOF
+			}
+			value = params[2 : 2+params[1]]
+			params = params[2+params[1]:]
+		case 2:
+			if len(params) < 2 {
+				return c, io.ErrUnexpectedEOF
+			}
+			value = params[1:2]
+			params = params[2:]
+		default:
+			return c, fmt.Errorf("unknown contribution parameter: %d", paramType)
+		}
+		switch paramType {
+		case 1:
+			c.Name = string(value)
+		case 2:
+			c.NumIterationsExp = value[0]
+		case 3:
+			c.BeaconHash = bytes.Clone(value)
+		}
+	}
+	return c, nil
+}
+
+func (ptauFile *PtauFile) ReadContributions() ([]PtauContribution, error) {
+	if len(ptauFile.Sections[7]) == 0 {
+		return nil, fmt.Errorf("the ptau has no contributions section")
+	}
+	// Contributions (7)
+	section, err := ptauFile.openSection(7)
+	if err != nil {
+		return nil, err
+	}
+	nContributions, err := readULE32(section)
+	if err != nil {
+		return nil, err
+	}
+	contributions := make([]PtauContribution, nContributions)
+	for i := range contributions {
+		contributions[i], err = readPtauContribution(section)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the contribution #%d: %w", i+1, err)
+		}
+	}
+	return contributions, nil
+}
+
+// Response hash of the contributor, i.e. the hash of the challenge and of
+// its (compressed) response resumed with the public key.
+func (c *PtauContribution) ResponseHash(ceremonyPower uint32) ([blake2b.Size]byte, error) {
+	var responseHash [blake2b.Size]byte
+	// blake2b-wasm follows the RFC 7693 reference context:
+	// b[128] | h[8]uint64 | t[2]uint64 | c
+	state := c.PartialHash
+	block := state[0:128]
+	t0 := binary.LittleEndian.Uint64(state[192:200])
+	t1 := binary.LittleEndian.Uint64(state[200:208])
+	offset := binary.LittleEndian.Uint64(state[208:216])
+	domainSize := uint64(1) << ceremonyPower
+	responseSize := blake2b.Size + (domainSize*2-1)*bn254.SizeOfG1AffineCompressed + domainSize*bn254.SizeOfG2AffineCompressed +
+		domainSize*2*bn254.SizeOfG1AffineCompressed + bn254.SizeOfG2AffineCompressed
+	if t1 != 0 || offset > blake2b.BlockSize || t0+offset != responseSize {
+		return responseHash, fmt.Errorf("the partial hash doesn't cover the %d bytes of the response", responseSize)
+	}
+	// Marshaled state of x/crypto: magic | h | c | size | block | offset
+	marshaled := make([]byte, 0, 3+8*8+2*8+1+blake2b.BlockSize+1)
+	marshaled = append(marshaled, "b2b"...)
+	for i := 0; i < 8; i++ {
+		marshaled = binary.BigEndian.AppendUint64(marshaled, binary.LittleEndian.Uint64(state[128+8*i:]))
+	}
+	marshaled = binary.BigEndian.AppendUint64(marshaled, t0)
+	marshaled = binary.BigEndian.AppendUint64(marshaled, t1)
+	marshaled = append(marshaled, blake2b.Size)
+	marshaled = append(marshaled, block...)
+	marshaled = append(marshaled, byte(offset))
+	hasher, _ := blake2b.New512(nil)
+	if err := hasher.(interface{ UnmarshalBinary([]byte) error }).UnmarshalBinary(marshaled); err != nil {
+		return responseHash, err
+	}
+	for _, key := range []*PtauKey{&c.Tau, &c.Alpha, &c.Beta} {
+		hashG1(hasher, key.G1S, key.G1SX)
+	}
+	hashG2(hasher, c.Tau.G2SPX, c.Alpha.G2SPX, c.Beta.G2SPX)
+	copy(responseHash[:], hasher.Sum(nil))
+	return responseHash, nil
+}
+
+// Points are hashed uncompressed, X.A1 | X.A0 | Y.A1 | Y.A0 for G2
+func hashG1(hasher hash.Hash, points ...bn254.G1Affine) {
+	for _, p := range points {
+		b := p.RawBytes()
+		hasher.Write(b[:])
+	}
+}
+
+func hashG2(hasher hash.Hash, points ...bn254.G2Affine) {
+	for _, p := range points {
+		b := p.RawBytes()
+		hasher.Write(b[:])
+	}
+}
+
+func hashG1Repeat(hasher hash.Hash, p bn254.G1Affine, n int) {
+	b := p.RawBytes()
+	chunk := bytes.Repeat(b[:], PTAU_VERIFY_CHUNK_SIZE)
+	for ; n > PTAU_VERIFY_CHUNK_SIZE; n -= PTAU_VERIFY_CHUNK_SIZE {
+		hasher.Write(chunk)
+	}
+	hasher.Write(chunk[:n*len(b)])
+}
+
+func hashG2Repeat(hasher hash.Hash, p bn254.G2Affine, n int) {
+	b := p.RawBytes()
+	chunk := bytes.Repeat(b[:], PTAU_VERIFY_CHUNK_SIZE)
+	for ; n > PTAU_VERIFY_CHUNK_SIZE; n -= PTAU_VERIFY_CHUNK_SIZE {
+		hasher.Write(chunk)
+	}
+	hasher.Write(chunk[:n*len(b)])
+}
+
+// Challenge of the first contributor, the hash of the empty hash followed by
+// the powers of the ceremony with all the secrets set to 1.
+func ptauFirstChallenge(ceremonyPower uint32) [blake2b.Size]byte {
+	_, _, g1, g2 := bn254.Generators()
+	domainSize := 1 << ceremonyPower
+	emptyHash := blake2b.Sum512(nil)
+	hasher, _ := blake2b.New512(nil)
+	hasher.Write(emptyHash[:])
+	hashG1Repeat(hasher, g1, domainSize*2-1)
+	hashG2Repeat(hasher, g2, domainSize)
+	hashG1Repeat(hasher, g1, domainSize)
+	hashG1Repeat(hasher, g1, domainSize)
+	hashG2(hasher, g2)
+	var challenge [blake2b.Size]byte
+	copy(challenge[:], hasher.Sum(nil))
+	return challenge
+}
+
+// Challenge of the contributor following the last one
+func ptauNextChallenge(responseHash [blake2b.Size]byte, phase1 *mpc.Phase1) [blake2b.Size]byte {
+	hasher, _ := blake2b.New512(nil)
+	hasher.Write(responseHash[:])
+	hashG1(hasher, phase1.Parameters.G1.Tau...)
+	hashG2(hasher, phase1.Parameters.G2.Tau...)
+	hashG1(hasher, phase1.Parameters.G1.AlphaTau...)
+	hashG1(hasher, phase1.Parameters.G1.BetaTau...)
+	hashG2(hasher, phase1.Parameters.G2.Beta)
+	var challenge [blake2b.Size]byte
+	copy(challenge[:], hasher.Sum(nil))
+	return challenge
+}
+
+func g1FromRng(rng *chacha) bn254.G1Affine {
+	var three fp.Element
+	three.SetUint64(3)
+	for {
+		var p bn254.G1Affine
+		p.X = fpFromRng(rng)
+		greatest := rng.nextBool()
+		var y2 fp.Element
+		y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, &three)
+		if p.Y.Sqrt(&y2) == nil {
+			continue
+		}
+		if p.Y.LexicographicallyLargest() != greatest {
+			p.Y.Neg(&p.Y)
+		}
+		return p
+	}
+}
+
+func g2FromRng(rng *chacha) bn254.G2Affine {
+	for {
+		var p bn254.G2Affine
+		p.X.A0 = fpFromRng(rng)
+		p.X.A1 = fpFromRng(rng)
+		greatest := rng.nextBool()
+		var y2 bn254.E2
+		y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, &g2B)
+		if y2.Legendre() == -1 {
+			continue
+		}
+		p.Y.Sqrt(&y2)
+		if p.Y.LexicographicallyLargest() != greatest {
+			p.Y.Neg(&p.Y)
+		}
+		// Plain double and add, GLV only applies to the subgroup
+		var acc, q bn254.G2Jac
+		q.FromAffine(&p)
+		for i := g2Cofactor.BitLen() - 1; i >= 0; i-- {
+			acc.DoubleAssign()
+			if g2Cofactor.Bit(i) == 1 {
+				acc.AddAssign(&q)
+			}
+		}
+		p.FromJacobian(&acc)
+		return p
+	}
+}
+
+// [s·H(s', s's)]₂ is [s]₂ with a base bound to the challenge
+func ptauG2SP(personalization byte, challenge [blake2b.Size]byte, g1s, g1sx bn254.G1Affine) bn254.G2Affine {
+	hasher, _ := blake2b.New512(nil)
+	hasher.Write([]byte{personalization})
+	hasher.Write(challenge[:])
+	hashG1(hasher, g1s, g1sx)
+	var seed [32]byte
+	copy(seed[:], hasher.Sum(nil))
+	return g2FromRng(newChaCha(seed))
+}
+
+// Secrets of a beacon contribution, drawn from the beacon generator
+func ptauKeyFromBeacon(challenge [blake2b.Size]byte, beaconHash []byte, numIterationsExp uint8) (tau, alpha, beta PtauKey, err error) {
+	rng, err := rngFromBeacon(beaconHash, numIterationsExp)
+	if err != nil {
+		return tau, alpha, beta, err
+	}
+	secrets := [3]fr.Element{frFromRng(rng), frFromRng(rng), frFromRng(rng)}
+	keys := [3]*PtauKey{&tau, &alpha, &beta}
+	for i, key := range keys {
+		var s big.Int
+		secrets[i].BigInt(&s)
+		key.G1S = g1FromRng(rng)
+		key.G1SX.ScalarMultiplication(&key.G1S, &s)
+		g2sp := ptauG2SP(byte(i), challenge, key.G1S, key.G1SX)
+		key.G2SPX.ScalarMultiplication(&g2sp, &s)
+	}
+	return tau, alpha, beta, nil
+}
+
+// e(a1, b2) == e(b1, a2)
+func sameRatio(a1, b1 bn254.G1Affine, a2, b2 bn254.G2Affine) (bool, error) {
+	var b1Neg bn254.G1Affine
+	b1Neg.Neg(&b1)
+	return bn254.PairingCheck([]bn254.G1Affine{a1, b1Neg}, []bn254.G2Affine{b2, a2})
+}
+
+// Random linear combinations Σrᵢpᵢ and Σrᵢpᵢ₊₁ of the consecutive points
+func linearCombinationG1(points []bn254.G1Affine) (l, r bn254.G1Affine, err error) {
+	var lJac, rJac, tmp bn254.G1Jac
+	scalars := make([]fr.Element, PTAU_VERIFY_CHUNK_SIZE)
+	for start := 0; start < len(points)-1; start += PTAU_VERIFY_CHUNK_SIZE {
+		end := min(start+PTAU_VERIFY_CHUNK_SIZE, len(points)-1)
+		s := scalars[:end-start]
+		for i := range s {
+			if _, err = s[i].SetRandom(); err != nil {
+				return l, r, err
+			}
+		}
+		if _, err = tmp.MultiExp(points[start:end], s, ecc.MultiExpConfig{}); err != nil {
+			return l, r, err
+		}
+		lJac.AddAssign(&tmp)
+		if _, err = tmp.MultiExp(points[start+1:end+1], s, ecc.MultiExpConfig{}); err != nil {
+			return l, r, err
+		}
+		rJac.AddAssign(&tmp)
+	}
+	l.FromJacobian(&lJac)
+	r.FromJacobian(&rJac)
+	return l, r, nil
+}
+
+func linearCombinationG2(points []bn254.G2Affine) (l, r bn254.G2Affine, err error) {
+	var lJac, rJac, tmp bn254.G2Jac
+	scalars := make([]fr.Element, PTAU_VERIFY_CHUNK_SIZE)
+	for start := 0; start < len(points)-1; start += PTAU_VERIFY_CHUNK_SIZE {
+		end := min(start+PTAU_VERIFY_CHUNK_SIZE, len(points)-1)
+		s := scalars[:end-start]
+		for i := range s {
+			if _, err = s[i].SetRandom(); err != nil {
+				return l, r, err
+			}
+		}
+		if _, err = tmp.MultiExp(points[start:end], s, ecc.MultiExpConfig{}); err != nil {
+			return l, r, err
+		}
+		lJac.AddAssign(&tmp)
+		if _, err = tmp.MultiExp(points[start+1:end+1], s, ecc.MultiExpConfig{}); err != nil {
+			return l, r, err
+		}
+		rJac.AddAssign(&tmp)
+	}
+	l.FromJacobian(&lJac)
+	r.FromJacobian(&rJac)
+	return l, r, nil
+}
+
+// Check that every section is made of consecutive powers of the same τ,
+// i.e. e([τⁱ⁺¹]₁, [1]₂) == e([τⁱ]₁, [τ]₂), batched with random linear combinations.
+func verifyPtauPowers(phase1 *mpc.Phase1) error {
+	_, _, g1, g2 := bn254.Generators()
+	params := &phase1.Parameters
+	if len(params.G1.Tau) < 2 || len(params.G2.Tau) < 2 || len(params.G1.AlphaTau) == 0 || len(params.G1.BetaTau) == 0 {
+		return fmt.Errorf("the ptau is too small")
+	}
+	if !params.G1.Tau[0].Equal(&g1) {
+		return fmt.Errorf("the tauG1 section must start with the G1 generator")
+	}
+	if !params.G2.Tau[0].Equal(&g2) {
+		return fmt.Errorf("the tauG2 section must start with the G2 generator")
+	}
+	tauG1, tauG2 := params.G1.Tau[1], params.G2.Tau[1]
+	if tauG1.IsInfinity() || tauG2.IsInfinity() {
+		return fmt.Errorf("the ptau has a null tau")
+	}
+	for _, section := range []struct {
+		name   string
+		points []bn254.G1Affine
+	}{
+		{"tauG1", params.G1.Tau},
+		{"alphaTauG1", params.G1.AlphaTau},
+		{"betaTauG1", params.G1.BetaTau},
+	} {
+		l, r, err := linearCombinationG1(section.points)
+		if err != nil {
+			return err
+		}
+		ok, err := sameRatio(l, r, g2, tauG2)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("the %s section isn't made of the powers of tau", section.name)
+		}
+	}
+	l, r, err := linearCombinationG2(params.G2.Tau)
+	if err != nil {
+		return err
+	}
+	ok, err := sameRatio(g1, tauG1, l, r)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("the tauG2 section isn't made of the powers of tau")
+	}
+	ok, err = sameRatio(g1, params.G1.BetaTau[0], g2, params.G2.Beta)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("the betaG2 section doesn't match the betaTauG1 section")
+	}
+	return nil
+}
+
+// Check that the contribution proves the knowledge of the secrets it
+// multiplied the previous contribution with.
+func verifyPtauContribution(cur *PtauContribution, prev *PtauContribution) error {
+	if cur.Type == PTAU_CONTRIBUTION_BEACON {
+		tau, alpha, beta, err := ptauKeyFromBeacon(prev.NextChallenge, cur.BeaconHash, cur.NumIterationsExp)
+		if err != nil {
+			return err
+		}
+		if tau != cur.Tau || alpha != cur.Alpha || beta != cur.Beta {
+			return fmt.Errorf("the key doesn't match the beacon")
+		}
+	} else if cur.Type != PTAU_CONTRIBUTION_RANDOM {
+		return fmt.Errorf("unknown contribution type: %d", cur.Type)
+	}
+	for _, check := range []struct {
+		name            string
+		personalization byte
+		key             *PtauKey
+		prevG1, curG1   bn254.G1Affine
+	}{
+		{"tau", PTAU_PERSONALIZATION_TAU, &cur.Tau, prev.TauG1, cur.TauG1},
+		{"alpha", PTAU_PERSONALIZATION_ALPHA, &cur.Alpha, prev.AlphaG1, cur.AlphaG1},
+		{"beta", PTAU_PERSONALIZATION_BETA, &cur.Beta, prev.BetaG1, cur.BetaG1},
+	} {
+		if check.key.G1S.IsInfinity() || check.key.G1SX.IsInfinity() {
+			return fmt.Errorf("the %s key is null", check.name)
+		}
+		g2sp := ptauG2SP(check.personalization, prev.NextChallenge, check.key.G1S, check.key.G1SX)
+		ok, err := sameRatio(check.key.G1S, check.key.G1SX, g2sp, check.key.G2SPX)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("invalid %s key", check.name)
+		}
+		ok, err = sameRatio(check.prevG1, check.curG1, g2sp, check.key.G2SPX)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%sG1 doesn't follow the previous contribution", check.name)
+		}
+	}
+	for _, check := range []struct {
+		name          string
+		key           *PtauKey
+		prevG2, curG2 bn254.G2Affine
+	}{
+		{"tau", &cur.Tau, prev.TauG2, cur.TauG2},
+		{"beta", &cur.Beta, prev.BetaG2, cur.BetaG2},
+	} {
+		ok, err := sameRatio(check.key.G1S, check.key.G1SX, check.prevG2, check.curG2)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%sG2 doesn't follow the previous contribution", check.name)
+		}
+	}
+	return nil
+}
+
+// Verify the powers of the ptau and the chain of contributions, as `snarkjs
+// powersoftau verify` does. The contributions are printed along the way.
+func verifyPtau(header PtauHeader, contributions []PtauContribution, phase1 *mpc.Phase1) error {
+	if header.N8 != BN254_FIELD_ELEMENT_SIZE || header.Prime.Cmp(fp.Modulus()) != 0 {
+		return fmt.Errorf("the ptau isn't defined over bn254")
+	}
+	if header.Power > header.CeremonyPower {
+		return fmt.Errorf("the ptau power %d is larger than the ceremony power %d", header.Power, header.CeremonyPower)
+	}
+	if err := verifyPtauPowers(phase1); err != nil {
+		return err
+	}
+	if len(contributions) == 0 {
+		return fmt.Errorf("the ptau has no contributions")
+	}
+
+	params := &phase1.Parameters
+	last := &contributions[len(contributions)-1]
+	if !last.TauG1.Equal(&params.G1.Tau[1]) || !last.TauG2.Equal(&params.G2.Tau[1]) ||
+		!last.AlphaG1.Equal(&params.G1.AlphaTau[0]) || !last.BetaG1.Equal(&params.G1.BetaTau[0]) ||
+		!last.BetaG2.Equal(&params.G2.Beta) {
+		return fmt.Errorf("the powers don't match the last contribution")
+	}
+
+	_, _, g1, g2 := bn254.Generators()
+	prev := &PtauContribution{
+		TauG1:         g1,
+		TauG2:         g2,
+		AlphaG1:       g1,
+		BetaG1:        g1,
+		BetaG2:        g2,
+		NextChallenge: ptauFirstChallenge(header.CeremonyPower),
+	}
+	var responseHash [blake2b.Size]byte
+	for i := range contributions {
+		cur := &contributions[i]
+		if err := verifyPtauContribution(cur, prev); err != nil {
+			return fmt.Errorf("contribution #%d %q: %w", i+1, cur.Name, err)
+		}
+		var err error
+		responseHash, err = cur.ResponseHash(header.CeremonyPower)
+		if err != nil {
+			return fmt.Errorf("contribution #%d %q: %w", i+1, cur.Name, err)
+		}
+		fmt.Printf("contribution #%d %q\n  challenge: %X\n  response:  %X\n", i+1, cur.Name, prev.NextChallenge, responseHash)
+		if cur.Type == PTAU_CONTRIBUTION_BEACON {
+			fmt.Printf("  beacon:    %X (2^%d iterations)\n", cur.BeaconHash, cur.NumIterationsExp)
+		}
+		prev = cur
+	}
+
+	// The last challenge can only be recomputed on the full ceremony
+	if header.Power == header.CeremonyPower {
+		if ptauNextChallenge(responseHash, phase1) != last.NextChallenge {
+			return errors.New("the powers don't hash to the next challenge of the last contribution")
+		}
+	} else {
+		fmt.Printf("the ptau is truncated from 2^%d to 2^%d, the next challenge of the last contribution can't be checked\n", header.CeremonyPower, header.Power)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/binary"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func appendPtauContribution(b []byte, c *PtauContribution) []byte {
+	b = appendPtauG1(b, &c.TauG1)
+	b = appendPtauG2(b, &c.TauG2)
+	b = appendPtauG1(b, &c.AlphaG1)
+	b = appendPtauG1(b, &c.BetaG1)
+	b = appendPtauG2(b, &c.BetaG2)
+	for _, g1 := range []*bn254.G1Affine{&c.Tau.G1S, &c.Tau.G1SX, &c.Alpha.G1S, &c.Alpha.G1SX, &c.Beta.G1S, &c.Beta.G1SX} {
+		b = appendPtauG1(b, g1)
+	}
+	for _, g2 := range []*bn254.G2Affine{&c.Tau.G2SPX, &c.Alpha.G2SPX, &c.Beta.G2SPX} {
+		b = appendPtauG2(b, g2)
+	}
+	b = append(b, c.PartialHash[:]...)
+	b = append(b, c.NextChallenge[:]...)
+	b = binary.LittleEndian.AppendUint32(b, c.Type)
+	params := append([]byte{1, byte(len(c.Name))}, c.Name...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(params)))
+	return append(b, params...)
+}
+
+// The blake2b-wasm context after hashing all but the last bytes of a
+// response, whose content the verification can't check.
+func ptauPartialHash(t *testing.T, ceremonyPower uint32) [PTAU_PARTIAL_HASH_SIZE]byte {
+	domainSize := 1 << ceremonyPower
+	responseSize := blake2b.Size + (domainSize*2-1)*bn254.SizeOfG1AffineCompressed + domainSize*bn254.SizeOfG2AffineCompressed +
+		domainSize*2*bn254.SizeOfG1AffineCompressed + bn254.SizeOfG2AffineCompressed
+	hasher, err := blake2b.New512(nil)
+	require.NoError(t, err)
+	hasher.Write(make([]byte, responseSize))
+	// magic | h | c | size | block | offset, see ResponseHash
+	marshaled, err := hasher.(interface{ MarshalBinary() ([]byte, error) }).MarshalBinary()
+	require.NoError(t, err)
+	var state [PTAU_PARTIAL_HASH_SIZE]byte
+	copy(state[0:128], marshaled[3+8*8+2*8+1:])
+	for i := 0; i < 8+2; i++ {
+		binary.LittleEndian.PutUint64(state[128+8*i:], binary.BigEndian.Uint64(marshaled[3+8*i:]))
+	}
+	binary.LittleEndian.PutUint64(state[208:216], uint64(marshaled[len(marshaled)-1]))
+	return state
+}
+
+// The powers of random secrets, along with the contribution proving them
+// as the first of the ceremony.
+func contributePtau(t *testing.T, power uint32, name string) (mpc.Phase1, PtauContribution) {
+	N := 1 << power
+	var tau, alpha, beta fr.Element
+	for _, s := range []*fr.Element{&tau, &alpha, &beta} {
+		_, err := s.SetRandom()
+		require.NoError(t, err)
+	}
+	_, _, g1, g2 := bn254.Generators()
+	g1Mul := func(s *fr.Element) (p bn254.G1Affine) {
+		var b big.Int
+		return *p.ScalarMultiplication(&g1, s.BigInt(&b))
+	}
+	g2Mul := func(s *fr.Element) (p bn254.G2Affine) {
+		var b big.Int
+		return *p.ScalarMultiplication(&g2, s.BigInt(&b))
+	}
+
+	var phase1 mpc.Phase1
+	params := &phase1.Parameters
+	params.G1.Tau = make([]bn254.G1Affine, 2*N-1)
+	params.G1.AlphaTau = make([]bn254.G1Affine, N)
+	params.G1.BetaTau = make([]bn254.G1Affine, N)
+	params.G2.Tau = make([]bn254.G2Affine, N)
+	var tauI, alphaTauI, betaTauI fr.Element
+	tauI.SetOne()
+	for i := range params.G1.Tau {
+		params.G1.Tau[i] = g1Mul(&tauI)
+		if i < N {
+			alphaTauI.Mul(&alpha, &tauI)
+			betaTauI.Mul(&beta, &tauI)
+			params.G1.AlphaTau[i] = g1Mul(&alphaTauI)
+			params.G1.BetaTau[i] = g1Mul(&betaTauI)
+			params.G2.Tau[i] = g2Mul(&tauI)
+		}
+		tauI.Mul(&tauI, &tau)
+	}
+	params.G2.Beta = g2Mul(&beta)
+
+	c := PtauContribution{
+		TauG1:       params.G1.Tau[1],
+		TauG2:       params.G2.Tau[1],
+		AlphaG1:     params.G1.AlphaTau[0],
+		BetaG1:      params.G1.BetaTau[0],
+		BetaG2:      params.G2.Beta,
+		PartialHash: ptauPartialHash(t, power),
+		Type:        PTAU_CONTRIBUTION_RANDOM,
+		Name:        name,
+	}
+	challenge := ptauFirstChallenge(power)
+	for i, secret := range []struct {
+		s   *fr.Element
+		key *PtauKey
+	}{
+		{&tau, &c.Tau},
+		{&alpha, &c.Alpha},
+		{&beta, &c.Beta},
+	} {
+		var r fr.Element
+		_, err := r.SetRandom()
+		require.NoError(t, err)
+		var s big.Int
+		secret.s.BigInt(&s)
+		secret.key.G1S = g1Mul(&r)
+		secret.key.G1SX.ScalarMultiplication(&secret.key.G1S, &s)
+		g2sp := ptauG2SP(byte(i), challenge, secret.key.G1S, secret.key.G1SX)
+		secret.key.G2SPX.ScalarMultiplication(&g2sp, &s)
+	}
+	responseHash, err := c.ResponseHash(power)
+	require.NoError(t, err)
+	c.NextChallenge = ptauNextChallenge(responseHash, &phase1)
+	return phase1, c
+}
+
+func TestVerifyPtau(t *testing.T) {
+	const power = 3
+	phase1, contribution := contributePtau(t, power, "alice")
+	path := filepath.Join(t.TempDir(), "contributed.ptau")
+	writePtau(t, path, power, &phase1, appendPtauContribution(nil, &contribution))
+	ptauFile, err := InitPtau(path)
+	require.NoError(t, err)
+	defer ptauFile.Close()
+	read, err := ptauFile.ReadPhase1()
+	require.NoError(t, err)
+	contributions, err := ptauFile.ReadContributions()
+	require.NoError(t, err)
+	require.Equal(t, []PtauContribution{contribution}, contributions)
+	require.NoError(t, verifyPtau(ptauFile.Header, contributions, &read))
+
+	t.Run("tampered contribution", func(t *testing.T) {
+		// The beta key is bound to the personalization of beta
+		tampered := contribution
+		tampered.Alpha = contribution.Beta
+		err := verifyPtau(ptauFile.Header, []PtauContribution{tampered}, &read)
+		require.ErrorContains(t, err, `contribution #1 "alice": invalid alpha key`)
+
+		tampered = contribution
+		tampered.Tau.G2SPX = contribution.Beta.G2SPX
+		err = verifyPtau(ptauFile.Header, []PtauContribution{tampered}, &read)
+		require.ErrorContains(t, err, `contribution #1 "alice": invalid tau key`)
+
+		tampered = contribution
+		tampered.PartialHash[0] ^= 1
+		err = verifyPtau(ptauFile.Header, []PtauContribution{tampered}, &read)
+		require.ErrorContains(t, err, "the powers don't hash to the next challenge of the last contribution")
+	})
+
+	t.Run("powers of another contribution", func(t *testing.T) {
+		other, _ := contributePtau(t, power, "bob")
+		err := verifyPtau(ptauFile.Header, contributions, &other)
+		require.ErrorContains(t, err, "the powers don't match the last contribution")
+	})
+}
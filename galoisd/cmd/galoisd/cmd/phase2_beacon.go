@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/spf13/cobra"
+)
+
+// Domain separation tag of δ in the proof of knowledge
+const PHASE2_DST_DELTA = 1
+
+// Public beacon the final contribution is derived from
+type Phase2Beacon struct {
+	Hash             string `json:"hash"`
+	NumIterationsExp uint8  `json:"num_iterations_exp"`
+}
+
+// Same hash as gnark, over the parameters and the public key
+func phase2Hash(c *mpc.Phase2) []byte {
+	hash := c.Hash
+	c.Hash = nil
+	sha := sha256.New()
+	c.WriteTo(sha)
+	c.Hash = hash
+	return sha.Sum(nil)
+}
+
+// Proof of knowledge of δ bound to the challenge, as built by gnark
+func phase2PublicKey(delta, s fr.Element, challenge []byte) mpc.PublicKey {
+	_, _, g1, _ := bn254.Generators()
+	var pk mpc.PublicKey
+	var sBi, deltaBi big.Int
+	s.BigInt(&sBi)
+	delta.BigInt(&deltaBi)
+	pk.SG.ScalarMultiplication(&g1, &sBi)
+	pk.SXG.ScalarMultiplication(&pk.SG, &deltaBi)
+	var buf bytes.Buffer
+	buf.Write(pk.SG.Marshal())
+	buf.Write(pk.SXG.Marshal())
+	buf.Write(challenge)
+	R, err := bn254.HashToG2(buf.Bytes(), []byte{PHASE2_DST_DELTA})
+	if err != nil {
+		panic(err)
+	}
+	pk.XR.ScalarMultiplication(&R, &deltaBi)
+	return pk
+}
+
+// Contribute δ, which is up to the caller contrary to gnark's Contribute
+func contributePhase2(c *mpc.Phase2, delta, s fr.Element) {
+	var deltaInv fr.Element
+	var deltaBi, deltaInvBi big.Int
+	deltaInv.Inverse(&delta)
+	delta.BigInt(&deltaBi)
+	deltaInv.BigInt(&deltaInvBi)
+
+	c.PublicKey = phase2PublicKey(delta, s, c.Hash)
+
+	c.Parameters.G1.Delta.ScalarMultiplication(&c.Parameters.G1.Delta, &deltaBi)
+	c.Parameters.G2.Delta.ScalarMultiplication(&c.Parameters.G2.Delta, &deltaBi)
+	c.Parameters.G2.GRootSigmaNeg.ScalarMultiplication(&c.Parameters.G2.GRootSigmaNeg, &deltaBi)
+	scale := func(points []bn254.G1Affine) {
+		parallelize(len(points), func(start, end int) {
+			for i := start; i < end; i++ {
+				points[i].ScalarMultiplication(&points[i], &deltaInvBi)
+			}
+		})
+	}
+	for _, basis := range c.Parameters.G1.BasisExpSigma {
+		scale(basis)
+	}
+	scale(c.Parameters.G1.Z)
+	scale(c.Parameters.G1.L)
+
+	c.Hash = phase2Hash(c)
+}
+
+// δ and the key secret mixing the system randomness with the user entropy,
+// so that neither of them alone determines the contribution.
+func phase2SecretsFromEntropy(entropy []byte) (delta, s fr.Element, err error) {
+	var random [64]byte
+	if _, err = rand.Read(random[:]); err != nil {
+		return delta, s, err
+	}
+	for i, secret := range []*fr.Element{&delta, &s} {
+		h := sha512.New()
+		h.Write([]byte{byte(i)})
+		h.Write(random[:])
+		h.Write(entropy)
+		secret.SetBytes(h.Sum(nil))
+	}
+	if delta.IsZero() || s.IsZero() {
+		return delta, s, fmt.Errorf("null secret")
+	}
+	return delta, s, nil
+}
+
+// δ and the key secret drawn from the beacon generator, anyone can
+// recompute them
+func phase2SecretsFromBeacon(beacon Phase2Beacon) (delta, s fr.Element, err error) {
+	beaconHash, err := hex.DecodeString(beacon.Hash)
+	if err != nil {
+		return delta, s, fmt.Errorf("invalid beacon hash: %w", err)
+	}
+	rng, err := rngFromBeacon(beaconHash, beacon.NumIterationsExp)
+	if err != nil {
+		return delta, s, err
+	}
+	delta = frFromRng(rng)
+	s = frFromRng(rng)
+	if delta.IsZero() || s.IsZero() {
+		return delta, s, fmt.Errorf("null secret")
+	}
+	return delta, s, nil
+}
+
+// The contribution must be the one of the beacon, on top of the checks of
+// mpc.VerifyPhase2 which bind the parameters to the public key.
+func verifyPhase2Beacon(prev, contrib *mpc.Phase2, beacon Phase2Beacon) error {
+	delta, s, err := phase2SecretsFromBeacon(beacon)
+	if err != nil {
+		return err
+	}
+	if phase2PublicKey(delta, s, prev.Hash) != contrib.PublicKey {
+		return fmt.Errorf("the contribution isn't derived from the beacon %s", beacon.Hash)
+	}
+	return nil
+}
+
+func Phase2BeaconCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Apply the final phase 2 contribution, derived from a public random beacon.",
+		Use:   "mpc-phase2-beacon [phase2] [phase2Output] [beaconHash] [numIterationsExp]",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transcriptPath, err := cmd.Flags().GetString(flagTranscript)
+			if err != nil {
+				return err
+			}
+			name, err := cmd.Flags().GetString(flagContributor)
+			if err != nil {
+				return err
+			}
+			numIterationsExp, err := strconv.ParseUint(args[3], 10, 8)
+			if err != nil {
+				return fmt.Errorf("invalid number of iterations: %w", err)
+			}
+			beacon := Phase2Beacon{Hash: args[2], NumIterationsExp: uint8(numIterationsExp)}
+			delta, s, err := phase2SecretsFromBeacon(beacon)
+			if err != nil {
+				return err
+			}
+			phase2Path := args[0]
+			var srs2 mpc.Phase2
+			err = readFrom(phase2Path, &srs2)
+			if err != nil {
+				return err
+			}
+			var transcript Phase2Transcript
+			if transcriptPath != "" {
+				transcript, err = openPhase2Transcript(transcriptPath, srs2.Hash)
+				if err != nil {
+					return err
+				}
+			}
+			contributePhase2(&srs2, delta, s)
+			phase2Output := args[1]
+			err = saveTo(phase2Output, &srs2)
+			if err != nil {
+				return err
+			}
+			if transcriptPath != "" {
+				transcript.Append(srs2.Hash, Phase2Contributor{Name: name})
+				transcript.Contributions[len(transcript.Contributions)-1].Beacon = &beacon
+				return writePhase2Transcript(transcriptPath, &transcript)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(flagTranscript, "", "Path to the transcript to record the contribution in, created if it doesn't exist.")
+	cmd.Flags().String(flagContributor, "beacon", "Name recorded in the transcript.")
+	return cmd
+}
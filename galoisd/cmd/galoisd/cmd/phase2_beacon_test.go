@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContributePhase2(t *testing.T) {
+	setup := newTestPhase2(t)
+	init := encodePhase2(t, &setup.init)
+
+	t.Run("entropy", func(t *testing.T) {
+		contrib := decodePhase2(t, init)
+		require.NoError(t, contributePhase2WithEntropy(contrib, []byte("some entropy")))
+		require.NoError(t, verifyPhase2Contribution(&setup.init, contrib, nil))
+		require.Equal(t, phase2Hash(contrib), contrib.Hash)
+
+		// The system randomness is mixed in, the same entropy gives another contribution
+		other := decodePhase2(t, init)
+		require.NoError(t, contributePhase2WithEntropy(other, []byte("some entropy")))
+		require.NoError(t, verifyPhase2Contribution(&setup.init, other, nil))
+		require.NotEqual(t, contrib.Hash, other.Hash)
+	})
+
+	beacon := Phase2Beacon{Hash: "0b5e1c0e7ab1e5ee", NumIterationsExp: 4}
+
+	t.Run("beacon", func(t *testing.T) {
+		delta, s, err := phase2SecretsFromBeacon(beacon)
+		require.NoError(t, err)
+		contrib := decodePhase2(t, init)
+		contributePhase2(contrib, delta, s)
+		require.NoError(t, verifyPhase2Contribution(&setup.init, contrib, &Phase2TranscriptEntry{Beacon: &beacon}))
+
+		// Anyone can recompute it
+		again := decodePhase2(t, init)
+		contributePhase2(again, delta, s)
+		require.Equal(t, encodePhase2(t, contrib), encodePhase2(t, again))
+
+		for _, wrong := range []Phase2Beacon{
+			{Hash: "0b5e1c0e7ab1e5ef", NumIterationsExp: beacon.NumIterationsExp},
+			{Hash: beacon.Hash, NumIterationsExp: beacon.NumIterationsExp + 1},
+		} {
+			err := verifyPhase2Contribution(&setup.init, contrib, &Phase2TranscriptEntry{Beacon: &wrong})
+			require.ErrorContains(t, err, "the contribution isn't derived from the beacon "+wrong.Hash)
+		}
+		err = verifyPhase2Contribution(&setup.init, contrib, &Phase2TranscriptEntry{Beacon: &Phase2Beacon{Hash: "not hex", NumIterationsExp: 4}})
+		require.ErrorContains(t, err, "invalid beacon hash")
+	})
+}
@@ -1,26 +1,95 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+
 	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
 	"github.com/spf13/cobra"
 )
 
+const (
+	flagEntropyFile = "entropy-file"
+)
+
+// Contribute with gnark, or with the user entropy mixed in if there is any
+func contributePhase2WithEntropy(srs2 *mpc.Phase2, entropy []byte) error {
+	if entropy == nil {
+		srs2.Contribute()
+		return nil
+	}
+	delta, s, err := phase2SecretsFromEntropy(entropy)
+	if err != nil {
+		return err
+	}
+	contributePhase2(srs2, delta, s)
+	return nil
+}
+
 func Phase2ContributeCmd() *cobra.Command {
 	var cmd = &cobra.Command{
 		Short: "Initialize the phase 2 of the groth16 multi-party computation.",
 		Use:   "mpc-phase2-contrib [phase2] [phase2Output]",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			transcriptPath, err := cmd.Flags().GetString(flagTranscript)
+			if err != nil {
+				return err
+			}
+			name, err := cmd.Flags().GetString(flagContributor)
+			if err != nil {
+				return err
+			}
+			contact, err := cmd.Flags().GetString(flagContact)
+			if err != nil {
+				return err
+			}
+			if transcriptPath != "" && name == "" {
+				return fmt.Errorf("the contributor name is required to record the contribution in the transcript")
+			}
 			phase2Path := args[0]
 			var srs2 mpc.Phase2
-			err := readFrom(phase2Path, &srs2)
+			err = readFrom(phase2Path, &srs2)
+			if err != nil {
+				return err
+			}
+			entropyPath, err := cmd.Flags().GetString(flagEntropyFile)
+			if err != nil {
+				return err
+			}
+			var entropy []byte
+			if entropyPath != "" {
+				entropy, err = os.ReadFile(entropyPath)
+				if err != nil {
+					return fmt.Errorf("failed to read entropy: %w", err)
+				}
+			}
+			var transcript Phase2Transcript
+			if transcriptPath != "" {
+				transcript, err = openPhase2Transcript(transcriptPath, srs2.Hash)
+				if err != nil {
+					return err
+				}
+			}
+			err = contributePhase2WithEntropy(&srs2, entropy)
 			if err != nil {
 				return err
 			}
-			srs2.Contribute()
 			phase2Output := args[1]
-			return saveTo(phase2Output, &srs2)
+			err = saveTo(phase2Output, &srs2)
+			if err != nil {
+				return err
+			}
+			if transcriptPath != "" {
+				transcript.Append(srs2.Hash, Phase2Contributor{Name: name, Contact: contact})
+				return writePhase2Transcript(transcriptPath, &transcript)
+			}
+			return nil
 		},
 	}
+	cmd.Flags().String(flagTranscript, "", "Path to the transcript to record the contribution in, created if it doesn't exist.")
+	cmd.Flags().String(flagContributor, "", "Name of the contributor, recorded in the transcript.")
+	cmd.Flags().String(flagContact, "", "Contact of the contributor, recorded in the transcript.")
+	cmd.Flags().String(flagEntropyFile, "", "Path to a file of user entropy, mixed with the system randomness to sample the contribution.")
 	return cmd
 }
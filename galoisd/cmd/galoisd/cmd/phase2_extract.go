@@ -1,22 +1,61 @@
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	provergrpc "galois/grpc"
+	"io"
+	"os"
 
 	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
 	bn254 "github.com/consensys/gnark/constraint/bn254"
 	"github.com/spf13/cobra"
 )
 
+const (
+	flagManifest = "manifest"
+)
+
+// Provenance of the extracted keys
+type KeyManifest struct {
+	ConstraintSystemHash  string `json:"constraint_system_hash"`
+	Phase2Hash            string `json:"phase2_hash"`
+	Contributions         int    `json:"contributions,omitempty"`
+	TranscriptSummaryHash string `json:"transcript_summary_hash,omitempty"`
+	ProvingKeyHash        string `json:"proving_key_hash"`
+	VerifyingKeyHash      string `json:"verifying_key_hash"`
+}
+
+func hashOf(x io.WriterTo) (string, error) {
+	h := sha256.New()
+	if _, err := x.WriteTo(h); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func Phase2ExtractCmd() *cobra.Command {
 	var cmd = &cobra.Command{
 		Short: "Initialize the phase 2 of the groth16 multi-party computation.",
 		Use:   "mpc-phase2-extract [r1cs] [phase1Final] [phase2Final] [phase2Evals] [provingKeyOutput] [verifyingKeyOutput]",
 		Args:  cobra.ExactArgs(6),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			transcriptPath, err := cmd.Flags().GetString(flagTranscript)
+			if err != nil {
+				return err
+			}
+			manifestPath, err := cmd.Flags().GetString(flagManifest)
+			if err != nil {
+				return err
+			}
+			if transcriptPath != "" && manifestPath == "" {
+				return fmt.Errorf("the transcript is only used to embed its summary hash in the manifest")
+			}
 			r1csPath := args[0]
 			var r1cs bn254.R1CS
-			err := readFrom(r1csPath, &r1cs)
+			err = readFrom(r1csPath, &r1cs)
 			if err != nil {
 				return fmt.Errorf("failed to read r1cs: %v", err)
 			}
@@ -45,8 +84,45 @@ func Phase2ExtractCmd() *cobra.Command {
 				return fmt.Errorf("failed to write pk: %v", err)
 			}
 			vkOutput := args[5]
-			return saveTo(vkOutput, &vk)
+			err = saveTo(vkOutput, &vk)
+			if err != nil {
+				return fmt.Errorf("failed to write vk: %v", err)
+			}
+			if manifestPath == "" {
+				return nil
+			}
+			manifest := KeyManifest{
+				ConstraintSystemHash: hex.EncodeToString(provergrpc.ConstraintSystemHash(&r1cs)),
+				Phase2Hash:           hex.EncodeToString(srs2.Hash),
+			}
+			if transcriptPath != "" {
+				transcript, err := readPhase2Transcript(transcriptPath)
+				if err != nil {
+					return fmt.Errorf("failed to read transcript: %w", err)
+				}
+				n := len(transcript.Contributions)
+				if n == 0 || transcript.Contributions[n-1].Hash != manifest.Phase2Hash {
+					return fmt.Errorf("the transcript doesn't end with the phase2 %s", manifest.Phase2Hash)
+				}
+				manifest.Contributions = n
+				manifest.TranscriptSummaryHash = hex.EncodeToString(transcript.SummaryHash())
+			}
+			manifest.ProvingKeyHash, err = hashOf(&pk)
+			if err != nil {
+				return err
+			}
+			manifest.VerifyingKeyHash, err = hashOf(&vk)
+			if err != nil {
+				return err
+			}
+			manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(manifestPath, append(manifestJSON, '\n'), 0644)
 		},
 	}
+	cmd.Flags().String(flagManifest, "", "Path to write the manifest of the keys to, with their hashes and the summary hash of the transcript.")
+	cmd.Flags().String(flagTranscript, "", "Path to the transcript of the contributions, as checked by mpc-phase2-verify-chain.")
 	return cmd
 }
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagTranscript  = "transcript"
+	flagContributor = "contributor"
+	flagContact     = "contact"
+)
+
+type Phase2Contributor struct {
+	Name    string `json:"name"`
+	Contact string `json:"contact,omitempty"`
+}
+
+// A contribution of the phase 2, linked to its parent through the hash of
+// the parent's parameters (the challenge the contributor signed).
+type Phase2TranscriptEntry struct {
+	Index       int               `json:"index"`
+	Hash        string            `json:"hash"`
+	ParentHash  string            `json:"parent_hash"`
+	Contributor Phase2Contributor `json:"contributor"`
+	Time        time.Time         `json:"time"`
+	Beacon      *Phase2Beacon     `json:"beacon,omitempty"`
+}
+
+type Phase2Transcript struct {
+	InitHash      string                  `json:"init_hash"`
+	Contributions []Phase2TranscriptEntry `json:"contributions"`
+}
+
+func readPhase2Transcript(path string) (Phase2Transcript, error) {
+	var transcript Phase2Transcript
+	transcriptJSON, err := os.ReadFile(path)
+	if err != nil {
+		return transcript, err
+	}
+	err = json.Unmarshal(transcriptJSON, &transcript)
+	return transcript, err
+}
+
+func writePhase2Transcript(path string, transcript *Phase2Transcript) error {
+	transcriptJSON, err := json.MarshalIndent(transcript, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(transcriptJSON, '\n'), 0644)
+}
+
+// Read the transcript at path, or start a new one from the parent if there
+// is none yet, which must end with the phase2 being contributed to.
+func openPhase2Transcript(path string, parentHash []byte) (Phase2Transcript, error) {
+	transcript, err := readPhase2Transcript(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Phase2Transcript{InitHash: hex.EncodeToString(parentHash)}, nil
+	} else if err != nil {
+		return transcript, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	if tip := transcript.Tip(); tip != hex.EncodeToString(parentHash) {
+		return transcript, fmt.Errorf("the transcript ends with %s, not with the contributed phase2 %x", tip, parentHash)
+	}
+	return transcript, nil
+}
+
+// Entry of the contribution with the given hash
+func (transcript *Phase2Transcript) Find(hash []byte) *Phase2TranscriptEntry {
+	for i := range transcript.Contributions {
+		if transcript.Contributions[i].Hash == hex.EncodeToString(hash) {
+			return &transcript.Contributions[i]
+		}
+	}
+	return nil
+}
+
+// Hash of the last phase2 of the transcript
+func (transcript *Phase2Transcript) Tip() string {
+	if n := len(transcript.Contributions); n > 0 {
+		return transcript.Contributions[n-1].Hash
+	}
+	return transcript.InitHash
+}
+
+func (transcript *Phase2Transcript) Append(hash []byte, contributor Phase2Contributor) {
+	transcript.Contributions = append(transcript.Contributions, Phase2TranscriptEntry{
+		Index:       len(transcript.Contributions) + 1,
+		Hash:        hex.EncodeToString(hash),
+		ParentHash:  transcript.Tip(),
+		Contributor: contributor,
+		Time:        time.Now().UTC(),
+	})
+}
+
+// Summary of the whole transcript, length prefixed to be unambiguous.
+func (transcript *Phase2Transcript) SummaryHash() []byte {
+	h := sha256.New()
+	writeString := func(s string) {
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(s))))
+		h.Write([]byte(s))
+	}
+	writeString(transcript.InitHash)
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(transcript.Contributions))))
+	for _, entry := range transcript.Contributions {
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(entry.Index)))
+		writeString(entry.Hash)
+		writeString(entry.ParentHash)
+		writeString(entry.Contributor.Name)
+		writeString(entry.Contributor.Contact)
+		h.Write(binary.BigEndian.AppendUint64(nil, uint64(entry.Time.UnixNano())))
+		if entry.Beacon != nil {
+			h.Write([]byte{1})
+			writeString(entry.Beacon.Hash)
+			h.Write([]byte{entry.Beacon.NumIterationsExp})
+		} else {
+			h.Write([]byte{0})
+		}
+	}
+	return h.Sum(nil)
+}
+
+func checkPhase2Shape(prev, contrib *mpc.Phase2) error {
+	if len(prev.Parameters.G1.L) != len(contrib.Parameters.G1.L) ||
+		len(prev.Parameters.G1.Z) != len(contrib.Parameters.G1.Z) ||
+		len(prev.Parameters.G1.BasisExpSigma) != len(contrib.Parameters.G1.BasisExpSigma) {
+		return fmt.Errorf("the parameters don't have the size of the previous ones")
+	}
+	for i := range prev.Parameters.G1.BasisExpSigma {
+		if len(prev.Parameters.G1.BasisExpSigma[i]) != len(contrib.Parameters.G1.BasisExpSigma[i]) {
+			return fmt.Errorf("the commitment %d basis doesn't have the size of the previous one", i)
+		}
+	}
+	return nil
+}
+
+// Verify the contribution against its parent, as a beacon contribution if
+// the transcript entry says so.
+func verifyPhase2Contribution(prev, contrib *mpc.Phase2, entry *Phase2TranscriptEntry) error {
+	if err := checkPhase2Shape(prev, contrib); err != nil {
+		return err
+	}
+	if err := mpc.VerifyPhase2(prev, contrib); err != nil {
+		return err
+	}
+	if entry != nil && entry.Beacon != nil {
+		return verifyPhase2Beacon(prev, contrib, *entry.Beacon)
+	}
+	return nil
+}
+
+// Verify every contribution against its parent, from the output of
+// mpc-phase2-init to the last contribution, and match them with the transcript.
+func verifyPhase2Chain(phase2Paths []string, transcript *Phase2Transcript) error {
+	var prev mpc.Phase2
+	err := readFrom(phase2Paths[0], &prev)
+	if err != nil {
+		return fmt.Errorf("failed to read phase2 init: %v", err)
+	}
+	if transcript.InitHash != hex.EncodeToString(prev.Hash) {
+		return fmt.Errorf("the transcript starts from %s, not from the phase2 init %x", transcript.InitHash, prev.Hash)
+	}
+	if len(transcript.Contributions) != len(phase2Paths)-1 {
+		return fmt.Errorf("the transcript has %d contributions, got %d files", len(transcript.Contributions), len(phase2Paths)-1)
+	}
+	for i, phase2Path := range phase2Paths[1:] {
+		var contrib mpc.Phase2
+		err := readFrom(phase2Path, &contrib)
+		if err != nil {
+			return fmt.Errorf("failed to read contribution #%d: %v", i+1, err)
+		}
+		entry := &transcript.Contributions[i]
+		if entry.Index != i+1 || entry.ParentHash != hex.EncodeToString(prev.Hash) || entry.Hash != hex.EncodeToString(contrib.Hash) {
+			return fmt.Errorf("contribution #%d doesn't match the transcript", i+1)
+		}
+		if err := verifyPhase2Contribution(&prev, &contrib, entry); err != nil {
+			return fmt.Errorf("contribution #%d: %w", i+1, err)
+		}
+		fmt.Printf("contribution #%d %q %s\n  parent: %s\n  hash:   %s\n", entry.Index, entry.Contributor.Name, entry.Time.Format(time.RFC3339), entry.ParentHash, entry.Hash)
+		if entry.Beacon != nil {
+			fmt.Printf("  beacon: %s (2^%d iterations)\n", entry.Beacon.Hash, entry.Beacon.NumIterationsExp)
+		}
+		prev = contrib
+	}
+	return nil
+}
+
+func Phase2VerifyChainCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Verify the whole chain of phase 2 contributions of the groth16 multi-party computation.",
+		Use:   "mpc-phase2-verify-chain [phase2Init] [phase2Contrib...]",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transcriptPath, err := cmd.Flags().GetString(flagTranscript)
+			if err != nil {
+				return err
+			}
+			transcript, err := readPhase2Transcript(transcriptPath)
+			if err != nil {
+				return fmt.Errorf("failed to read transcript: %w", err)
+			}
+			if err := verifyPhase2Chain(args, &transcript); err != nil {
+				return err
+			}
+			fmt.Printf("the %d contributions are valid, transcript summary hash: %x\n", len(transcript.Contributions), transcript.SummaryHash())
+			return nil
+		},
+	}
+	cmd.Flags().String(flagTranscript, "transcript.json", "Path to the transcript of the contributions.")
+	return cmd
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPhase2Chain(t *testing.T) {
+	setup := newTestPhase2(t)
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "phase2_init.bin")}
+	require.NoError(t, saveTo(paths[0], &setup.init))
+	transcript := Phase2Transcript{InitHash: hex.EncodeToString(setup.init.Hash)}
+	prev := encodePhase2(t, &setup.init)
+	for _, name := range []string{"alice", "bob"} {
+		contrib := decodePhase2(t, prev)
+		contrib.Contribute()
+		path := filepath.Join(dir, name+".bin")
+		require.NoError(t, saveTo(path, contrib))
+		paths = append(paths, path)
+		transcript.Append(contrib.Hash, Phase2Contributor{Name: name})
+		prev = encodePhase2(t, contrib)
+	}
+	require.NoError(t, verifyPhase2Chain(paths, &transcript))
+
+	// Copy of the transcript whose entries can be modified
+	tamper := func(modify func(*Phase2Transcript)) *Phase2Transcript {
+		tampered := transcript
+		tampered.Contributions = slices.Clone(transcript.Contributions)
+		modify(&tampered)
+		return &tampered
+	}
+
+	t.Run("transcript mismatch", func(t *testing.T) {
+		err := verifyPhase2Chain(paths, tamper(func(tampered *Phase2Transcript) {
+			tampered.InitHash = transcript.Tip()
+		}))
+		require.ErrorContains(t, err, "the transcript starts from "+transcript.Tip())
+
+		err = verifyPhase2Chain(paths[:2], &transcript)
+		require.ErrorContains(t, err, "the transcript has 2 contributions, got 1 files")
+
+		err = verifyPhase2Chain([]string{paths[0], paths[2], paths[1]}, &transcript)
+		require.ErrorContains(t, err, "contribution #1 doesn't match the transcript")
+
+		err = verifyPhase2Chain(paths, tamper(func(tampered *Phase2Transcript) {
+			tampered.Contributions[1].ParentHash = transcript.InitHash
+		}))
+		require.ErrorContains(t, err, "contribution #2 doesn't match the transcript")
+
+		err = verifyPhase2Chain(paths, tamper(func(tampered *Phase2Transcript) {
+			tampered.Contributions[0].Index = 2
+		}))
+		require.ErrorContains(t, err, "contribution #1 doesn't match the transcript")
+	})
+
+	t.Run("claimed beacon", func(t *testing.T) {
+		beacon := Phase2Beacon{Hash: "0b5e1c0e", NumIterationsExp: 4}
+		err := verifyPhase2Chain(paths, tamper(func(tampered *Phase2Transcript) {
+			tampered.Contributions[1].Beacon = &beacon
+		}))
+		require.ErrorContains(t, err, "contribution #2: the contribution isn't derived from the beacon 0b5e1c0e")
+	})
+
+	t.Run("skipped contribution", func(t *testing.T) {
+		// bob's contribution recorded on top of the init
+		err := verifyPhase2Chain([]string{paths[0], paths[2]}, tamper(func(tampered *Phase2Transcript) {
+			tampered.Contributions = tampered.Contributions[1:]
+			tampered.Contributions[0].Index = 1
+			tampered.Contributions[0].ParentHash = transcript.InitHash
+		}))
+		require.ErrorContains(t, err, "contribution #1: couldn't verify knowledge of δ")
+	})
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
 	"github.com/spf13/cobra"
 )
@@ -11,9 +13,13 @@ func Phase2VerifyCmd() *cobra.Command {
 		Use:   "mpc-phase2-verify [phase2Previous] [phase2Contrib]",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			transcriptPath, err := cmd.Flags().GetString(flagTranscript)
+			if err != nil {
+				return err
+			}
 			phase2Previous := args[0]
 			var prev mpc.Phase2
-			err := readFrom(phase2Previous, &prev)
+			err = readFrom(phase2Previous, &prev)
 			if err != nil {
 				return err
 			}
@@ -23,8 +29,27 @@ func Phase2VerifyCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return mpc.VerifyPhase2(&prev, &contrib)
+			var entry *Phase2TranscriptEntry
+			if transcriptPath != "" {
+				transcript, err := readPhase2Transcript(transcriptPath)
+				if err != nil {
+					return fmt.Errorf("failed to read transcript: %w", err)
+				}
+				entry = transcript.Find(contrib.Hash)
+				if entry == nil {
+					return fmt.Errorf("the contribution %x isn't in the transcript", contrib.Hash)
+				}
+			}
+			err = verifyPhase2Contribution(&prev, &contrib, entry)
+			if err != nil {
+				return err
+			}
+			if entry != nil && entry.Beacon != nil {
+				fmt.Printf("the contribution is derived from the beacon %s\n", entry.Beacon.Hash)
+			}
+			return nil
 		},
 	}
+	cmd.Flags().String(flagTranscript, "", "Path to the transcript of the contributions, to recognize and check beacon contributions.")
 	return cmd
 }
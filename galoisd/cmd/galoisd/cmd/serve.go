@@ -24,6 +24,13 @@ const (
 	flagVK       = "vk-path"
 	flagMaxConn  = "max-conn"
 	flagLogLevel = "log-level"
+	flagBackend  = "backend"
+	flagSRS      = "srs-path"
+
+	flagMisbehaviourR1CS    = "misbehaviour-cs-path"
+	flagMisbehaviourPK      = "misbehaviour-pk-path"
+	flagMisbehaviourVK      = "misbehaviour-vk-path"
+	flagMisbehaviourBackend = "misbehaviour-backend"
 )
 
 func ServeCmd() *cobra.Command {
@@ -44,6 +51,30 @@ func ServeCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			backend, err := cmd.Flags().GetString(flagBackend)
+			if err != nil {
+				return err
+			}
+			srsPath, err := cmd.Flags().GetString(flagSRS)
+			if err != nil {
+				return err
+			}
+			misbehaviourR1CSPath, err := cmd.Flags().GetString(flagMisbehaviourR1CS)
+			if err != nil {
+				return err
+			}
+			misbehaviourPKPath, err := cmd.Flags().GetString(flagMisbehaviourPK)
+			if err != nil {
+				return err
+			}
+			misbehaviourVKPath, err := cmd.Flags().GetString(flagMisbehaviourVK)
+			if err != nil {
+				return err
+			}
+			misbehaviourBackend, err := cmd.Flags().GetString(flagMisbehaviourBackend)
+			if err != nil {
+				return err
+			}
 			maxConn, err := cmd.Flags().GetInt(flagMaxConn)
 			if err != nil {
 				return err
@@ -71,18 +102,45 @@ func ServeCmd() *cobra.Command {
 				Time:                  5 * time.Second,
 				Timeout:               20 * time.Second,
 			}))
-			server, err := provergrpc.NewProverServer(uint32(maxConn), r1csPath, pkPath, vkPath)
+			server, err := provergrpc.NewProverServer(uint32(maxConn), provergrpc.BundleConfig{
+				Backend: provergrpc.Backend(backend),
+				CsPath:  r1csPath,
+				PkPath:  pkPath,
+				VkPath:  vkPath,
+				SrsPath: srsPath,
+			})
 			if err != nil {
 				return err
 			}
+			if misbehaviourR1CSPath != "" || misbehaviourPKPath != "" || misbehaviourVKPath != "" {
+				if misbehaviourR1CSPath == "" || misbehaviourPKPath == "" || misbehaviourVKPath == "" {
+					return fmt.Errorf("the misbehaviour circuit requires --%s, --%s and --%s", flagMisbehaviourR1CS, flagMisbehaviourPK, flagMisbehaviourVK)
+				}
+				err = server.WithMisbehaviour(provergrpc.BundleConfig{
+					Backend: provergrpc.Backend(misbehaviourBackend),
+					CsPath:  misbehaviourR1CSPath,
+					PkPath:  misbehaviourPKPath,
+					VkPath:  misbehaviourVKPath,
+					SrsPath: srsPath,
+				})
+				if err != nil {
+					return err
+				}
+			}
 			provergrpcapi.RegisterUnionProverAPIServer(grpcServer, server)
 			log.Info().Msg("Serving...")
 			return grpcServer.Serve(limitedLis)
 		},
 	}
-	cmd.Flags().String(flagR1CS, "r1cs.bin", "Path to the compiled R1CS circuit.")
+	cmd.Flags().String(flagR1CS, "r1cs.bin", "Path to the compiled circuit (R1CS for groth16, SCS for plonk).")
 	cmd.Flags().String(flagPK, "pk.bin", "Path to the proving key.")
 	cmd.Flags().String(flagVK, "vk.bin", "Path to the verifying key.")
+	cmd.Flags().String(flagBackend, string(provergrpc.BackendGroth16), "Proving backend of the circuit, either groth16 or plonk.")
+	cmd.Flags().String(flagSRS, "", "Path to the universal KZG SRS, required to setup a plonk circuit.")
+	cmd.Flags().String(flagMisbehaviourR1CS, "", "Path to the compiled misbehaviour circuit, enables ProveMisbehaviour.")
+	cmd.Flags().String(flagMisbehaviourPK, "", "Path to the misbehaviour proving key.")
+	cmd.Flags().String(flagMisbehaviourVK, "", "Path to the misbehaviour verifying key.")
+	cmd.Flags().String(flagMisbehaviourBackend, string(provergrpc.BackendGroth16), "Proving backend of the misbehaviour circuit, either groth16 or plonk.")
 	cmd.Flags().Int(flagMaxConn, 1, "Maximum number of concurrent connection.")
 	cmd.Flags().Int(flagLogLevel, int(zerolog.InfoLevel), "Log level see https://github.com/rs/zerolog/blob/c78e50e2da70f4ae63e1b65222c3acf12e9ba699/README.md#leveled-logging")
 	return cmd
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	provergrpc "galois/grpc"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/types"
+	"github.com/consensys/gnark/logger"
+	"github.com/spf13/cobra"
+	"github.com/unionlabs/union/11-cometbls/inputshash"
+)
+
+const (
+	flagVKFile      = "vk"
+	flagHeader      = "header"
+	flagTrustedRoot = "trusted-root"
+	flagProof       = "proof"
+)
+
+// Read a header as printed by example-prove, or as served by the CometBLS RPC
+// which encodes the 64-bit integers as strings.
+func readLightHeader(path string) (*types.Header, error) {
+	headerJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var header types.Header
+	err = json.Unmarshal(headerJSON, &header)
+	if err != nil {
+		if rpcErr := cmtjson.Unmarshal(headerJSON, &header); rpcErr != nil {
+			return nil, fmt.Errorf("Could not parse the header: %w", err)
+		}
+	}
+	return &header, nil
+}
+
+func decodeHex(flag string, s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("Could not decode --%s: %w", flag, err)
+	}
+	return b, nil
+}
+
+func VerifyOfflineCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Short: "Verify a light header proof locally, without a running prover, and show the public inputs on failure.",
+		Use:   "verify-offline",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vkPath, err := cmd.Flags().GetString(flagVKFile)
+			if err != nil {
+				return err
+			}
+			headerPath, err := cmd.Flags().GetString(flagHeader)
+			if err != nil {
+				return err
+			}
+			trustedRootHex, err := cmd.Flags().GetString(flagTrustedRoot)
+			if err != nil {
+				return err
+			}
+			proofHex, err := cmd.Flags().GetString(flagProof)
+			if err != nil {
+				return err
+			}
+			backend, err := cmd.Flags().GetString(flagBackend)
+			if err != nil {
+				return err
+			}
+			header, err := readLightHeader(headerPath)
+			if err != nil {
+				return err
+			}
+			trustedRoot, err := decodeHex(flagTrustedRoot, trustedRootHex)
+			if err != nil {
+				return err
+			}
+			proof, err := decodeHex(flagProof, proofHex)
+			if err != nil {
+				return err
+			}
+
+			err = inputshash.Validate(inputshash.LightHeader{
+				ChainId:            header.ChainID,
+				Height:             header.Height,
+				Time:               header.Time,
+				ValidatorsHash:     header.ValidatorsHash,
+				NextValidatorsHash: header.NextValidatorsHash,
+				AppHash:            header.AppHash,
+			}, trustedRoot)
+			if err != nil {
+				return err
+			}
+
+			logger.Disable()
+
+			protoHeader := header.ToProto()
+			result, err := provergrpc.VerifyOffline(provergrpc.Backend(backend), vkPath, proof, header.ChainID, protoHeader, trustedRoot)
+			if err != nil {
+				return err
+			}
+			if result.Err == nil {
+				fmt.Printf("the proof is valid, inputs hash: %X\n", result.InputsHash)
+				return nil
+			}
+
+			// Each word of the inputs hash preimage, to spot an encoding mismatch with the client
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "field\tvalue\tencoded")
+			for _, field := range result.Fields {
+				fmt.Fprintf(w, "%s\t%s\t%X\n", field.Name, field.Value, field.Encoded)
+			}
+			fmt.Fprintf(w, "inputs_hash\t\t%X\n", result.InputsHash)
+			if result.CommitmentsHash != nil {
+				fmt.Fprintf(w, "commitments_hash\t\t%X\n", result.CommitmentsHash)
+			}
+			w.Flush()
+			return fmt.Errorf("the proof is invalid: %w", result.Err)
+		},
+	}
+	cmd.Flags().String(flagVKFile, "vk.bin", "Path to the verifying key.")
+	cmd.Flags().String(flagHeader, "header.json", "Path to the JSON encoded header the proof is for.")
+	cmd.Flags().String(flagTrustedRoot, "", "Hex encoded trusted validators hash, i.e. the next validators hash of the trusted header.")
+	cmd.Flags().String(flagProof, "", "Hex encoded proof, serialized by gnark or, for groth16, in the EVM layout.")
+	cmd.Flags().String(flagBackend, string(provergrpc.BackendGroth16), "Proving backend of the circuit, either groth16 or plonk.")
+	cmd.MarkFlagRequired(flagTrustedRoot)
+	cmd.MarkFlagRequired(flagProof)
+	return cmd
+}
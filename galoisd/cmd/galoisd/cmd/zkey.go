@@ -0,0 +1,798 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"math/bits"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark/constraint"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"golang.org/x/crypto/blake2b"
+)
+
+///////////////////////////////////////////////////////////////////
+///                             ZKEY                            ///
+///////////////////////////////////////////////////////////////////
+
+// Taken from the iden3/snarkjs repo, zkey_utils.js
+// (https://github.com/iden3/snarkjs/blob/fb144555d8ce4779ad79e707f269771c672a8fb7/src/zkey_utils.js#L20-L45)
+// Format
+// ======
+// 4 bytes, zket
+// 4 bytes, version
+// 4 bytes, number of sections
+// 4 bytes, section number
+// 8 bytes, section size
+// Header(1)
+// 4 bytes, Prover Type 1 Groth
+// HeaderGroth(2)
+// 4 bytes, n8q
+// n8q bytes, q
+// 4 bytes, n8r
+// n8r bytes, r
+// 4 bytes, NVars
+// 4 bytes, NPub
+// 4 bytes, DomainSize  (multiple of 2)
+//      alpha1
+//      beta1
+//      beta2
+//      gamma2
+//      delta1
+//      delta2
+// IC(3)
+//      {NPub+1} G1, (beta*A_i(tau)+alpha*B_i(tau)+C_i(tau))/gamma of the public signals, ONE included
+// Coefs(4)
+// 4 bytes, number of coefficients
+//      {number of coefficients} [
+//          4 bytes, matrix (0 for A, 1 for B)
+//          4 bytes, constraint
+//          4 bytes, signal
+//          n8r bytes, value*R^2 where R = 2^256 is the montgomery constant
+//      ]
+// PointsA(5)
+//      {NVars} G1, A_i(tau)
+// PointsB1(6)
+//      {NVars} G1, B_i(tau)
+// PointsB2(7)
+//      {NVars} G2, B_i(tau)
+// PointsC(8)
+//      {NVars-NPub-1} G1, (beta*A_i(tau)+alpha*B_i(tau)+C_i(tau))/delta of the private signals
+// PointsH(9)
+//      {DomainSize} G1, L_{2i+1}(tau)/delta, L being the lagrange basis of the 2*DomainSize domain
+// Contributions(10)
+// 64 bytes, circuit hash
+// 4 bytes, number of contributions
+//      {number of contributions} [...]
+//
+// The points are affine, their coordinates are little endian in montgomery
+// form and the point at infinity is zeroed.
+
+const GROTH_16_PROTOCOL_ID = uint32(1)
+
+const (
+	ZKEY_VERSION = uint32(1)
+
+	ZKEY_SECTION_HEADER        = uint32(1)
+	ZKEY_SECTION_GROTH_HEADER  = uint32(2)
+	ZKEY_SECTION_IC            = uint32(3)
+	ZKEY_SECTION_COEFS         = uint32(4)
+	ZKEY_SECTION_A             = uint32(5)
+	ZKEY_SECTION_B1            = uint32(6)
+	ZKEY_SECTION_B2            = uint32(7)
+	ZKEY_SECTION_C             = uint32(8)
+	ZKEY_SECTION_H             = uint32(9)
+	ZKEY_SECTION_CONTRIBUTIONS = uint32(10)
+)
+
+// in bytes
+const (
+	ZKEY_G1_SIZE   = 2 * BN254_FIELD_ELEMENT_SIZE
+	ZKEY_G2_SIZE   = 4 * BN254_FIELD_ELEMENT_SIZE
+	ZKEY_COEF_SIZE = 12 + BN254_FIELD_ELEMENT_SIZE
+)
+
+type NotGroth16 struct {
+	Err error
+}
+
+func (r *NotGroth16) Error() string {
+	return fmt.Sprintf("Groth16 is the only supported protocol at this time (PLONK and FFLONK are not): %v", r.Err)
+}
+
+// A coefficient of the A or B matrix of the constraint system
+type ZkeyCoef struct {
+	Matrix     uint32
+	Constraint uint32
+	Signal     uint32
+	Value      fr.Element
+}
+
+// The contributions (section 10) are skipped, they can be verified with snarkjs
+type Zkey struct {
+	ZkeyHeader     ZkeyHeader
+	protocolHeader HeaderGroth
+	IC             []bn254.G1Affine
+	Coefs          []ZkeyCoef
+	A              []bn254.G1Affine
+	B1             []bn254.G1Affine
+	B2             []bn254.G2Affine
+	C              []bn254.G1Affine
+	H              []bn254.G1Affine
+}
+
+type ZkeyHeader struct {
+	ProtocolID     uint32
+	protocolHeader HeaderGroth
+}
+
+type HeaderGroth struct {
+	n8q        uint32
+	q          big.Int
+	n8r        uint32
+	r          big.Int
+	nVars      uint32
+	nPublic    uint32
+	domainSize uint32
+	power      uint32
+	alpha1     bn254.G1Affine
+	beta1      bn254.G1Affine
+	beta2      bn254.G2Affine
+	gamma2     bn254.G2Affine
+	delta1     bn254.G1Affine
+	delta2     bn254.G2Affine
+}
+
+func ReadZkey(zkeyPath string) (Zkey, error) {
+	reader, err := os.Open(zkeyPath)
+
+	if err != nil {
+		return Zkey{}, err
+	}
+
+	defer reader.Close()
+
+	// zkey
+	var zkeyStr = make([]byte, 4)
+	_, err = io.ReadFull(reader, zkeyStr)
+	if err != nil {
+		return Zkey{}, err
+	}
+	if string(zkeyStr) != "zkey" {
+		return Zkey{}, fmt.Errorf("not a zkey file, got the magic %q", zkeyStr)
+	}
+
+	// version
+	_, err = readULE32(reader)
+	if err != nil {
+		return Zkey{}, err
+	}
+
+	// number of sections
+	numSections, err := readULE32(reader)
+	if err != nil {
+		return Zkey{}, err
+	}
+
+	// in practice, all sections have only one segment, but who knows...
+	// 1-based indexing, unknown sections are ignored
+	sections := make([][]SectionSegment, ZKEY_SECTION_CONTRIBUTIONS+1)
+	for i := uint32(0); i < numSections; i++ {
+		ht, err := readULE32(reader)
+		if err != nil {
+			return Zkey{}, err
+		}
+		hl, err := readULE64(reader)
+		if err != nil {
+			return Zkey{}, err
+		}
+		pos, _ := reader.Seek(0, io.SeekCurrent)
+		if ht < uint32(len(sections)) {
+			sections[ht] = append(sections[ht], SectionSegment{pos: uint64(pos), size: hl})
+		}
+		reader.Seek(int64(hl), io.SeekCurrent)
+	}
+
+	header, err := readHeader(reader, sections)
+	if err != nil {
+		return Zkey{}, err
+	}
+
+	zkey := Zkey{ZkeyHeader: header, protocolHeader: header.protocolHeader}
+	h := &zkey.protocolHeader
+	if h.nPublic >= h.nVars {
+		return Zkey{}, fmt.Errorf("the zkey has %d public signals out of %d", h.nPublic, h.nVars)
+	}
+
+	if zkey.IC, err = readZkeyG1Section(reader, sections, ZKEY_SECTION_IC, int(h.nPublic)+1); err != nil {
+		return Zkey{}, err
+	}
+	if zkey.Coefs, err = readZkeyCoefs(reader, sections); err != nil {
+		return Zkey{}, err
+	}
+	if zkey.A, err = readZkeyG1Section(reader, sections, ZKEY_SECTION_A, int(h.nVars)); err != nil {
+		return Zkey{}, err
+	}
+	if zkey.B1, err = readZkeyG1Section(reader, sections, ZKEY_SECTION_B1, int(h.nVars)); err != nil {
+		return Zkey{}, err
+	}
+	if zkey.B2, err = readZkeyG2Section(reader, sections, ZKEY_SECTION_B2, int(h.nVars)); err != nil {
+		return Zkey{}, err
+	}
+	if zkey.C, err = readZkeyG1Section(reader, sections, ZKEY_SECTION_C, int(h.nVars-h.nPublic-1)); err != nil {
+		return Zkey{}, err
+	}
+	if zkey.H, err = readZkeyG1Section(reader, sections, ZKEY_SECTION_H, int(h.domainSize)); err != nil {
+		return Zkey{}, err
+	}
+
+	return zkey, nil
+}
+
+// Unlike seekToUniqueSection, a missing section is an error as a zkey may
+// come from anywhere.
+func openZkeySection(reader io.ReadSeeker, sections [][]SectionSegment, sectionId uint32) (*bufio.Reader, uint64, error) {
+	if len(sections[sectionId]) != 1 {
+		return nil, 0, fmt.Errorf("expected a single segment for the zkey section %d, got: %d", sectionId, len(sections[sectionId]))
+	}
+	seekToUniqueSection(reader, sections, sectionId)
+	size := sections[sectionId][0].size
+	return bufio.NewReader(io.LimitReader(reader, int64(size))), size, nil
+}
+
+func readHeader(reader io.ReadSeeker, sections [][]SectionSegment) (ZkeyHeader, error) {
+	var header = ZkeyHeader{}
+
+	section, _, err := openZkeySection(reader, sections, ZKEY_SECTION_HEADER)
+	if err != nil {
+		return header, err
+	}
+
+	protocolID, err := readULE32(section)
+
+	if err != nil {
+		return header, err
+	}
+
+	// if groth16
+	if protocolID == GROTH_16_PROTOCOL_ID {
+		section, _, err := openZkeySection(reader, sections, ZKEY_SECTION_GROTH_HEADER)
+		if err != nil {
+			return header, err
+		}
+		headerGroth, err := readHeaderGroth16(section)
+
+		if err != nil {
+			return header, err
+		}
+
+		header = ZkeyHeader{ProtocolID: protocolID, protocolHeader: headerGroth}
+
+	} else {
+		return header, &NotGroth16{Err: errors.New("ProtocolID is not Groth16")}
+	}
+
+	return header, nil
+}
+
+func readHeaderGroth16(reader io.Reader) (HeaderGroth, error) {
+	var header = HeaderGroth{}
+
+	n8q, err := readULE32(reader)
+	if err != nil {
+		return header, err
+	}
+
+	q, err := readBigInt(reader, n8q)
+	if err != nil {
+		return header, err
+	}
+
+	n8r, err := readULE32(reader)
+	if err != nil {
+		return header, err
+	}
+
+	r, err := readBigInt(reader, n8r)
+	if err != nil {
+		return header, err
+	}
+
+	if q.Cmp(fp.Modulus()) != 0 || r.Cmp(fr.Modulus()) != 0 {
+		return header, fmt.Errorf("BN254 is the only supported curve, got the base field %s and the scalar field %s", q.String(), r.String())
+	}
+
+	nVars, err := readULE32(reader)
+	if err != nil {
+		return header, err
+	}
+
+	nPublic, err := readULE32(reader)
+	if err != nil {
+		return header, err
+	}
+
+	domainSize, err := readULE32(reader)
+	if err != nil {
+		return header, err
+	}
+
+	power := math.Log2(float64(domainSize))
+
+	power_int := uint32(math.Ceil(power))
+
+	header = HeaderGroth{n8q: n8q, q: q, n8r: n8r, r: r, nVars: nVars, nPublic: nPublic, domainSize: domainSize, power: power_int}
+
+	g1s := []*bn254.G1Affine{&header.alpha1, &header.beta1}
+	for _, g1 := range g1s {
+		if *g1, err = readZkeyG1(reader); err != nil {
+			return header, err
+		}
+	}
+	g2s := []*bn254.G2Affine{&header.beta2, &header.gamma2}
+	for _, g2 := range g2s {
+		if *g2, err = readZkeyG2(reader); err != nil {
+			return header, err
+		}
+	}
+	if header.delta1, err = readZkeyG1(reader); err != nil {
+		return header, err
+	}
+	if header.delta2, err = readZkeyG2(reader); err != nil {
+		return header, err
+	}
+
+	return header, nil
+}
+
+func readZkeyFp(reader io.Reader) (fp.Element, error) {
+	var buffer [BN254_FIELD_ELEMENT_SIZE]byte
+	_, err := io.ReadFull(reader, buffer[:])
+	if err != nil {
+		return fp.Element{}, err
+	}
+
+	// already in montgomery form, the limbs are copied as is
+	var z fp.Element
+	for i := range z {
+		z[i] = binary.LittleEndian.Uint64(buffer[i*8 : (i+1)*8])
+	}
+	if new(big.Int).SetBytes(reverseSlice(buffer[:])).Cmp(fp.Modulus()) >= 0 {
+		return fp.Element{}, fmt.Errorf("coordinate is not reduced")
+	}
+	return z, nil
+}
+
+func readZkeyG1(reader io.Reader) (bn254.G1Affine, error) {
+	var g1 bn254.G1Affine
+	var err error
+	if g1.X, err = readZkeyFp(reader); err != nil {
+		return g1, err
+	}
+	if g1.Y, err = readZkeyFp(reader); err != nil {
+		return g1, err
+	}
+	if !g1.IsOnCurve() {
+		return g1, fmt.Errorf("g1Affine is not on curve")
+	}
+	return g1, nil
+}
+
+func readZkeyG2(reader io.Reader) (bn254.G2Affine, error) {
+	var g2 bn254.G2Affine
+	var err error
+	coordinates := []*fp.Element{&g2.X.A0, &g2.X.A1, &g2.Y.A0, &g2.Y.A1}
+	for _, coordinate := range coordinates {
+		if *coordinate, err = readZkeyFp(reader); err != nil {
+			return g2, err
+		}
+	}
+	if !g2.IsOnCurve() || !g2.IsInSubGroup() {
+		return g2, fmt.Errorf("g2Affine is not on curve or not in the subgroup")
+	}
+	return g2, nil
+}
+
+func readZkeyG1Section(reader io.ReadSeeker, sections [][]SectionSegment, sectionId uint32, count int) ([]bn254.G1Affine, error) {
+	section, size, err := openZkeySection(reader, sections, sectionId)
+	if err != nil {
+		return nil, err
+	}
+	if size != uint64(count)*ZKEY_G1_SIZE {
+		return nil, fmt.Errorf("expected %d points in the zkey section %d, got %d bytes", count, sectionId, size)
+	}
+	g1s := make([]bn254.G1Affine, count)
+	for i := range g1s {
+		if g1s[i], err = readZkeyG1(section); err != nil {
+			return nil, fmt.Errorf("invalid point %d of the zkey section %d: %w", i, sectionId, err)
+		}
+	}
+	return g1s, nil
+}
+
+func readZkeyG2Section(reader io.ReadSeeker, sections [][]SectionSegment, sectionId uint32, count int) ([]bn254.G2Affine, error) {
+	section, size, err := openZkeySection(reader, sections, sectionId)
+	if err != nil {
+		return nil, err
+	}
+	if size != uint64(count)*ZKEY_G2_SIZE {
+		return nil, fmt.Errorf("expected %d points in the zkey section %d, got %d bytes", count, sectionId, size)
+	}
+	g2s := make([]bn254.G2Affine, count)
+	for i := range g2s {
+		if g2s[i], err = readZkeyG2(section); err != nil {
+			return nil, fmt.Errorf("invalid point %d of the zkey section %d: %w", i, sectionId, err)
+		}
+	}
+	return g2s, nil
+}
+
+// The prover multiplies the coefficients with the witness in montgomery form,
+// hence the extra R factor on top of the montgomery one.
+func zkeyCoefFactors() (r2 fr.Element, r2Inv fr.Element) {
+	r2.SetBigInt(new(big.Int).Lsh(big.NewInt(1), 512))
+	r2Inv.Inverse(&r2)
+	return r2, r2Inv
+}
+
+func readZkeyCoefs(reader io.ReadSeeker, sections [][]SectionSegment) ([]ZkeyCoef, error) {
+	section, size, err := openZkeySection(reader, sections, ZKEY_SECTION_COEFS)
+	if err != nil {
+		return nil, err
+	}
+	nCoefs, err := readULE32(section)
+	if err != nil {
+		return nil, err
+	}
+	if size != 4+uint64(nCoefs)*ZKEY_COEF_SIZE {
+		return nil, fmt.Errorf("expected %d coefficients in the zkey, got %d bytes", nCoefs, size)
+	}
+	_, r2Inv := zkeyCoefFactors()
+	coefs := make([]ZkeyCoef, nCoefs)
+	for i := range coefs {
+		coef := &coefs[i]
+		for _, x := range []*uint32{&coef.Matrix, &coef.Constraint, &coef.Signal} {
+			if *x, err = readULE32(section); err != nil {
+				return nil, err
+			}
+		}
+		value, err := readBigInt(section, BN254_FIELD_ELEMENT_SIZE)
+		if err != nil {
+			return nil, err
+		}
+		if value.Cmp(fr.Modulus()) >= 0 {
+			return nil, fmt.Errorf("coefficient %d is not reduced", i)
+		}
+		coef.Value.SetBigInt(&value)
+		coef.Value.Mul(&coef.Value, &r2Inv)
+	}
+	return coefs, nil
+}
+
+type zkeyWriter struct {
+	writer io.Writer
+	n      int64
+	err    error
+}
+
+func (w *zkeyWriter) write(b []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.writer.Write(b)
+	w.n += int64(n)
+	w.err = err
+}
+
+func (w *zkeyWriter) writeULE32(x uint32) {
+	w.write(binary.LittleEndian.AppendUint32(nil, x))
+}
+
+func (w *zkeyWriter) writeULE64(x uint64) {
+	w.write(binary.LittleEndian.AppendUint64(nil, x))
+}
+
+func (w *zkeyWriter) writeBigInt(x *big.Int) {
+	var buffer [BN254_FIELD_ELEMENT_SIZE]byte
+	x.FillBytes(buffer[:])
+	w.write(reverseSlice(buffer[:]))
+}
+
+func (w *zkeyWriter) writeFp(z *fp.Element) {
+	var buffer [BN254_FIELD_ELEMENT_SIZE]byte
+	for i := range z {
+		binary.LittleEndian.PutUint64(buffer[i*8:(i+1)*8], z[i])
+	}
+	w.write(buffer[:])
+}
+
+func (w *zkeyWriter) writeG1(g1 *bn254.G1Affine) {
+	w.writeFp(&g1.X)
+	w.writeFp(&g1.Y)
+}
+
+func (w *zkeyWriter) writeG2(g2 *bn254.G2Affine) {
+	w.writeFp(&g2.X.A0)
+	w.writeFp(&g2.X.A1)
+	w.writeFp(&g2.Y.A0)
+	w.writeFp(&g2.Y.A1)
+}
+
+func (w *zkeyWriter) startSection(sectionId uint32, size uint64) {
+	w.writeULE32(sectionId)
+	w.writeULE64(size)
+}
+
+func (w *zkeyWriter) writeG1Section(sectionId uint32, g1s []bn254.G1Affine) {
+	w.startSection(sectionId, uint64(len(g1s))*ZKEY_G1_SIZE)
+	for i := range g1s {
+		w.writeG1(&g1s[i])
+	}
+}
+
+func (w *zkeyWriter) writeCoef(coef *ZkeyCoef, r2 *fr.Element) {
+	w.writeULE32(coef.Matrix)
+	w.writeULE32(coef.Constraint)
+	w.writeULE32(coef.Signal)
+	var value fr.Element
+	value.Mul(&coef.Value, r2)
+	var valueBig big.Int
+	value.BigInt(&valueBig)
+	w.writeBigInt(&valueBig)
+}
+
+// Hash of the circuit the contributions are chained from, computed over the
+// groth16 header dimensions and the coefficients. snarkjs derives it from its
+// own circuit representation, `snarkjs zkey verify` will thus not accept it.
+func (zkey *Zkey) circuitHash() [blake2b.Size]byte {
+	hasher, _ := blake2b.New512(nil)
+	w := zkeyWriter{writer: hasher}
+	w.writeULE32(zkey.protocolHeader.nVars)
+	w.writeULE32(zkey.protocolHeader.nPublic)
+	w.writeULE32(zkey.protocolHeader.domainSize)
+	r2, _ := zkeyCoefFactors()
+	for i := range zkey.Coefs {
+		w.writeCoef(&zkey.Coefs[i], &r2)
+	}
+	var hash [blake2b.Size]byte
+	copy(hash[:], hasher.Sum(nil))
+	return hash
+}
+
+// Write the zkey without any contribution
+func (zkey *Zkey) WriteTo(writer io.Writer) (int64, error) {
+	w := zkeyWriter{writer: writer}
+	h := &zkey.protocolHeader
+
+	w.write([]byte("zkey"))
+	w.writeULE32(ZKEY_VERSION)
+	// number of sections, the contributions being the last one
+	w.writeULE32(ZKEY_SECTION_CONTRIBUTIONS)
+
+	w.startSection(ZKEY_SECTION_HEADER, 4)
+	w.writeULE32(GROTH_16_PROTOCOL_ID)
+
+	w.startSection(ZKEY_SECTION_GROTH_HEADER, 4+BN254_FIELD_ELEMENT_SIZE+4+BN254_FIELD_ELEMENT_SIZE+3*4+3*ZKEY_G1_SIZE+3*ZKEY_G2_SIZE)
+	w.writeULE32(BN254_FIELD_ELEMENT_SIZE)
+	w.writeBigInt(fp.Modulus())
+	w.writeULE32(BN254_FIELD_ELEMENT_SIZE)
+	w.writeBigInt(fr.Modulus())
+	w.writeULE32(h.nVars)
+	w.writeULE32(h.nPublic)
+	w.writeULE32(h.domainSize)
+	w.writeG1(&h.alpha1)
+	w.writeG1(&h.beta1)
+	w.writeG2(&h.beta2)
+	w.writeG2(&h.gamma2)
+	w.writeG1(&h.delta1)
+	w.writeG2(&h.delta2)
+
+	w.writeG1Section(ZKEY_SECTION_IC, zkey.IC)
+
+	w.startSection(ZKEY_SECTION_COEFS, 4+uint64(len(zkey.Coefs))*ZKEY_COEF_SIZE)
+	w.writeULE32(uint32(len(zkey.Coefs)))
+	r2, _ := zkeyCoefFactors()
+	for i := range zkey.Coefs {
+		w.writeCoef(&zkey.Coefs[i], &r2)
+	}
+
+	w.writeG1Section(ZKEY_SECTION_A, zkey.A)
+	w.writeG1Section(ZKEY_SECTION_B1, zkey.B1)
+	w.startSection(ZKEY_SECTION_B2, uint64(len(zkey.B2))*ZKEY_G2_SIZE)
+	for i := range zkey.B2 {
+		w.writeG2(&zkey.B2[i])
+	}
+	w.writeG1Section(ZKEY_SECTION_C, zkey.C)
+	w.writeG1Section(ZKEY_SECTION_H, zkey.H)
+
+	w.startSection(ZKEY_SECTION_CONTRIBUTIONS, blake2b.Size+4)
+	circuitHash := zkey.circuitHash()
+	w.write(circuitHash[:])
+	w.writeULE32(0)
+
+	return w.n, w.err
+}
+
+// snarkjs zkeys have no equivalent to the gnark Pedersen commitments (BSB22)
+func checkZkeyCommitments(r1cs *cs.R1CS) error {
+	commitments := r1cs.CommitmentInfo.(constraint.Groth16Commitments)
+	if len(commitments) != 0 {
+		return fmt.Errorf("the circuit has %d Pedersen commitment(s) which snarkjs zkeys don't support", len(commitments))
+	}
+	return nil
+}
+
+// The A and B matrices as snarkjs stores them. Unlike circom's, gnark circuits
+// don't constrain the public signals with an extra `signal * 0 = 0`
+// constraint each, hence the zkeys only carry the circuit constraints.
+func r1csZkeyCoefs(r1cs *cs.R1CS) []ZkeyCoef {
+	var coefs []ZkeyCoef
+	appendTerms := func(matrix uint32, constraintId uint32, terms constraint.LinearExpression) {
+		for _, t := range terms {
+			if t.CoeffID() == constraint.CoeffIdZero {
+				continue
+			}
+			coefs = append(coefs, ZkeyCoef{
+				Matrix:     matrix,
+				Constraint: constraintId,
+				Signal:     uint32(t.WireID()),
+				Value:      r1cs.Coefficients[t.CoeffID()],
+			})
+		}
+	}
+	j := uint32(0)
+	it := r1cs.GetR1CIterator()
+	for c := it.Next(); c != nil; c = it.Next() {
+		appendTerms(0, j, c.L)
+		appendTerms(1, j, c.R)
+		j++
+	}
+	return coefs
+}
+
+// Split a loop over n items into chunks processed concurrently
+func parallelize(n int, work func(start, end int)) {
+	nbChunks := runtime.NumCPU()
+	if nbChunks > n {
+		nbChunks = n
+	}
+	var wg sync.WaitGroup
+	for chunk := 0; chunk < nbChunks; chunk++ {
+		start, end := chunk*n/nbChunks, (chunk+1)*n/nbChunks
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			work(start, end)
+		}()
+	}
+	wg.Wait()
+}
+
+// Natural order DFT of G1 points, aᵢ ← Σⱼ ωⁱʲ·aⱼ with ω the generator of the
+// domain of size len(a) (resp. its inverse).
+func dftG1(a []bn254.G1Jac, inverse bool) {
+	n := len(a)
+	domain := fft.NewDomain(uint64(n))
+	omega := domain.Generator
+	if inverse {
+		omega = domain.GeneratorInv
+	}
+
+	// ωʲ for j < n/2, the twiddles of the smaller stages are strided
+	twiddles := make([]big.Int, n/2)
+	var w fr.Element
+	w.SetOne()
+	for j := range twiddles {
+		w.BigInt(&twiddles[j])
+		w.Mul(&w, &omega)
+	}
+
+	shift := uint(bits.UintSize - bits.TrailingZeros(uint(n)))
+	for i := 0; i < n; i++ {
+		if j := int(bits.Reverse(uint(i)) >> shift); j > i {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+
+	for m := 2; m <= n; m <<= 1 {
+		half, stride := m/2, n/m
+		parallelize(n/2, func(start, end int) {
+			var t bn254.G1Jac
+			for butterfly := start; butterfly < end; butterfly++ {
+				j := butterfly % half
+				k := (butterfly/half)*m + j
+				t.Set(&a[k+half])
+				if j != 0 {
+					t.ScalarMultiplication(&t, &twiddles[j*stride])
+				}
+				a[k+half].Set(&a[k])
+				a[k+half].SubAssign(&t)
+				a[k].AddAssign(&t)
+			}
+		})
+	}
+}
+
+// snarkjs evaluates h·Z = A·B-C at the odd roots of unity of the 2n domain,
+// xᵢ = ω₂ₙ·ωₙⁱ, and commits to it with H[i] = L₂ᵢ₊₁(τ)/δ. gnark commits to
+// the coefficients of h with Z[j] = τʲ·Z(τ)/δ in bit reversed order. τʲ·Z(τ)
+// vanishes at the even roots and equals -2·xᵢʲ at the odd ones, hence
+// Z[j] = -2·ω₂ₙʲ·Σᵢ ωₙⁱʲ·H[i].
+func zkeyHToZ(h []bn254.G1Affine) []bn254.G1Affine {
+	n := len(h)
+	points := make([]bn254.G1Jac, n)
+	for i := range h {
+		points[i].FromAffine(&h[i])
+	}
+	dftG1(points, false)
+
+	omega2n := fft.NewDomain(uint64(2 * n)).Generator
+	var factor fr.Element
+	factor.SetUint64(2).Neg(&factor)
+	scalars := make([]big.Int, n)
+	for j := range scalars {
+		factor.BigInt(&scalars[j])
+		factor.Mul(&factor, &omega2n)
+	}
+	parallelize(n, func(start, end int) {
+		for j := start; j < end; j++ {
+			points[j].ScalarMultiplication(&points[j], &scalars[j])
+		}
+	})
+
+	z := bn254.BatchJacobianToAffineG1(points)
+	bitReverseG1(z)
+	// h has degree n-2, the last point τⁿ⁻¹·Z(τ)/δ is never used
+	return z[:n-1]
+}
+
+// The inverse of zkeyHToZ, H[i] = -1/2n·Σⱼ ωₙ⁻ⁱʲ·ω₂ₙ⁻ʲ·Z[j]. gnark doesn't
+// keep τⁿ⁻¹·Z(τ)/δ, which is replaced by the point at infinity: both
+// commitments still agree on the polynomials of degree n-2 the prover commits
+// to, but the H points differ from the ones snarkjs would have computed.
+func zkeyZToH(z []bn254.G1Affine, n int) []bn254.G1Affine {
+	natural := make([]bn254.G1Affine, n)
+	copy(natural, z)
+	bitReverseG1(natural)
+
+	omega2nInv := fft.NewDomain(uint64(2 * n)).GeneratorInv
+	var factor fr.Element
+	factor.SetUint64(uint64(2 * n)).Inverse(&factor).Neg(&factor)
+	scalars := make([]big.Int, n)
+	for j := range scalars {
+		factor.BigInt(&scalars[j])
+		factor.Mul(&factor, &omega2nInv)
+	}
+	points := make([]bn254.G1Jac, n)
+	parallelize(n, func(start, end int) {
+		for j := start; j < end; j++ {
+			points[j].FromAffine(&natural[j])
+			points[j].ScalarMultiplication(&points[j], &scalars[j])
+		}
+	})
+	dftG1(points, true)
+	return bn254.BatchJacobianToAffineG1(points)
+}
+
+func bitReverseG1(a []bn254.G1Affine) {
+	n := uint(len(a))
+	nn := uint(bits.UintSize - bits.TrailingZeros(n))
+
+	for i := uint(0); i < n; i++ {
+		irev := bits.Reverse(i) >> nn
+		if irev > i {
+			a[i], a[irev] = a[irev], a[i]
+		}
+	}
+}
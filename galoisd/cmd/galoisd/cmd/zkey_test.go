@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+// Enough constraints and public inputs for the zkey sections to be non
+// trivial, without Pedersen commitments which zkeys don't support.
+type cubeCircuit struct {
+	X frontend.Variable `gnark:",public"`
+	Z frontend.Variable `gnark:",public"`
+	Y frontend.Variable
+}
+
+func (c *cubeCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.X, api.Add(api.Mul(c.Y, c.Y, c.Y), c.Y, 5))
+	api.AssertIsEqual(c.Z, api.Mul(c.X, c.Y))
+	return nil
+}
+
+func setupZkeyCircuit(t *testing.T, circuit frontend.Circuit) (*cs_bn254.R1CS, *groth16_bn254.ProvingKey, *groth16_bn254.VerifyingKey) {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	require.NoError(t, err)
+	pk, vk, err := groth16.Setup(ccs)
+	require.NoError(t, err)
+	return ccs.(*cs_bn254.R1CS), pk.(*groth16_bn254.ProvingKey), vk.(*groth16_bn254.VerifyingKey)
+}
+
+func TestZkeyRoundTrip(t *testing.T) {
+	r1cs, pk, vk := setupZkeyCircuit(t, &cubeCircuit{})
+
+	// export → ReadZkey → toGnark
+	zkey, err := zkeyFromGnark(r1cs, pk, vk)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "circuit.zkey")
+	require.NoError(t, saveTo(path, zkey))
+	read, err := ReadZkey(path)
+	require.NoError(t, err)
+	// The export leaves the moduli to the writer, which the reader sets
+	header := read.protocolHeader
+	require.Equal(t, ecc.BN254.BaseField(), &header.q)
+	require.Equal(t, ecc.BN254.ScalarField(), &header.r)
+	header.q, header.r = zkey.protocolHeader.q, zkey.protocolHeader.r
+	require.Equal(t, zkey.protocolHeader, header)
+	require.Equal(t, zkey.Coefs, read.Coefs)
+	importedPk, importedVk, err := read.toGnark(r1cs)
+	require.NoError(t, err)
+
+	witness, err := frontend.NewWitness(&cubeCircuit{X: 35, Z: 105, Y: 3}, ecc.BN254.ScalarField())
+	require.NoError(t, err)
+	publicWitness, err := witness.Public()
+	require.NoError(t, err)
+	wrongWitness, err := frontend.NewWitness(&cubeCircuit{X: 35, Z: 106}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	require.NoError(t, err)
+
+	// The imported keys prove and verify, and agree with the original ones
+	proof, err := groth16.Prove(r1cs, &importedPk, witness)
+	require.NoError(t, err)
+	require.NoError(t, groth16.Verify(proof, &importedVk, publicWitness))
+	require.NoError(t, groth16.Verify(proof, vk, publicWitness))
+	require.Error(t, groth16.Verify(proof, &importedVk, wrongWitness))
+	proof, err = groth16.Prove(r1cs, pk, witness)
+	require.NoError(t, err)
+	require.NoError(t, groth16.Verify(proof, &importedVk, publicWitness))
+
+	t.Run("other circuit", func(t *testing.T) {
+		other, _, _ := setupZkeyCircuit(t, &committedSquareCircuit{})
+		_, _, err := read.toGnark(other)
+		require.ErrorContains(t, err, "Pedersen commitment")
+		_, err = zkeyFromGnark(other, pk, vk)
+		require.ErrorContains(t, err, "Pedersen commitment")
+
+		tampered := read
+		tampered.Coefs = append([]ZkeyCoef{}, read.Coefs...)
+		tampered.Coefs[0].Value.SetUint64(42)
+		_, _, err = tampered.toGnark(r1cs)
+		require.ErrorContains(t, err, "the coefficient 0 of the zkey doesn't match the constraint system")
+	})
+
+	t.Run("mismatching delta", func(t *testing.T) {
+		tampered := read
+		tampered.protocolHeader.delta1 = read.protocolHeader.alpha1
+		_, _, err := tampered.toGnark(r1cs)
+		require.ErrorContains(t, err, "the zkey delta1 and delta2 don't match")
+	})
+}
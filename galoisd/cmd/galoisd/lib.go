@@ -5,74 +5,95 @@ package main
 
 /*
    #include <stdlib.h>
-   #include <stdio.h>
-   #include <errno.h>
+   #include <stdint.h>
+   #include <stddef.h>
+
+   // Opaque handle of an output held by the library, to be freed with
+   // GaloisBufferFree once copied.
+   typedef uintptr_t GaloisBuffer;
+
+   // Returned by every call, along with the message of the error in the
+   // buffer given as last argument.
+   enum {
+       GALOIS_OK = 0,
+       GALOIS_ERR_INVALID_ARGUMENT = 1,
+       GALOIS_ERR_DECODE = 2,
+       GALOIS_ERR_VERIFICATION = 3,
+       GALOIS_ERR_BUFFER_TOO_SMALL = 4,
+       GALOIS_ERR_INTERNAL = 5,
+   };
+
+   #define GALOIS_HASH_SIZE 32
 */
 import "C"
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"fmt"
 	"io"
-	"log"
+	"runtime/cgo"
 	"unsafe"
 
 	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	bn254 "github.com/consensys/gnark/constraint/bn254"
 )
 
-//export Phase2Verify
-func Phase2Verify(phase2PreviousRaw *C.char, phase2ContribRaw *C.char, l C.int) bool {
-	phase2PreviousPayload := C.GoBytes(unsafe.Pointer(phase2PreviousRaw), l)
-	var previous mpc.Phase2
-	err := readFromBuffer(phase2PreviousPayload, &previous)
-	if err != nil {
-		log.Printf("Failed to read phase2PreviousPayload: %v\n", err)
-		return false
-	}
-	phase2ContribPayload := C.GoBytes(unsafe.Pointer(phase2ContribRaw), l)
-	var contrib mpc.Phase2
-	err = readFromBuffer(phase2ContribPayload, &contrib)
-	if err != nil {
-		log.Printf("Failed to read phase2ContribPayload: %v\n", err)
-		return false
+// An error along with the code returned to the caller
+type galoisError struct {
+	code C.int
+	err  error
+}
+
+func (e *galoisError) Error() string {
+	return e.err.Error()
+}
+
+func newGaloisError(code C.int, format string, args ...any) *galoisError {
+	return &galoisError{code: code, err: fmt.Errorf(format, args...)}
+}
+
+// Turn the error into its code and hand its message over to the caller
+// through errorOut, if not null, which is left at 0 on success. The message is
+// returned by the call that failed rather than kept globally, as the library
+// is called from several threads. A panic must not unwind through the
+// caller's frames, hence is recovered as an internal error.
+func galoisResult(errorOut *C.GaloisBuffer, f func() error) (code C.int) {
+	defer func() {
+		if r := recover(); r != nil {
+			code = setError(errorOut, newGaloisError(C.GALOIS_ERR_INTERNAL, "%v", r))
+		}
+	}()
+	if errorOut != nil {
+		*errorOut = 0
 	}
-	err = mpc.VerifyPhase2(&previous, &contrib)
-	if err != nil {
-		log.Printf("Failed to verify phase2 contribution: %v\n", err)
-		return false
+	err := f()
+	if err == nil {
+		return C.GALOIS_OK
 	}
-	return true
+	return setError(errorOut, err)
 }
 
-//export Phase2Contribute
-func Phase2Contribute(phase2PayloadRaw *C.char, phase2ContribRaw *C.char, l C.int) bool {
-	phase2Payload := C.GoBytes(unsafe.Pointer(phase2PayloadRaw), l)
-	phase2Contrib, err := phase2Contribute(phase2Payload)
-	if err != nil {
-		log.Printf("Failed to contribute %v\n", err)
-		return false
+func setError(errorOut *C.GaloisBuffer, err error) C.int {
+	code := C.int(C.GALOIS_ERR_INTERNAL)
+	if e, ok := err.(*galoisError); ok {
+		code = e.code
 	}
-	phase2ContribOutput := unsafe.Slice(phase2ContribRaw, l)
-	for i := 0; i < int(l); i++ {
-		phase2ContribOutput[i] = C.char(phase2Contrib[i])
+	if errorOut != nil {
+		*errorOut = C.GaloisBuffer(cgo.NewHandle([]byte(err.Error())))
 	}
-	return true
+	return code
 }
 
-func phase2Contribute(phase2Payload []byte) ([]byte, error) {
-	var srs2 mpc.Phase2
-	err := readFromBuffer(phase2Payload, &srs2)
-	if err != nil {
-		log.Printf("Failed to read phase2Payload: %v\n", err)
-		return nil, err
+// Borrow the caller's buffer for the duration of the call.
+func borrowBytes(raw *C.char, l C.size_t) ([]byte, error) {
+	if l == 0 {
+		return nil, nil
 	}
-	srs2.Contribute()
-	var phase2Output bytes.Buffer
-	_, err = srs2.WriteTo(&phase2Output)
-	if err != nil {
-		log.Printf("Failed to write phase2Payload: %v\n", err)
-		return nil, err
+	if raw == nil {
+		return nil, newGaloisError(C.GALOIS_ERR_INVALID_ARGUMENT, "null buffer of length %d", l)
 	}
-	return phase2Output.Bytes(), nil
+	return unsafe.Slice((*byte)(unsafe.Pointer(raw)), int(l)), nil
 }
 
 func readFromBuffer(buffer []byte, obj io.ReaderFrom) error {
@@ -80,4 +101,240 @@ func readFromBuffer(buffer []byte, obj io.ReaderFrom) error {
 	return err
 }
 
+func decode(raw *C.char, l C.size_t, name string, obj io.ReaderFrom) error {
+	buffer, err := borrowBytes(raw, l)
+	if err != nil {
+		return err
+	}
+	err = readFromBuffer(buffer, obj)
+	if err != nil {
+		return newGaloisError(C.GALOIS_ERR_DECODE, "failed to read %s: %v", name, err)
+	}
+	return nil
+}
+
+// Encode the output and hand it over to the caller.
+func newBuffer(out *C.GaloisBuffer, x io.WriterTo) error {
+	if out == nil {
+		return newGaloisError(C.GALOIS_ERR_INVALID_ARGUMENT, "null output")
+	}
+	var buffer bytes.Buffer
+	_, err := x.WriteTo(&buffer)
+	if err != nil {
+		return err
+	}
+	*out = C.GaloisBuffer(cgo.NewHandle(buffer.Bytes()))
+	return nil
+}
+
+func bufferBytes(buffer C.GaloisBuffer) ([]byte, error) {
+	if buffer == 0 {
+		return nil, newGaloisError(C.GALOIS_ERR_INVALID_ARGUMENT, "null buffer handle")
+	}
+	b, ok := cgo.Handle(buffer).Value().([]byte)
+	if !ok {
+		return nil, newGaloisError(C.GALOIS_ERR_INVALID_ARGUMENT, "not a buffer handle")
+	}
+	return b, nil
+}
+
+//export GaloisBufferLen
+func GaloisBufferLen(buffer C.GaloisBuffer, l *C.size_t, errorOut *C.GaloisBuffer) C.int {
+	return galoisResult(errorOut, func() error {
+		if l == nil {
+			return newGaloisError(C.GALOIS_ERR_INVALID_ARGUMENT, "null length")
+		}
+		b, err := bufferBytes(buffer)
+		if err != nil {
+			return err
+		}
+		*l = C.size_t(len(b))
+		return nil
+	})
+}
+
+//export GaloisBufferCopy
+func GaloisBufferCopy(buffer C.GaloisBuffer, outRaw *C.char, outLen C.size_t, errorOut *C.GaloisBuffer) C.int {
+	return galoisResult(errorOut, func() error {
+		b, err := bufferBytes(buffer)
+		if err != nil {
+			return err
+		}
+		if int(outLen) < len(b) {
+			return newGaloisError(C.GALOIS_ERR_BUFFER_TOO_SMALL, "the buffer is %d bytes long, got %d", len(b), outLen)
+		}
+		out, err := borrowBytes(outRaw, outLen)
+		if err != nil {
+			return err
+		}
+		copy(out, b)
+		return nil
+	})
+}
+
+//export GaloisBufferFree
+func GaloisBufferFree(buffer C.GaloisBuffer, errorOut *C.GaloisBuffer) C.int {
+	return galoisResult(errorOut, func() error {
+		if buffer != 0 {
+			cgo.Handle(buffer).Delete()
+		}
+		return nil
+	})
+}
+
+//export Phase2Verify
+func Phase2Verify(phase2PreviousRaw *C.char, phase2PreviousLen C.size_t, phase2ContribRaw *C.char, phase2ContribLen C.size_t, errorOut *C.GaloisBuffer) C.int {
+	return galoisResult(errorOut, func() error {
+		var previous mpc.Phase2
+		err := decode(phase2PreviousRaw, phase2PreviousLen, "the previous phase2", &previous)
+		if err != nil {
+			return err
+		}
+		var contrib mpc.Phase2
+		err = decode(phase2ContribRaw, phase2ContribLen, "the contribution", &contrib)
+		if err != nil {
+			return err
+		}
+		err = verifyPhase2(&previous, &contrib)
+		if err != nil {
+			return newGaloisError(C.GALOIS_ERR_VERIFICATION, "failed to verify phase2 contribution: %v", err)
+		}
+		return nil
+	})
+}
+
+// Points not in the subgroup make mpc.VerifyPhase2 panic, which is an
+// invalid contribution rather than an internal error.
+func verifyPhase2(previous, contrib *mpc.Phase2) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return mpc.VerifyPhase2(previous, contrib)
+}
+
+//export Phase2Contribute
+func Phase2Contribute(phase2PayloadRaw *C.char, phase2PayloadLen C.size_t, phase2Contrib *C.GaloisBuffer, errorOut *C.GaloisBuffer) C.int {
+	return galoisResult(errorOut, func() error {
+		var srs2 mpc.Phase2
+		err := decode(phase2PayloadRaw, phase2PayloadLen, "the phase2", &srs2)
+		if err != nil {
+			return err
+		}
+		srs2.Contribute()
+		return newBuffer(phase2Contrib, &srs2)
+	})
+}
+
+// Write the hash of the phase2, the challenge of the next contribution,
+// after checking that it's the hash of its content.
+//
+//export Phase2Hash
+func Phase2Hash(phase2Raw *C.char, phase2Len C.size_t, hashRaw *C.char, hashLen C.size_t, errorOut *C.GaloisBuffer) C.int {
+	return galoisResult(errorOut, func() error {
+		var srs2 mpc.Phase2
+		err := decode(phase2Raw, phase2Len, "the phase2", &srs2)
+		if err != nil {
+			return err
+		}
+		hash := srs2.Hash
+		srs2.Hash = nil
+		sha := sha256.New()
+		_, err = srs2.WriteTo(sha)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(hash, sha.Sum(nil)) {
+			return newGaloisError(C.GALOIS_ERR_VERIFICATION, "the phase2 hash doesn't match its content")
+		}
+		if hashLen < C.GALOIS_HASH_SIZE {
+			return newGaloisError(C.GALOIS_ERR_BUFFER_TOO_SMALL, "the hash is %d bytes long, got %d", C.GALOIS_HASH_SIZE, hashLen)
+		}
+		out, err := borrowBytes(hashRaw, hashLen)
+		if err != nil {
+			return err
+		}
+		copy(out, hash)
+		return nil
+	})
+}
+
+//export Phase2Init
+func Phase2Init(r1csRaw *C.char, r1csLen C.size_t, phase1Raw *C.char, phase1Len C.size_t, phase2 *C.GaloisBuffer, phase2Evals *C.GaloisBuffer, errorOut *C.GaloisBuffer) C.int {
+	return galoisResult(errorOut, func() error {
+		var r1cs bn254.R1CS
+		err := decode(r1csRaw, r1csLen, "the r1cs", &r1cs)
+		if err != nil {
+			return err
+		}
+		var srs1 mpc.Phase1
+		err = decode(phase1Raw, phase1Len, "the phase1", &srs1)
+		if err != nil {
+			return err
+		}
+		if phase2Evals == nil {
+			return newGaloisError(C.GALOIS_ERR_INVALID_ARGUMENT, "null output")
+		}
+		srs2, evals := mpc.InitPhase2(&r1cs, &srs1)
+		err = newBuffer(phase2, &srs2)
+		if err != nil {
+			return err
+		}
+		err = newBuffer(phase2Evals, &evals)
+		if err != nil {
+			GaloisBufferFree(*phase2, nil)
+			*phase2 = 0
+		}
+		return err
+	})
+}
+
+//export Phase2Extract
+func Phase2Extract(
+	r1csRaw *C.char, r1csLen C.size_t,
+	phase1Raw *C.char, phase1Len C.size_t,
+	phase2Raw *C.char, phase2Len C.size_t,
+	phase2EvalsRaw *C.char, phase2EvalsLen C.size_t,
+	provingKey *C.GaloisBuffer, verifyingKey *C.GaloisBuffer,
+	errorOut *C.GaloisBuffer,
+) C.int {
+	return galoisResult(errorOut, func() error {
+		var r1cs bn254.R1CS
+		err := decode(r1csRaw, r1csLen, "the r1cs", &r1cs)
+		if err != nil {
+			return err
+		}
+		var srs1 mpc.Phase1
+		err = decode(phase1Raw, phase1Len, "the phase1", &srs1)
+		if err != nil {
+			return err
+		}
+		var srs2 mpc.Phase2
+		err = decode(phase2Raw, phase2Len, "the phase2", &srs2)
+		if err != nil {
+			return err
+		}
+		var evals mpc.Phase2Evaluations
+		err = decode(phase2EvalsRaw, phase2EvalsLen, "the phase2 evaluations", &evals)
+		if err != nil {
+			return err
+		}
+		if verifyingKey == nil {
+			return newGaloisError(C.GALOIS_ERR_INVALID_ARGUMENT, "null output")
+		}
+		pk, vk := mpc.ExtractKeys(&r1cs, &srs1, &srs2, &evals)
+		err = newBuffer(provingKey, &pk)
+		if err != nil {
+			return err
+		}
+		err = newBuffer(verifyingKey, &vk)
+		if err != nil {
+			GaloisBufferFree(*provingKey, nil)
+			*provingKey = 0
+		}
+		return err
+	})
+}
+
 func main() {}
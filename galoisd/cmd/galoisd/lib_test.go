@@ -0,0 +1,197 @@
+//go:build library && library_testing
+// +build library,library_testing
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/bits"
+	"sync"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	mpc "github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cubeCircuit struct {
+	X frontend.Variable `gnark:",public"`
+	Y frontend.Variable
+}
+
+func (c *cubeCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.X, api.Add(api.Mul(c.Y, c.Y, c.Y), c.Y, 5))
+	return nil
+}
+
+func encode(t *testing.T, x io.WriterTo) []byte {
+	var buffer bytes.Buffer
+	_, err := x.WriteTo(&buffer)
+	require.NoError(t, err)
+	return buffer.Bytes()
+}
+
+// Query the length of the buffer, copy it and free it, as a C caller would.
+func takeBuffer(t *testing.T, buffer uintptr) []byte {
+	l, result := cBufferLen(buffer)
+	requireOK(t, result)
+	b, result := cBufferCopy(buffer, l)
+	requireOK(t, result)
+	requireOK(t, cBufferFree(buffer))
+	return b
+}
+
+func requireOK(t *testing.T, result cResult) {
+	require.Equal(t, cResult{code: galoisOK}, result)
+}
+
+func requireError(t *testing.T, result cResult, expectedCode int, message string) {
+	require.Equal(t, expectedCode, result.code)
+	require.Contains(t, result.message, message)
+}
+
+// The r1cs and a phase1 matching its domain
+func setup(t *testing.T) (*cs.R1CS, []byte, []byte) {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &cubeCircuit{})
+	require.NoError(t, err)
+	r1cs := ccs.(*cs.R1CS)
+	domain := ecc.NextPowerOfTwo(uint64(r1cs.GetNbConstraints()))
+	srs1 := mpc.InitPhase1(bits.TrailingZeros64(domain))
+	srs1.Contribute()
+	return r1cs, encode(t, r1cs), encode(t, &srs1)
+}
+
+func TestCeremony(t *testing.T) {
+	ccs, r1csBytes, phase1 := setup(t)
+
+	phase2Buffer, evalsBuffer, result := cPhase2Init(r1csBytes, phase1)
+	requireOK(t, result)
+	phase2Init := takeBuffer(t, phase2Buffer)
+	evals := takeBuffer(t, evalsBuffer)
+
+	contribBuffer, result := cPhase2Contribute(phase2Init)
+	requireOK(t, result)
+	phase2Contrib := takeBuffer(t, contribBuffer)
+	// The contribution has the size of its parent, with different lengths
+	// below to check that they are read independently.
+	require.Len(t, phase2Contrib, len(phase2Init))
+	requireOK(t, cPhase2Verify(phase2Init, phase2Contrib))
+
+	var srs2 mpc.Phase2
+	_, err := srs2.ReadFrom(bytes.NewReader(phase2Contrib))
+	require.NoError(t, err)
+	hash, result := cPhase2Hash(phase2Contrib, galoisHashSize)
+	requireOK(t, result)
+	require.Equal(t, srs2.Hash, hash)
+
+	pkBuffer, vkBuffer, result := cPhase2Extract(r1csBytes, phase1, phase2Contrib, evals)
+	requireOK(t, result)
+	var pk groth16_bn254.ProvingKey
+	_, err = pk.ReadFrom(bytes.NewReader(takeBuffer(t, pkBuffer)))
+	require.NoError(t, err)
+	var vk groth16_bn254.VerifyingKey
+	_, err = vk.ReadFrom(bytes.NewReader(takeBuffer(t, vkBuffer)))
+	require.NoError(t, err)
+
+	witness, err := frontend.NewWitness(&cubeCircuit{X: 35, Y: 3}, ecc.BN254.ScalarField())
+	require.NoError(t, err)
+	publicWitness, err := witness.Public()
+	require.NoError(t, err)
+	proof, err := groth16.Prove(ccs, &pk, witness)
+	require.NoError(t, err)
+	require.NoError(t, groth16.Verify(proof, &vk, publicWitness))
+}
+
+func TestErrors(t *testing.T) {
+	_, r1csBytes, phase1 := setup(t)
+	phase2Buffer, evalsBuffer, result := cPhase2Init(r1csBytes, phase1)
+	requireOK(t, result)
+	phase2Init := takeBuffer(t, phase2Buffer)
+	takeBuffer(t, evalsBuffer)
+
+	t.Run("unchanged contribution", func(t *testing.T) {
+		result := cPhase2Verify(phase2Init, phase2Init)
+		requireError(t, result, galoisVerificationError, "failed to verify phase2 contribution")
+	})
+
+	t.Run("truncated contribution", func(t *testing.T) {
+		result := cPhase2Verify(phase2Init, phase2Init[:len(phase2Init)/2])
+		requireError(t, result, galoisDecodeError, "failed to read the contribution")
+	})
+
+	t.Run("null input", func(t *testing.T) {
+		_, result := cPhase2Contribute(nil)
+		requireError(t, result, galoisDecodeError, "failed to read the phase2")
+	})
+
+	t.Run("tampered hash", func(t *testing.T) {
+		tampered := bytes.Clone(phase2Init)
+		tampered[len(tampered)-1] ^= 1
+		_, result := cPhase2Hash(tampered, galoisHashSize)
+		requireError(t, result, galoisVerificationError, "the phase2 hash doesn't match its content")
+	})
+
+	t.Run("small hash buffer", func(t *testing.T) {
+		_, result := cPhase2Hash(phase2Init, galoisHashSize-1)
+		requireError(t, result, galoisBufferTooSmall, "the hash is 32 bytes long, got 31")
+	})
+
+	t.Run("small output buffer", func(t *testing.T) {
+		contribBuffer, result := cPhase2Contribute(phase2Init)
+		requireOK(t, result)
+		l, result := cBufferLen(contribBuffer)
+		requireOK(t, result)
+		_, result = cBufferCopy(contribBuffer, l-1)
+		requireError(t, result, galoisBufferTooSmall, "bytes long")
+		// The buffer is still there
+		contrib, result := cBufferCopy(contribBuffer, l)
+		requireOK(t, result)
+		requireOK(t, cPhase2Verify(phase2Init, contrib))
+		requireOK(t, cBufferFree(contribBuffer))
+	})
+
+	t.Run("invalid handles", func(t *testing.T) {
+		_, result := cBufferLen(0)
+		requireError(t, result, galoisInvalidArgument, "null buffer handle")
+		requireOK(t, cBufferFree(0))
+		// Freed twice, cgo panics which must not reach the caller
+		contribBuffer, result := cPhase2Contribute(phase2Init)
+		requireOK(t, result)
+		requireOK(t, cBufferFree(contribBuffer))
+		result = cBufferFree(contribBuffer)
+		requireError(t, result, galoisInternalError, "invalid Handle")
+	})
+
+	t.Run("null error output", func(t *testing.T) {
+		require.Equal(t, galoisDecodeError, cPhase2VerifyCode(phase2Init, nil))
+		require.Equal(t, galoisOK, cPhase2VerifyCode(phase2Init, takeBuffer(t, mustContribute(t, phase2Init))))
+	})
+
+	// Each call returns its own error, whichever thread fails concurrently
+	t.Run("concurrent errors", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, result := cPhase2Hash(phase2Init, i)
+				assert.Equal(t, cResult{galoisBufferTooSmall, fmt.Sprintf("the hash is 32 bytes long, got %d", i)}, result)
+			}(i)
+		}
+		wg.Wait()
+	})
+}
+
+func mustContribute(t *testing.T, phase2 []byte) uintptr {
+	contribBuffer, result := cPhase2Contribute(phase2)
+	requireOK(t, result)
+	return contribBuffer
+}
@@ -0,0 +1,38 @@
+//go:build library && library_testing
+// +build library,library_testing
+
+#include "_cgo_export.h"
+
+// Test files can't use cgo, the tests go through these shims to call the
+// exported API from C.
+int call_phase2_verify(char *previous, size_t previousLen, char *contrib, size_t contribLen, GaloisBuffer *error) {
+    return Phase2Verify(previous, previousLen, contrib, contribLen, error);
+}
+
+int call_phase2_contribute(char *phase2, size_t phase2Len, GaloisBuffer *contrib, GaloisBuffer *error) {
+    return Phase2Contribute(phase2, phase2Len, contrib, error);
+}
+
+int call_phase2_hash(char *phase2, size_t phase2Len, char *hash, size_t hashLen, GaloisBuffer *error) {
+    return Phase2Hash(phase2, phase2Len, hash, hashLen, error);
+}
+
+int call_phase2_init(char *r1cs, size_t r1csLen, char *phase1, size_t phase1Len, GaloisBuffer *phase2, GaloisBuffer *evals, GaloisBuffer *error) {
+    return Phase2Init(r1cs, r1csLen, phase1, phase1Len, phase2, evals, error);
+}
+
+int call_phase2_extract(char *r1cs, size_t r1csLen, char *phase1, size_t phase1Len, char *phase2, size_t phase2Len, char *evals, size_t evalsLen, GaloisBuffer *pk, GaloisBuffer *vk, GaloisBuffer *error) {
+    return Phase2Extract(r1cs, r1csLen, phase1, phase1Len, phase2, phase2Len, evals, evalsLen, pk, vk, error);
+}
+
+int call_buffer_len(GaloisBuffer buffer, size_t *len, GaloisBuffer *error) {
+    return GaloisBufferLen(buffer, len, error);
+}
+
+int call_buffer_copy(GaloisBuffer buffer, char *out, size_t outLen, GaloisBuffer *error) {
+    return GaloisBufferCopy(buffer, out, outLen, error);
+}
+
+int call_buffer_free(GaloisBuffer buffer, GaloisBuffer *error) {
+    return GaloisBufferFree(buffer, error);
+}
@@ -0,0 +1,157 @@
+//go:build library && library_testing
+// +build library,library_testing
+
+package main
+
+/*
+   #include <stdlib.h>
+   #include <stdint.h>
+
+   typedef uintptr_t GaloisBuffer;
+
+   // Test files can't use cgo, the tests go through these shims of
+   // lib_testing.c to call the exported API from C.
+   int call_phase2_verify(char *previous, size_t previousLen, char *contrib, size_t contribLen, GaloisBuffer *error);
+   int call_phase2_contribute(char *phase2, size_t phase2Len, GaloisBuffer *contrib, GaloisBuffer *error);
+   int call_phase2_hash(char *phase2, size_t phase2Len, char *hash, size_t hashLen, GaloisBuffer *error);
+   int call_phase2_init(char *r1cs, size_t r1csLen, char *phase1, size_t phase1Len, GaloisBuffer *phase2, GaloisBuffer *evals, GaloisBuffer *error);
+   int call_phase2_extract(char *r1cs, size_t r1csLen, char *phase1, size_t phase1Len, char *phase2, size_t phase2Len, char *evals, size_t evalsLen, GaloisBuffer *pk, GaloisBuffer *vk, GaloisBuffer *error);
+   int call_buffer_len(GaloisBuffer buffer, size_t *len, GaloisBuffer *error);
+   int call_buffer_copy(GaloisBuffer buffer, char *out, size_t outLen, GaloisBuffer *error);
+   int call_buffer_free(GaloisBuffer buffer, GaloisBuffer *error);
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// Codes of the ABI, which the bindings of other languages rely upon
+const (
+	galoisOK                = 0
+	galoisInvalidArgument   = 1
+	galoisDecodeError       = 2
+	galoisVerificationError = 3
+	galoisBufferTooSmall    = 4
+	galoisInternalError     = 5
+	galoisHashSize          = 32
+)
+
+// Copy of the input in C memory, to be freed by the caller, nil for a nil input.
+func cBytes(b []byte) *C.char {
+	if b == nil {
+		return nil
+	}
+	return (*C.char)(C.CBytes(b))
+}
+
+func cFree(p *C.char) {
+	C.free(unsafe.Pointer(p))
+}
+
+// The result of a call, along with the message of its error
+type cResult struct {
+	code    int
+	message string
+}
+
+// Take the message out of the error buffer of a call, as a C caller would.
+func cCallResult(code C.int, errorBuffer C.GaloisBuffer) cResult {
+	result := cResult{code: int(code)}
+	if errorBuffer == 0 {
+		return result
+	}
+	var l C.size_t
+	if C.call_buffer_len(errorBuffer, &l, nil) == galoisOK {
+		outRaw := (*C.char)(C.malloc(l + 1))
+		defer cFree(outRaw)
+		if C.call_buffer_copy(errorBuffer, outRaw, l, nil) == galoisOK {
+			result.message = C.GoStringN(outRaw, C.int(l))
+		}
+	}
+	C.call_buffer_free(errorBuffer, nil)
+	return result
+}
+
+func cPhase2Verify(previous, contrib []byte) cResult {
+	previousRaw, contribRaw := cBytes(previous), cBytes(contrib)
+	defer cFree(previousRaw)
+	defer cFree(contribRaw)
+	var errorBuffer C.GaloisBuffer
+	code := C.call_phase2_verify(previousRaw, C.size_t(len(previous)), contribRaw, C.size_t(len(contrib)), &errorBuffer)
+	return cCallResult(code, errorBuffer)
+}
+
+func cPhase2Contribute(phase2 []byte) (uintptr, cResult) {
+	phase2Raw := cBytes(phase2)
+	defer cFree(phase2Raw)
+	var contrib, errorBuffer C.GaloisBuffer
+	code := C.call_phase2_contribute(phase2Raw, C.size_t(len(phase2)), &contrib, &errorBuffer)
+	return uintptr(contrib), cCallResult(code, errorBuffer)
+}
+
+func cPhase2Hash(phase2 []byte, hashLen int) ([]byte, cResult) {
+	phase2Raw := cBytes(phase2)
+	defer cFree(phase2Raw)
+	hashRaw := (*C.char)(C.malloc(C.size_t(hashLen) + 1))
+	defer cFree(hashRaw)
+	var errorBuffer C.GaloisBuffer
+	code := C.call_phase2_hash(phase2Raw, C.size_t(len(phase2)), hashRaw, C.size_t(hashLen), &errorBuffer)
+	return C.GoBytes(unsafe.Pointer(hashRaw), C.int(hashLen)), cCallResult(code, errorBuffer)
+}
+
+func cPhase2Init(r1cs, phase1 []byte) (uintptr, uintptr, cResult) {
+	r1csRaw, phase1Raw := cBytes(r1cs), cBytes(phase1)
+	defer cFree(r1csRaw)
+	defer cFree(phase1Raw)
+	var phase2, evals, errorBuffer C.GaloisBuffer
+	code := C.call_phase2_init(r1csRaw, C.size_t(len(r1cs)), phase1Raw, C.size_t(len(phase1)), &phase2, &evals, &errorBuffer)
+	return uintptr(phase2), uintptr(evals), cCallResult(code, errorBuffer)
+}
+
+func cPhase2Extract(r1cs, phase1, phase2, evals []byte) (uintptr, uintptr, cResult) {
+	r1csRaw, phase1Raw, phase2Raw, evalsRaw := cBytes(r1cs), cBytes(phase1), cBytes(phase2), cBytes(evals)
+	defer cFree(r1csRaw)
+	defer cFree(phase1Raw)
+	defer cFree(phase2Raw)
+	defer cFree(evalsRaw)
+	var pk, vk, errorBuffer C.GaloisBuffer
+	code := C.call_phase2_extract(
+		r1csRaw, C.size_t(len(r1cs)),
+		phase1Raw, C.size_t(len(phase1)),
+		phase2Raw, C.size_t(len(phase2)),
+		evalsRaw, C.size_t(len(evals)),
+		&pk, &vk, &errorBuffer,
+	)
+	return uintptr(pk), uintptr(vk), cCallResult(code, errorBuffer)
+}
+
+func cBufferLen(buffer uintptr) (int, cResult) {
+	var l C.size_t
+	var errorBuffer C.GaloisBuffer
+	code := C.call_buffer_len(C.GaloisBuffer(buffer), &l, &errorBuffer)
+	return int(l), cCallResult(code, errorBuffer)
+}
+
+// Copy the buffer into C memory of the given length, then back to Go.
+func cBufferCopy(buffer uintptr, outLen int) ([]byte, cResult) {
+	outRaw := (*C.char)(C.malloc(C.size_t(outLen) + 1))
+	defer cFree(outRaw)
+	var errorBuffer C.GaloisBuffer
+	code := C.call_buffer_copy(C.GaloisBuffer(buffer), outRaw, C.size_t(outLen), &errorBuffer)
+	return C.GoBytes(unsafe.Pointer(outRaw), C.int(outLen)), cCallResult(code, errorBuffer)
+}
+
+func cBufferFree(buffer uintptr) cResult {
+	var errorBuffer C.GaloisBuffer
+	code := C.call_buffer_free(C.GaloisBuffer(buffer), &errorBuffer)
+	return cCallResult(code, errorBuffer)
+}
+
+// Call Phase2Verify without an error output, only the code is returned.
+func cPhase2VerifyCode(previous, contrib []byte) int {
+	previousRaw, contribRaw := cBytes(previous), cBytes(contrib)
+	defer cFree(previousRaw)
+	defer cFree(contribRaw)
+	return int(C.call_phase2_verify(previousRaw, C.size_t(len(previous)), contribRaw, C.size_t(len(contrib)), nil))
+}
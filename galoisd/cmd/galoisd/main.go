@@ -5,6 +5,8 @@ package main
 
 import (
 	"galois/cmd/galoisd/cmd"
+	"os"
+
 	"github.com/spf13/cobra"
 )
 
@@ -16,12 +18,25 @@ func main() {
 	rootCmd.AddCommand(cmd.ExampleVerifyCmd())
 	rootCmd.AddCommand(cmd.QueryStats())
 	rootCmd.AddCommand(cmd.QueryStatsHealth())
+	rootCmd.AddCommand(cmd.CircuitCheckCmd())
+	rootCmd.AddCommand(cmd.BenchCmd())
+	rootCmd.AddCommand(cmd.GenVectorsCmd())
+	rootCmd.AddCommand(cmd.VerifyOfflineCmd())
 	rootCmd.AddCommand(
 		cmd.Phase1InitCmd(),
+		cmd.Phase1SRSCmd(),
 		cmd.Phase2InitCmd(),
 		cmd.Phase2ContributeCmd(),
+		cmd.Phase2BeaconCmd(),
 		cmd.Phase2VerifyCmd(),
+		cmd.Phase2VerifyChainCmd(),
 		cmd.Phase2ExtractCmd(),
+		cmd.CeremonyCoordinatorCmd(),
+		cmd.CeremonyContributeCmd(),
+		cmd.ImportZkeyCmd(),
+		cmd.ExportZkeyCmd(),
 	)
-	rootCmd.Execute()
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
 }
@@ -10,13 +10,41 @@
       mkCi,
       ...
     }:
+    let
+      # The inputs hash encoding is shared with the light client
+      src = pkgs.lib.fileset.toSource {
+        root = ../.;
+        fileset = pkgs.lib.fileset.unions [
+          ./.
+          ../11-cometbls/inputshash
+        ];
+      };
+      vendorHash = "sha256-5aF7Sf2BrZwOdE6PpJOkDLuKiRqvX1BaD6dybuubZoo=";
+    in
     {
+      checks = {
+        # The C API of the library is only built, hence tested, with the
+        # library tags
+        galoisd-library-tests = goPkgs.buildGo123Module {
+          name = "galoisd-library-tests";
+          inherit src vendorHash;
+          modRoot = "galoisd";
+          subPackages = [ "cmd/galoisd" ];
+          tags = [
+            "library"
+            "library_testing"
+          ];
+          CGO_ENABLED = 1;
+          doCheck = true;
+        };
+      };
+
       packages = {
         galoisd = goPkgs.pkgsStatic.buildGo123Module (
           {
             name = "galoisd";
-            src = ./.;
-            vendorHash = "sha256-lGqoOkJnTvCdIonLwDDqz9ozDDJwB4wyJXlCgvt4arE=";
+            inherit src vendorHash;
+            modRoot = "galoisd";
             meta = {
               mainProgram = "galoisd";
             };
@@ -40,8 +68,8 @@
         galoisd-library = goPkgs.pkgsStatic.buildGo123Module (
           {
             name = "libgalois";
-            src = ./.;
-            vendorHash = "sha256-lGqoOkJnTvCdIonLwDDqz9ozDDJwB4wyJXlCgvt4arE=";
+            inherit src vendorHash;
+            modRoot = "galoisd";
             tags = [ "library" ];
             doCheck = false;
           }
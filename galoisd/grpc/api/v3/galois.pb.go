@@ -10,6 +10,7 @@ import (
 	v1 "github.com/cometbft/cometbft/api/cometbft/types/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -21,6 +22,52 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ProofType int32
+
+const (
+	ProofType_GROTH16 ProofType = 0
+	ProofType_PLONK   ProofType = 1
+)
+
+// Enum value maps for ProofType.
+var (
+	ProofType_name = map[int32]string{
+		0: "GROTH16",
+		1: "PLONK",
+	}
+	ProofType_value = map[string]int32{
+		"GROTH16": 0,
+		"PLONK":   1,
+	}
+)
+
+func (x ProofType) Enum() *ProofType {
+	p := new(ProofType)
+	*p = x
+	return p
+}
+
+func (x ProofType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProofType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v3_galois_proto_enumTypes[0].Descriptor()
+}
+
+func (ProofType) Type() protoreflect.EnumType {
+	return &file_api_v3_galois_proto_enumTypes[0]
+}
+
+func (x ProofType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProofType.Descriptor instead.
+func (ProofType) EnumDescriptor() ([]byte, []int) {
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{0}
+}
+
 type FrElement struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -73,10 +120,11 @@ type ZeroKnowledgeProof struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Content           []byte `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	CompressedContent []byte `protobuf:"bytes,2,opt,name=compressed_content,json=compressedContent,proto3" json:"compressed_content,omitempty"`
-	EvmProof          []byte `protobuf:"bytes,3,opt,name=evm_proof,json=evmProof,proto3" json:"evm_proof,omitempty"`
-	PublicInputs      []byte `protobuf:"bytes,4,opt,name=public_inputs,json=publicInputs,proto3" json:"public_inputs,omitempty"`
+	Content           []byte    `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	CompressedContent []byte    `protobuf:"bytes,2,opt,name=compressed_content,json=compressedContent,proto3" json:"compressed_content,omitempty"`
+	EvmProof          []byte    `protobuf:"bytes,3,opt,name=evm_proof,json=evmProof,proto3" json:"evm_proof,omitempty"`
+	PublicInputs      []byte    `protobuf:"bytes,4,opt,name=public_inputs,json=publicInputs,proto3" json:"public_inputs,omitempty"`
+	ProofType         ProofType `protobuf:"varint,5,opt,name=proof_type,json=proofType,proto3,enum=union.galois.api.v3.ProofType" json:"proof_type,omitempty"`
 }
 
 func (x *ZeroKnowledgeProof) Reset() {
@@ -139,6 +187,13 @@ func (x *ZeroKnowledgeProof) GetPublicInputs() []byte {
 	return nil
 }
 
+func (x *ZeroKnowledgeProof) GetProofType() ProofType {
+	if x != nil {
+		return x.ProofType
+	}
+	return ProofType_GROTH16
+}
+
 type ValidatorSetCommit struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -328,19 +383,165 @@ func (x *ProveResponse) GetTrustedValidatorSetRoot() []byte {
 	return nil
 }
 
+type LightHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainId            string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Height             int64                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Time               *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	ValidatorsHash     []byte                 `protobuf:"bytes,4,opt,name=validators_hash,json=validatorsHash,proto3" json:"validators_hash,omitempty"`
+	NextValidatorsHash []byte                 `protobuf:"bytes,5,opt,name=next_validators_hash,json=nextValidatorsHash,proto3" json:"next_validators_hash,omitempty"`
+	AppHash            []byte                 `protobuf:"bytes,6,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+}
+
+func (x *LightHeader) Reset() {
+	*x = LightHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v3_galois_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LightHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LightHeader) ProtoMessage() {}
+
+func (x *LightHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_galois_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LightHeader.ProtoReflect.Descriptor instead.
+func (*LightHeader) Descriptor() ([]byte, []int) {
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LightHeader) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *LightHeader) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *LightHeader) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *LightHeader) GetValidatorsHash() []byte {
+	if x != nil {
+		return x.ValidatorsHash
+	}
+	return nil
+}
+
+func (x *LightHeader) GetNextValidatorsHash() []byte {
+	if x != nil {
+		return x.NextValidatorsHash
+	}
+	return nil
+}
+
+func (x *LightHeader) GetAppHash() []byte {
+	if x != nil {
+		return x.AppHash
+	}
+	return nil
+}
+
+type LightHeaderInputs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UntrustedHeader         *LightHeader `protobuf:"bytes,1,opt,name=untrusted_header,json=untrustedHeader,proto3" json:"untrusted_header,omitempty"`
+	TrustedValidatorSetRoot []byte       `protobuf:"bytes,2,opt,name=trusted_validator_set_root,json=trustedValidatorSetRoot,proto3" json:"trusted_validator_set_root,omitempty"`
+}
+
+func (x *LightHeaderInputs) Reset() {
+	*x = LightHeaderInputs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v3_galois_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LightHeaderInputs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LightHeaderInputs) ProtoMessage() {}
+
+func (x *LightHeaderInputs) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_galois_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LightHeaderInputs.ProtoReflect.Descriptor instead.
+func (*LightHeaderInputs) Descriptor() ([]byte, []int) {
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LightHeaderInputs) GetUntrustedHeader() *LightHeader {
+	if x != nil {
+		return x.UntrustedHeader
+	}
+	return nil
+}
+
+func (x *LightHeaderInputs) GetTrustedValidatorSetRoot() []byte {
+	if x != nil {
+		return x.TrustedValidatorSetRoot
+	}
+	return nil
+}
+
 type VerifyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Proof      *ZeroKnowledgeProof `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
-	InputsHash []byte              `protobuf:"bytes,2,opt,name=inputs_hash,json=inputsHash,proto3" json:"inputs_hash,omitempty"`
+	Proof *ZeroKnowledgeProof `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	// Types that are assignable to Inputs:
+	//
+	//	*VerifyRequest_InputsHash
+	//	*VerifyRequest_LightHeader
+	Inputs isVerifyRequest_Inputs `protobuf_oneof:"inputs"`
 }
 
 func (x *VerifyRequest) Reset() {
 	*x = VerifyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[5]
+		mi := &file_api_v3_galois_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -353,7 +554,7 @@ func (x *VerifyRequest) String() string {
 func (*VerifyRequest) ProtoMessage() {}
 
 func (x *VerifyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[5]
+	mi := &file_api_v3_galois_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,7 +567,7 @@ func (x *VerifyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyRequest.ProtoReflect.Descriptor instead.
 func (*VerifyRequest) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{5}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *VerifyRequest) GetProof() *ZeroKnowledgeProof {
@@ -376,13 +577,43 @@ func (x *VerifyRequest) GetProof() *ZeroKnowledgeProof {
 	return nil
 }
 
+func (m *VerifyRequest) GetInputs() isVerifyRequest_Inputs {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
 func (x *VerifyRequest) GetInputsHash() []byte {
-	if x != nil {
+	if x, ok := x.GetInputs().(*VerifyRequest_InputsHash); ok {
 		return x.InputsHash
 	}
 	return nil
 }
 
+func (x *VerifyRequest) GetLightHeader() *LightHeaderInputs {
+	if x, ok := x.GetInputs().(*VerifyRequest_LightHeader); ok {
+		return x.LightHeader
+	}
+	return nil
+}
+
+type isVerifyRequest_Inputs interface {
+	isVerifyRequest_Inputs()
+}
+
+type VerifyRequest_InputsHash struct {
+	InputsHash []byte `protobuf:"bytes,2,opt,name=inputs_hash,json=inputsHash,proto3,oneof"`
+}
+
+type VerifyRequest_LightHeader struct {
+	LightHeader *LightHeaderInputs `protobuf:"bytes,3,opt,name=light_header,json=lightHeader,proto3,oneof"`
+}
+
+func (*VerifyRequest_InputsHash) isVerifyRequest_Inputs() {}
+
+func (*VerifyRequest_LightHeader) isVerifyRequest_Inputs() {}
+
 type VerifyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -394,7 +625,7 @@ type VerifyResponse struct {
 func (x *VerifyResponse) Reset() {
 	*x = VerifyResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[6]
+		mi := &file_api_v3_galois_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -407,7 +638,7 @@ func (x *VerifyResponse) String() string {
 func (*VerifyResponse) ProtoMessage() {}
 
 func (x *VerifyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[6]
+	mi := &file_api_v3_galois_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,7 +651,7 @@ func (x *VerifyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyResponse.ProtoReflect.Descriptor instead.
 func (*VerifyResponse) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{6}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *VerifyResponse) GetValid() bool {
@@ -439,7 +670,7 @@ type GenerateContractRequest struct {
 func (x *GenerateContractRequest) Reset() {
 	*x = GenerateContractRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[7]
+		mi := &file_api_v3_galois_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -452,7 +683,7 @@ func (x *GenerateContractRequest) String() string {
 func (*GenerateContractRequest) ProtoMessage() {}
 
 func (x *GenerateContractRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[7]
+	mi := &file_api_v3_galois_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,7 +696,7 @@ func (x *GenerateContractRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateContractRequest.ProtoReflect.Descriptor instead.
 func (*GenerateContractRequest) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{7}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{9}
 }
 
 type GenerateContractResponse struct {
@@ -479,7 +710,7 @@ type GenerateContractResponse struct {
 func (x *GenerateContractResponse) Reset() {
 	*x = GenerateContractResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[8]
+		mi := &file_api_v3_galois_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -492,7 +723,7 @@ func (x *GenerateContractResponse) String() string {
 func (*GenerateContractResponse) ProtoMessage() {}
 
 func (x *GenerateContractResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[8]
+	mi := &file_api_v3_galois_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,7 +736,7 @@ func (x *GenerateContractResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GenerateContractResponse.ProtoReflect.Descriptor instead.
 func (*GenerateContractResponse) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{8}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GenerateContractResponse) GetContent() []byte {
@@ -524,7 +755,7 @@ type QueryStatsRequest struct {
 func (x *QueryStatsRequest) Reset() {
 	*x = QueryStatsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[9]
+		mi := &file_api_v3_galois_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -537,7 +768,7 @@ func (x *QueryStatsRequest) String() string {
 func (*QueryStatsRequest) ProtoMessage() {}
 
 func (x *QueryStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[9]
+	mi := &file_api_v3_galois_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -550,7 +781,7 @@ func (x *QueryStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryStatsRequest.ProtoReflect.Descriptor instead.
 func (*QueryStatsRequest) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{9}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{11}
 }
 
 type VariableStats struct {
@@ -568,7 +799,7 @@ type VariableStats struct {
 func (x *VariableStats) Reset() {
 	*x = VariableStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[10]
+		mi := &file_api_v3_galois_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -581,7 +812,7 @@ func (x *VariableStats) String() string {
 func (*VariableStats) ProtoMessage() {}
 
 func (x *VariableStats) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[10]
+	mi := &file_api_v3_galois_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -594,7 +825,7 @@ func (x *VariableStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VariableStats.ProtoReflect.Descriptor instead.
 func (*VariableStats) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{10}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *VariableStats) GetNbInternalVariables() uint32 {
@@ -644,7 +875,7 @@ type ProvingKeyStats struct {
 func (x *ProvingKeyStats) Reset() {
 	*x = ProvingKeyStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[11]
+		mi := &file_api_v3_galois_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -657,7 +888,7 @@ func (x *ProvingKeyStats) String() string {
 func (*ProvingKeyStats) ProtoMessage() {}
 
 func (x *ProvingKeyStats) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[11]
+	mi := &file_api_v3_galois_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -670,7 +901,7 @@ func (x *ProvingKeyStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProvingKeyStats.ProtoReflect.Descriptor instead.
 func (*ProvingKeyStats) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{11}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ProvingKeyStats) GetNbG1() uint32 {
@@ -700,7 +931,7 @@ type VerifyingKeyStats struct {
 func (x *VerifyingKeyStats) Reset() {
 	*x = VerifyingKeyStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[12]
+		mi := &file_api_v3_galois_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -713,7 +944,7 @@ func (x *VerifyingKeyStats) String() string {
 func (*VerifyingKeyStats) ProtoMessage() {}
 
 func (x *VerifyingKeyStats) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[12]
+	mi := &file_api_v3_galois_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -726,7 +957,7 @@ func (x *VerifyingKeyStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyingKeyStats.ProtoReflect.Descriptor instead.
 func (*VerifyingKeyStats) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{12}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *VerifyingKeyStats) GetNbG1() uint32 {
@@ -762,7 +993,7 @@ type CommitmentStats struct {
 func (x *CommitmentStats) Reset() {
 	*x = CommitmentStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[13]
+		mi := &file_api_v3_galois_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -775,7 +1006,7 @@ func (x *CommitmentStats) String() string {
 func (*CommitmentStats) ProtoMessage() {}
 
 func (x *CommitmentStats) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[13]
+	mi := &file_api_v3_galois_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,7 +1019,7 @@ func (x *CommitmentStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommitmentStats.ProtoReflect.Descriptor instead.
 func (*CommitmentStats) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{13}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CommitmentStats) GetNbPublicCommitted() uint32 {
@@ -819,7 +1050,7 @@ type QueryStatsResponse struct {
 func (x *QueryStatsResponse) Reset() {
 	*x = QueryStatsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[14]
+		mi := &file_api_v3_galois_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -832,7 +1063,7 @@ func (x *QueryStatsResponse) String() string {
 func (*QueryStatsResponse) ProtoMessage() {}
 
 func (x *QueryStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[14]
+	mi := &file_api_v3_galois_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -845,7 +1076,7 @@ func (x *QueryStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryStatsResponse.ProtoReflect.Descriptor instead.
 func (*QueryStatsResponse) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{14}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *QueryStatsResponse) GetVariableStats() *VariableStats {
@@ -887,7 +1118,7 @@ type PollRequest struct {
 func (x *PollRequest) Reset() {
 	*x = PollRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[15]
+		mi := &file_api_v3_galois_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -900,7 +1131,7 @@ func (x *PollRequest) String() string {
 func (*PollRequest) ProtoMessage() {}
 
 func (x *PollRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[15]
+	mi := &file_api_v3_galois_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -913,7 +1144,7 @@ func (x *PollRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PollRequest.ProtoReflect.Descriptor instead.
 func (*PollRequest) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{15}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *PollRequest) GetRequest() *ProveRequest {
@@ -932,7 +1163,7 @@ type ProveRequestPending struct {
 func (x *ProveRequestPending) Reset() {
 	*x = ProveRequestPending{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[16]
+		mi := &file_api_v3_galois_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -945,7 +1176,7 @@ func (x *ProveRequestPending) String() string {
 func (*ProveRequestPending) ProtoMessage() {}
 
 func (x *ProveRequestPending) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[16]
+	mi := &file_api_v3_galois_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -958,7 +1189,7 @@ func (x *ProveRequestPending) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProveRequestPending.ProtoReflect.Descriptor instead.
 func (*ProveRequestPending) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{16}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{18}
 }
 
 type ProveRequestFailed struct {
@@ -972,7 +1203,7 @@ type ProveRequestFailed struct {
 func (x *ProveRequestFailed) Reset() {
 	*x = ProveRequestFailed{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[17]
+		mi := &file_api_v3_galois_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -985,7 +1216,7 @@ func (x *ProveRequestFailed) String() string {
 func (*ProveRequestFailed) ProtoMessage() {}
 
 func (x *ProveRequestFailed) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[17]
+	mi := &file_api_v3_galois_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -998,7 +1229,7 @@ func (x *ProveRequestFailed) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProveRequestFailed.ProtoReflect.Descriptor instead.
 func (*ProveRequestFailed) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{17}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ProveRequestFailed) GetMessage() string {
@@ -1019,7 +1250,7 @@ type ProveRequestDone struct {
 func (x *ProveRequestDone) Reset() {
 	*x = ProveRequestDone{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[18]
+		mi := &file_api_v3_galois_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1032,7 +1263,7 @@ func (x *ProveRequestDone) String() string {
 func (*ProveRequestDone) ProtoMessage() {}
 
 func (x *ProveRequestDone) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[18]
+	mi := &file_api_v3_galois_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1045,7 +1276,7 @@ func (x *ProveRequestDone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProveRequestDone.ProtoReflect.Descriptor instead.
 func (*ProveRequestDone) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{18}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ProveRequestDone) GetResponse() *ProveResponse {
@@ -1071,7 +1302,7 @@ type PollResponse struct {
 func (x *PollResponse) Reset() {
 	*x = PollResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v3_galois_proto_msgTypes[19]
+		mi := &file_api_v3_galois_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1084,7 +1315,7 @@ func (x *PollResponse) String() string {
 func (*PollResponse) ProtoMessage() {}
 
 func (x *PollResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v3_galois_proto_msgTypes[19]
+	mi := &file_api_v3_galois_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1097,7 +1328,7 @@ func (x *PollResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PollResponse.ProtoReflect.Descriptor instead.
 func (*PollResponse) Descriptor() ([]byte, []int) {
-	return file_api_v3_galois_proto_rawDescGZIP(), []int{19}
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{21}
 }
 
 func (m *PollResponse) GetResult() isPollResponse_Result {
@@ -1150,6 +1381,195 @@ func (*PollResponse_Failed) isPollResponse_Result() {}
 
 func (*PollResponse_Done) isPollResponse_Result() {}
 
+type ConflictingHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vote          *v1.CanonicalVote   `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
+	Header        *v1.Header          `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	TrustedCommit *ValidatorSetCommit `protobuf:"bytes,3,opt,name=trusted_commit,json=trustedCommit,proto3" json:"trusted_commit,omitempty"`
+}
+
+func (x *ConflictingHeader) Reset() {
+	*x = ConflictingHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v3_galois_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConflictingHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConflictingHeader) ProtoMessage() {}
+
+func (x *ConflictingHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_galois_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConflictingHeader.ProtoReflect.Descriptor instead.
+func (*ConflictingHeader) Descriptor() ([]byte, []int) {
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ConflictingHeader) GetVote() *v1.CanonicalVote {
+	if x != nil {
+		return x.Vote
+	}
+	return nil
+}
+
+func (x *ConflictingHeader) GetHeader() *v1.Header {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *ConflictingHeader) GetTrustedCommit() *ValidatorSetCommit {
+	if x != nil {
+		return x.TrustedCommit
+	}
+	return nil
+}
+
+type ProveMisbehaviourRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header_1 *ConflictingHeader `protobuf:"bytes,1,opt,name=header_1,json=header1,proto3" json:"header_1,omitempty"`
+	Header_2 *ConflictingHeader `protobuf:"bytes,2,opt,name=header_2,json=header2,proto3" json:"header_2,omitempty"`
+}
+
+func (x *ProveMisbehaviourRequest) Reset() {
+	*x = ProveMisbehaviourRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v3_galois_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProveMisbehaviourRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProveMisbehaviourRequest) ProtoMessage() {}
+
+func (x *ProveMisbehaviourRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_galois_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProveMisbehaviourRequest.ProtoReflect.Descriptor instead.
+func (*ProveMisbehaviourRequest) Descriptor() ([]byte, []int) {
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProveMisbehaviourRequest) GetHeader_1() *ConflictingHeader {
+	if x != nil {
+		return x.Header_1
+	}
+	return nil
+}
+
+func (x *ProveMisbehaviourRequest) GetHeader_2() *ConflictingHeader {
+	if x != nil {
+		return x.Header_2
+	}
+	return nil
+}
+
+type ProveMisbehaviourResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proof                   *ZeroKnowledgeProof `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	TrustedValidatorSetRoot []byte              `protobuf:"bytes,2,opt,name=trusted_validator_set_root,json=trustedValidatorSetRoot,proto3" json:"trusted_validator_set_root,omitempty"`
+	BlockHash_1             []byte              `protobuf:"bytes,3,opt,name=block_hash_1,json=blockHash1,proto3" json:"block_hash_1,omitempty"`
+	BlockHash_2             []byte              `protobuf:"bytes,4,opt,name=block_hash_2,json=blockHash2,proto3" json:"block_hash_2,omitempty"`
+}
+
+func (x *ProveMisbehaviourResponse) Reset() {
+	*x = ProveMisbehaviourResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v3_galois_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProveMisbehaviourResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProveMisbehaviourResponse) ProtoMessage() {}
+
+func (x *ProveMisbehaviourResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v3_galois_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProveMisbehaviourResponse.ProtoReflect.Descriptor instead.
+func (*ProveMisbehaviourResponse) Descriptor() ([]byte, []int) {
+	return file_api_v3_galois_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ProveMisbehaviourResponse) GetProof() *ZeroKnowledgeProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *ProveMisbehaviourResponse) GetTrustedValidatorSetRoot() []byte {
+	if x != nil {
+		return x.TrustedValidatorSetRoot
+	}
+	return nil
+}
+
+func (x *ProveMisbehaviourResponse) GetBlockHash_1() []byte {
+	if x != nil {
+		return x.BlockHash_1
+	}
+	return nil
+}
+
+func (x *ProveMisbehaviourResponse) GetBlockHash_2() []byte {
+	if x != nil {
+		return x.BlockHash_2
+	}
+	return nil
+}
+
 var File_api_v3_galois_proto protoreflect.FileDescriptor
 
 var file_api_v3_galois_proto_rawDesc = []byte{
@@ -1161,20 +1581,26 @@ var file_api_v3_galois_proto_rawDesc = []byte{
 	0x62, 0x66, 0x74, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x61, 0x6c,
 	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x21, 0x63, 0x6f,
 	0x6d, 0x65, 0x74, 0x62, 0x66, 0x74, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x2f,
-	0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0x21, 0x0a, 0x09, 0x46, 0x72, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x22, 0x9f, 0x01, 0x0a, 0x12, 0x5a, 0x65, 0x72, 0x6f, 0x4b, 0x6e, 0x6f, 0x77, 0x6c,
-	0x65, 0x64, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65,
-	0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x11, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x76, 0x6d, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x65, 0x76, 0x6d, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12,
-	0x23, 0x0a, 0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49, 0x6e,
-	0x70, 0x75, 0x74, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x12, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x21, 0x0a, 0x09, 0x46, 0x72, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0xde, 0x01, 0x0a, 0x12, 0x5a, 0x65, 0x72, 0x6f, 0x4b, 0x6e, 0x6f, 0x77,
+	0x6c, 0x65, 0x64, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x76, 0x6d, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x65, 0x76, 0x6d, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x23, 0x0a, 0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x3d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x75, 0x6e, 0x69, 0x6f,
+	0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x54, 0x79, 0x70, 0x65, 0x22, 0x90, 0x01, 0x0a, 0x12, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
 	0x6f, 0x72, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x42, 0x0a, 0x0a, 0x76,
 	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x62, 0x66, 0x74, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
@@ -1211,138 +1637,214 @@ var file_api_v3_galois_proto_rawDesc = []byte{
 	0x73, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73,
 	0x65, 0x74, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x17, 0x74,
 	0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53,
-	0x65, 0x74, 0x52, 0x6f, 0x6f, 0x74, 0x22, 0x6f, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67,
-	0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x5a, 0x65, 0x72,
-	0x6f, 0x4b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
-	0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73,
-	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x69, 0x6e, 0x70,
-	0x75, 0x74, 0x73, 0x48, 0x61, 0x73, 0x68, 0x22, 0x26, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x69, 0x66,
-	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x22,
-	0x19, 0x0a, 0x17, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72,
-	0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x34, 0x0a, 0x18, 0x47, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x22, 0x13, 0x0a, 0x11, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xf3, 0x01, 0x0a, 0x0d, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62,
-	0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x6e, 0x62, 0x5f, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6e, 0x62, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6e,
-	0x62, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6e, 0x62, 0x53, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6e,
-	0x62, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6e, 0x62, 0x50, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6e,
-	0x62, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6e, 0x62, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
-	0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6e, 0x62, 0x5f, 0x63, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63,
-	0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6e, 0x62, 0x43,
-	0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x3b, 0x0a, 0x0f, 0x50,
-	0x72, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x13,
-	0x0a, 0x05, 0x6e, 0x62, 0x5f, 0x67, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6e,
-	0x62, 0x47, 0x31, 0x12, 0x13, 0x0a, 0x05, 0x6e, 0x62, 0x5f, 0x67, 0x32, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x04, 0x6e, 0x62, 0x47, 0x32, 0x22, 0x69, 0x0a, 0x11, 0x56, 0x65, 0x72, 0x69,
-	0x66, 0x79, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x13, 0x0a,
-	0x05, 0x6e, 0x62, 0x5f, 0x67, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6e, 0x62,
-	0x47, 0x31, 0x12, 0x13, 0x0a, 0x05, 0x6e, 0x62, 0x5f, 0x67, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x04, 0x6e, 0x62, 0x47, 0x32, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x62, 0x5f, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x5f, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0f, 0x6e, 0x62, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x57, 0x69, 0x74, 0x6e,
-	0x65, 0x73, 0x73, 0x22, 0x73, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
-	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6e, 0x62, 0x5f, 0x70, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x11, 0x6e, 0x62, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x43, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x6e, 0x62, 0x5f, 0x70, 0x72, 0x69,
-	0x76, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x6e, 0x62, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x43,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x22, 0xda, 0x02, 0x0a, 0x12, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x49, 0x0a, 0x0e, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e,
-	0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x56, 0x61,
-	0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0d, 0x76, 0x61, 0x72,
-	0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x50, 0x0a, 0x11, 0x70, 0x72,
-	0x6f, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61,
-	0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0f, 0x70, 0x72, 0x6f,
-	0x76, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x56, 0x0a, 0x13,
-	0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x69, 0x6e, 0x67, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x74,
-	0x61, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x75, 0x6e, 0x69, 0x6f,
+	0x65, 0x74, 0x52, 0x6f, 0x6f, 0x74, 0x22, 0xe6, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x67, 0x68, 0x74,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x30, 0x0a, 0x14, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x70, 0x70, 0x48, 0x61, 0x73, 0x68, 0x22,
+	0x9d, 0x01, 0x0a, 0x11, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x4b, 0x0a, 0x10, 0x75, 0x6e, 0x74, 0x72, 0x75, 0x73, 0x74,
+	0x65, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x52, 0x0f, 0x75, 0x6e, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x12, 0x3b, 0x0a, 0x1a, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x72, 0x6f, 0x6f, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x17, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x52, 0x6f, 0x6f, 0x74, 0x22,
+	0xc8, 0x01, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3d, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x5a, 0x65, 0x72, 0x6f, 0x4b, 0x6e, 0x6f, 0x77, 0x6c,
+	0x65, 0x64, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x21, 0x0a, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x4b, 0x0a, 0x0c, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x75, 0x6e, 0x69, 0x6f,
 	0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e,
-	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x52, 0x11, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x12, 0x4f, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
-	0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
-	0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x33, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
-	0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x4a, 0x0a, 0x0b, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61,
-	0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x15, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x2e, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x76,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x52, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x76,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x3e, 0x0a, 0x08,
-	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
-	0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xde, 0x01, 0x0a,
-	0x0c, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a,
-	0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
+	0x4c, 0x69, 0x67, 0x68, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x73, 0x48, 0x00, 0x52, 0x0b, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x42, 0x08, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x22, 0x26, 0x0a, 0x0e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x22, 0x19, 0x0a, 0x17, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x34, 0x0a,
+	0x18, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x22, 0x13, 0x0a, 0x11, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xf3, 0x01, 0x0a, 0x0d, 0x56, 0x61, 0x72,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x6e, 0x62,
+	0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62,
+	0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6e, 0x62, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x2e,
+	0x0a, 0x13, 0x6e, 0x62, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x76, 0x61, 0x72, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6e, 0x62, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x2e,
+	0x0a, 0x13, 0x6e, 0x62, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x76, 0x61, 0x72, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6e, 0x62, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x6e, 0x62, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6e, 0x62, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6e, 0x62, 0x5f, 0x63, 0x6f, 0x65, 0x66,
+	0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e,
+	0x6e, 0x62, 0x43, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x3b,
+	0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x13, 0x0a, 0x05, 0x6e, 0x62, 0x5f, 0x67, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x6e, 0x62, 0x47, 0x31, 0x12, 0x13, 0x0a, 0x05, 0x6e, 0x62, 0x5f, 0x67, 0x32, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6e, 0x62, 0x47, 0x32, 0x22, 0x69, 0x0a, 0x11, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x13, 0x0a, 0x05, 0x6e, 0x62, 0x5f, 0x67, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x6e, 0x62, 0x47, 0x31, 0x12, 0x13, 0x0a, 0x05, 0x6e, 0x62, 0x5f, 0x67, 0x32, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6e, 0x62, 0x47, 0x32, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x62,
+	0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x6e, 0x62, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x57,
+	0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x22, 0x73, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6e, 0x62, 0x5f,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6e, 0x62, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x6e, 0x62, 0x5f,
+	0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x6e, 0x62, 0x50, 0x72, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x22, 0xda, 0x02, 0x0a, 0x12,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x49, 0x0a, 0x0e, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x75, 0x6e, 0x69,
+	0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33,
+	0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0d,
+	0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x50, 0x0a,
+	0x11, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e,
+	0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0f,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x56, 0x0a, 0x13, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x69, 0x6e, 0x67, 0x5f, 0x6b, 0x65, 0x79,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x75,
+	0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x33, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x11, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x69, 0x6e, 0x67, 0x4b,
+	0x65, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x4f, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d,
+	0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x4a, 0x0a, 0x0b, 0x50, 0x6f, 0x6c, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e,
+	0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50,
+	0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x15, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x2e, 0x0a, 0x12, 0x50,
+	0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x52, 0x0a, 0x10, 0x50,
+	0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x6e, 0x65, 0x12,
+	0x3e, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0xde, 0x01, 0x0a, 0x0c, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x44, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x07, 0x70,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x41, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67,
+	0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f,
+	0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x48,
+	0x00, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x3b, 0x0a, 0x04, 0x64, 0x6f, 0x6e,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e,
+	0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72,
+	0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x6e, 0x65, 0x48, 0x00,
+	0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0xcc, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x04, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x62, 0x66, 0x74, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63,
+	0x61, 0x6c, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x04, 0x76, 0x6f, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63,
+	0x6f, 0x6d, 0x65, 0x74, 0x62, 0x66, 0x74, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x4e, 0x0a, 0x0e, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e,
+	0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x52, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22,
+	0xa0, 0x01, 0x0a, 0x18, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61,
+	0x76, 0x69, 0x6f, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x08,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26,
 	0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x07, 0x70, 0x65, 0x6e, 0x64,
-	0x69, 0x6e, 0x67, 0x12, 0x41, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f,
-	0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x48, 0x00, 0x52, 0x06,
-	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x3b, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c,
+	0x69, 0x2e, 0x76, 0x33, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x31, 0x12,
+	0x41, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x26, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
+	0x69, 0x6e, 0x67, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x32, 0x22, 0xdb, 0x01, 0x0a, 0x19, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x4d, 0x69, 0x73, 0x62,
+	0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x75, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3d, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x5a, 0x65, 0x72, 0x6f, 0x4b, 0x6e, 0x6f, 0x77, 0x6c, 0x65,
+	0x64, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12,
+	0x3b, 0x0a, 0x1a, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x17, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x20, 0x0a, 0x0c,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x31, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x31, 0x12, 0x20,
+	0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x32, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x32,
+	0x2a, 0x23, 0x0a, 0x09, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a,
+	0x07, 0x47, 0x52, 0x4f, 0x54, 0x48, 0x31, 0x36, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x4c,
+	0x4f, 0x4e, 0x4b, 0x10, 0x01, 0x32, 0xc4, 0x04, 0x0a, 0x0e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x50,
+	0x72, 0x6f, 0x76, 0x65, 0x72, 0x41, 0x50, 0x49, 0x12, 0x4e, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x76,
+	0x65, 0x12, 0x21, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c,
 	0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x04, 0x64,
-	0x6f, 0x6e, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x32, 0xd0, 0x03,
-	0x0a, 0x0e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x72, 0x41, 0x50, 0x49,
-	0x12, 0x4e, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x12, 0x21, 0x2e, 0x75, 0x6e, 0x69, 0x6f,
-	0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e,
-	0x50, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x75,
-	0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x51, 0x0a, 0x06, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x22, 0x2e, 0x75, 0x6e, 0x69,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x06, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x12, 0x22, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69,
+	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67,
+	0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x10, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12,
+	0x2c, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e,
+	0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x33, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x0a,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x26, 0x2e, 0x75, 0x6e, 0x69,
 	0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33,
-	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
-	0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x33, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x10, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43,
-	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x2c, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e,
-	0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x47, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61,
-	0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x47, 0x65, 0x6e, 0x65,
-	0x72, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x12, 0x26, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69,
+	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x27, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69,
 	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x75, 0x6e, 0x69,
-	0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33,
-	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x04, 0x50, 0x6f, 0x6c, 0x6c, 0x12, 0x20, 0x2e, 0x75, 0x6e,
-	0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
-	0x33, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x04, 0x50,
+	0x6f, 0x6c, 0x6c, 0x12, 0x20, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f,
+	0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61,
+	0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x33, 0x2e, 0x50, 0x6f, 0x6c, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x11, 0x50, 0x72, 0x6f, 0x76,
+	0x65, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x75, 0x72, 0x12, 0x2d, 0x2e,
 	0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x76, 0x33, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x42, 0x17, 0x5a, 0x15, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2f, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73,
-	0x2f, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x2e, 0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61,
+	0x76, 0x69, 0x6f, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x75,
+	0x6e, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x33, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76,
+	0x69, 0x6f, 0x75, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x17, 0x5a, 0x15,
+	0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x2f, 0x67, 0x61, 0x6c, 0x6f, 0x69, 0x73, 0x2f, 0x72, 0x70, 0x63,
+	0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1357,64 +1859,84 @@ func file_api_v3_galois_proto_rawDescGZIP() []byte {
 	return file_api_v3_galois_proto_rawDescData
 }
 
-var file_api_v3_galois_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_api_v3_galois_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_v3_galois_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_api_v3_galois_proto_goTypes = []interface{}{
-	(*FrElement)(nil),                // 0: union.galois.api.v3.FrElement
-	(*ZeroKnowledgeProof)(nil),       // 1: union.galois.api.v3.ZeroKnowledgeProof
-	(*ValidatorSetCommit)(nil),       // 2: union.galois.api.v3.ValidatorSetCommit
-	(*ProveRequest)(nil),             // 3: union.galois.api.v3.ProveRequest
-	(*ProveResponse)(nil),            // 4: union.galois.api.v3.ProveResponse
-	(*VerifyRequest)(nil),            // 5: union.galois.api.v3.VerifyRequest
-	(*VerifyResponse)(nil),           // 6: union.galois.api.v3.VerifyResponse
-	(*GenerateContractRequest)(nil),  // 7: union.galois.api.v3.GenerateContractRequest
-	(*GenerateContractResponse)(nil), // 8: union.galois.api.v3.GenerateContractResponse
-	(*QueryStatsRequest)(nil),        // 9: union.galois.api.v3.QueryStatsRequest
-	(*VariableStats)(nil),            // 10: union.galois.api.v3.VariableStats
-	(*ProvingKeyStats)(nil),          // 11: union.galois.api.v3.ProvingKeyStats
-	(*VerifyingKeyStats)(nil),        // 12: union.galois.api.v3.VerifyingKeyStats
-	(*CommitmentStats)(nil),          // 13: union.galois.api.v3.CommitmentStats
-	(*QueryStatsResponse)(nil),       // 14: union.galois.api.v3.QueryStatsResponse
-	(*PollRequest)(nil),              // 15: union.galois.api.v3.PollRequest
-	(*ProveRequestPending)(nil),      // 16: union.galois.api.v3.ProveRequestPending
-	(*ProveRequestFailed)(nil),       // 17: union.galois.api.v3.ProveRequestFailed
-	(*ProveRequestDone)(nil),         // 18: union.galois.api.v3.ProveRequestDone
-	(*PollResponse)(nil),             // 19: union.galois.api.v3.PollResponse
-	(*v1.SimpleValidator)(nil),       // 20: cometbft.types.v1.SimpleValidator
-	(*v1.CanonicalVote)(nil),         // 21: cometbft.types.v1.CanonicalVote
-	(*v1.Header)(nil),                // 22: cometbft.types.v1.Header
+	(ProofType)(0),                    // 0: union.galois.api.v3.ProofType
+	(*FrElement)(nil),                 // 1: union.galois.api.v3.FrElement
+	(*ZeroKnowledgeProof)(nil),        // 2: union.galois.api.v3.ZeroKnowledgeProof
+	(*ValidatorSetCommit)(nil),        // 3: union.galois.api.v3.ValidatorSetCommit
+	(*ProveRequest)(nil),              // 4: union.galois.api.v3.ProveRequest
+	(*ProveResponse)(nil),             // 5: union.galois.api.v3.ProveResponse
+	(*LightHeader)(nil),               // 6: union.galois.api.v3.LightHeader
+	(*LightHeaderInputs)(nil),         // 7: union.galois.api.v3.LightHeaderInputs
+	(*VerifyRequest)(nil),             // 8: union.galois.api.v3.VerifyRequest
+	(*VerifyResponse)(nil),            // 9: union.galois.api.v3.VerifyResponse
+	(*GenerateContractRequest)(nil),   // 10: union.galois.api.v3.GenerateContractRequest
+	(*GenerateContractResponse)(nil),  // 11: union.galois.api.v3.GenerateContractResponse
+	(*QueryStatsRequest)(nil),         // 12: union.galois.api.v3.QueryStatsRequest
+	(*VariableStats)(nil),             // 13: union.galois.api.v3.VariableStats
+	(*ProvingKeyStats)(nil),           // 14: union.galois.api.v3.ProvingKeyStats
+	(*VerifyingKeyStats)(nil),         // 15: union.galois.api.v3.VerifyingKeyStats
+	(*CommitmentStats)(nil),           // 16: union.galois.api.v3.CommitmentStats
+	(*QueryStatsResponse)(nil),        // 17: union.galois.api.v3.QueryStatsResponse
+	(*PollRequest)(nil),               // 18: union.galois.api.v3.PollRequest
+	(*ProveRequestPending)(nil),       // 19: union.galois.api.v3.ProveRequestPending
+	(*ProveRequestFailed)(nil),        // 20: union.galois.api.v3.ProveRequestFailed
+	(*ProveRequestDone)(nil),          // 21: union.galois.api.v3.ProveRequestDone
+	(*PollResponse)(nil),              // 22: union.galois.api.v3.PollResponse
+	(*ConflictingHeader)(nil),         // 23: union.galois.api.v3.ConflictingHeader
+	(*ProveMisbehaviourRequest)(nil),  // 24: union.galois.api.v3.ProveMisbehaviourRequest
+	(*ProveMisbehaviourResponse)(nil), // 25: union.galois.api.v3.ProveMisbehaviourResponse
+	(*v1.SimpleValidator)(nil),        // 26: cometbft.types.v1.SimpleValidator
+	(*v1.CanonicalVote)(nil),          // 27: cometbft.types.v1.CanonicalVote
+	(*v1.Header)(nil),                 // 28: cometbft.types.v1.Header
+	(*timestamppb.Timestamp)(nil),     // 29: google.protobuf.Timestamp
 }
 var file_api_v3_galois_proto_depIdxs = []int32{
-	20, // 0: union.galois.api.v3.ValidatorSetCommit.validators:type_name -> cometbft.types.v1.SimpleValidator
-	21, // 1: union.galois.api.v3.ProveRequest.vote:type_name -> cometbft.types.v1.CanonicalVote
-	22, // 2: union.galois.api.v3.ProveRequest.untrusted_header:type_name -> cometbft.types.v1.Header
-	2,  // 3: union.galois.api.v3.ProveRequest.trusted_commit:type_name -> union.galois.api.v3.ValidatorSetCommit
-	2,  // 4: union.galois.api.v3.ProveRequest.untrusted_commit:type_name -> union.galois.api.v3.ValidatorSetCommit
-	1,  // 5: union.galois.api.v3.ProveResponse.proof:type_name -> union.galois.api.v3.ZeroKnowledgeProof
-	1,  // 6: union.galois.api.v3.VerifyRequest.proof:type_name -> union.galois.api.v3.ZeroKnowledgeProof
-	10, // 7: union.galois.api.v3.QueryStatsResponse.variable_stats:type_name -> union.galois.api.v3.VariableStats
-	11, // 8: union.galois.api.v3.QueryStatsResponse.proving_key_stats:type_name -> union.galois.api.v3.ProvingKeyStats
-	12, // 9: union.galois.api.v3.QueryStatsResponse.verifying_key_stats:type_name -> union.galois.api.v3.VerifyingKeyStats
-	13, // 10: union.galois.api.v3.QueryStatsResponse.commitment_stats:type_name -> union.galois.api.v3.CommitmentStats
-	3,  // 11: union.galois.api.v3.PollRequest.request:type_name -> union.galois.api.v3.ProveRequest
-	4,  // 12: union.galois.api.v3.ProveRequestDone.response:type_name -> union.galois.api.v3.ProveResponse
-	16, // 13: union.galois.api.v3.PollResponse.pending:type_name -> union.galois.api.v3.ProveRequestPending
-	17, // 14: union.galois.api.v3.PollResponse.failed:type_name -> union.galois.api.v3.ProveRequestFailed
-	18, // 15: union.galois.api.v3.PollResponse.done:type_name -> union.galois.api.v3.ProveRequestDone
-	3,  // 16: union.galois.api.v3.UnionProverAPI.Prove:input_type -> union.galois.api.v3.ProveRequest
-	5,  // 17: union.galois.api.v3.UnionProverAPI.Verify:input_type -> union.galois.api.v3.VerifyRequest
-	7,  // 18: union.galois.api.v3.UnionProverAPI.GenerateContract:input_type -> union.galois.api.v3.GenerateContractRequest
-	9,  // 19: union.galois.api.v3.UnionProverAPI.QueryStats:input_type -> union.galois.api.v3.QueryStatsRequest
-	15, // 20: union.galois.api.v3.UnionProverAPI.Poll:input_type -> union.galois.api.v3.PollRequest
-	4,  // 21: union.galois.api.v3.UnionProverAPI.Prove:output_type -> union.galois.api.v3.ProveResponse
-	6,  // 22: union.galois.api.v3.UnionProverAPI.Verify:output_type -> union.galois.api.v3.VerifyResponse
-	8,  // 23: union.galois.api.v3.UnionProverAPI.GenerateContract:output_type -> union.galois.api.v3.GenerateContractResponse
-	14, // 24: union.galois.api.v3.UnionProverAPI.QueryStats:output_type -> union.galois.api.v3.QueryStatsResponse
-	19, // 25: union.galois.api.v3.UnionProverAPI.Poll:output_type -> union.galois.api.v3.PollResponse
-	21, // [21:26] is the sub-list for method output_type
-	16, // [16:21] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	0,  // 0: union.galois.api.v3.ZeroKnowledgeProof.proof_type:type_name -> union.galois.api.v3.ProofType
+	26, // 1: union.galois.api.v3.ValidatorSetCommit.validators:type_name -> cometbft.types.v1.SimpleValidator
+	27, // 2: union.galois.api.v3.ProveRequest.vote:type_name -> cometbft.types.v1.CanonicalVote
+	28, // 3: union.galois.api.v3.ProveRequest.untrusted_header:type_name -> cometbft.types.v1.Header
+	3,  // 4: union.galois.api.v3.ProveRequest.trusted_commit:type_name -> union.galois.api.v3.ValidatorSetCommit
+	3,  // 5: union.galois.api.v3.ProveRequest.untrusted_commit:type_name -> union.galois.api.v3.ValidatorSetCommit
+	2,  // 6: union.galois.api.v3.ProveResponse.proof:type_name -> union.galois.api.v3.ZeroKnowledgeProof
+	29, // 7: union.galois.api.v3.LightHeader.time:type_name -> google.protobuf.Timestamp
+	6,  // 8: union.galois.api.v3.LightHeaderInputs.untrusted_header:type_name -> union.galois.api.v3.LightHeader
+	2,  // 9: union.galois.api.v3.VerifyRequest.proof:type_name -> union.galois.api.v3.ZeroKnowledgeProof
+	7,  // 10: union.galois.api.v3.VerifyRequest.light_header:type_name -> union.galois.api.v3.LightHeaderInputs
+	13, // 11: union.galois.api.v3.QueryStatsResponse.variable_stats:type_name -> union.galois.api.v3.VariableStats
+	14, // 12: union.galois.api.v3.QueryStatsResponse.proving_key_stats:type_name -> union.galois.api.v3.ProvingKeyStats
+	15, // 13: union.galois.api.v3.QueryStatsResponse.verifying_key_stats:type_name -> union.galois.api.v3.VerifyingKeyStats
+	16, // 14: union.galois.api.v3.QueryStatsResponse.commitment_stats:type_name -> union.galois.api.v3.CommitmentStats
+	4,  // 15: union.galois.api.v3.PollRequest.request:type_name -> union.galois.api.v3.ProveRequest
+	5,  // 16: union.galois.api.v3.ProveRequestDone.response:type_name -> union.galois.api.v3.ProveResponse
+	19, // 17: union.galois.api.v3.PollResponse.pending:type_name -> union.galois.api.v3.ProveRequestPending
+	20, // 18: union.galois.api.v3.PollResponse.failed:type_name -> union.galois.api.v3.ProveRequestFailed
+	21, // 19: union.galois.api.v3.PollResponse.done:type_name -> union.galois.api.v3.ProveRequestDone
+	27, // 20: union.galois.api.v3.ConflictingHeader.vote:type_name -> cometbft.types.v1.CanonicalVote
+	28, // 21: union.galois.api.v3.ConflictingHeader.header:type_name -> cometbft.types.v1.Header
+	3,  // 22: union.galois.api.v3.ConflictingHeader.trusted_commit:type_name -> union.galois.api.v3.ValidatorSetCommit
+	23, // 23: union.galois.api.v3.ProveMisbehaviourRequest.header_1:type_name -> union.galois.api.v3.ConflictingHeader
+	23, // 24: union.galois.api.v3.ProveMisbehaviourRequest.header_2:type_name -> union.galois.api.v3.ConflictingHeader
+	2,  // 25: union.galois.api.v3.ProveMisbehaviourResponse.proof:type_name -> union.galois.api.v3.ZeroKnowledgeProof
+	4,  // 26: union.galois.api.v3.UnionProverAPI.Prove:input_type -> union.galois.api.v3.ProveRequest
+	8,  // 27: union.galois.api.v3.UnionProverAPI.Verify:input_type -> union.galois.api.v3.VerifyRequest
+	10, // 28: union.galois.api.v3.UnionProverAPI.GenerateContract:input_type -> union.galois.api.v3.GenerateContractRequest
+	12, // 29: union.galois.api.v3.UnionProverAPI.QueryStats:input_type -> union.galois.api.v3.QueryStatsRequest
+	18, // 30: union.galois.api.v3.UnionProverAPI.Poll:input_type -> union.galois.api.v3.PollRequest
+	24, // 31: union.galois.api.v3.UnionProverAPI.ProveMisbehaviour:input_type -> union.galois.api.v3.ProveMisbehaviourRequest
+	5,  // 32: union.galois.api.v3.UnionProverAPI.Prove:output_type -> union.galois.api.v3.ProveResponse
+	9,  // 33: union.galois.api.v3.UnionProverAPI.Verify:output_type -> union.galois.api.v3.VerifyResponse
+	11, // 34: union.galois.api.v3.UnionProverAPI.GenerateContract:output_type -> union.galois.api.v3.GenerateContractResponse
+	17, // 35: union.galois.api.v3.UnionProverAPI.QueryStats:output_type -> union.galois.api.v3.QueryStatsResponse
+	22, // 36: union.galois.api.v3.UnionProverAPI.Poll:output_type -> union.galois.api.v3.PollResponse
+	25, // 37: union.galois.api.v3.UnionProverAPI.ProveMisbehaviour:output_type -> union.galois.api.v3.ProveMisbehaviourResponse
+	32, // [32:38] is the sub-list for method output_type
+	26, // [26:32] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_api_v3_galois_proto_init() }
@@ -1484,7 +2006,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyRequest); i {
+			switch v := v.(*LightHeader); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1496,7 +2018,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyResponse); i {
+			switch v := v.(*LightHeaderInputs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1508,7 +2030,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenerateContractRequest); i {
+			switch v := v.(*VerifyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1520,7 +2042,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GenerateContractResponse); i {
+			switch v := v.(*VerifyResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1532,7 +2054,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryStatsRequest); i {
+			switch v := v.(*GenerateContractRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1544,7 +2066,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VariableStats); i {
+			switch v := v.(*GenerateContractResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1556,7 +2078,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProvingKeyStats); i {
+			switch v := v.(*QueryStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1568,7 +2090,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyingKeyStats); i {
+			switch v := v.(*VariableStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1580,7 +2102,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommitmentStats); i {
+			switch v := v.(*ProvingKeyStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1592,7 +2114,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryStatsResponse); i {
+			switch v := v.(*VerifyingKeyStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1604,7 +2126,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PollRequest); i {
+			switch v := v.(*CommitmentStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1616,7 +2138,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProveRequestPending); i {
+			switch v := v.(*QueryStatsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1628,7 +2150,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProveRequestFailed); i {
+			switch v := v.(*PollRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1640,7 +2162,7 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProveRequestDone); i {
+			switch v := v.(*ProveRequestPending); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1652,6 +2174,30 @@ func file_api_v3_galois_proto_init() {
 			}
 		}
 		file_api_v3_galois_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProveRequestFailed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v3_galois_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProveRequestDone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v3_galois_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PollResponse); i {
 			case 0:
 				return &v.state
@@ -1663,8 +2209,48 @@ func file_api_v3_galois_proto_init() {
 				return nil
 			}
 		}
+		file_api_v3_galois_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConflictingHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v3_galois_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProveMisbehaviourRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v3_galois_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProveMisbehaviourResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_api_v3_galois_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*VerifyRequest_InputsHash)(nil),
+		(*VerifyRequest_LightHeader)(nil),
 	}
-	file_api_v3_galois_proto_msgTypes[19].OneofWrappers = []interface{}{
+	file_api_v3_galois_proto_msgTypes[21].OneofWrappers = []interface{}{
 		(*PollResponse_Pending)(nil),
 		(*PollResponse_Failed)(nil),
 		(*PollResponse_Done)(nil),
@@ -1674,13 +2260,14 @@ func file_api_v3_galois_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_v3_galois_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   20,
+			NumEnums:      1,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v3_galois_proto_goTypes,
 		DependencyIndexes: file_api_v3_galois_proto_depIdxs,
+		EnumInfos:         file_api_v3_galois_proto_enumTypes,
 		MessageInfos:      file_api_v3_galois_proto_msgTypes,
 	}.Build()
 	File_api_v3_galois_proto = out.File
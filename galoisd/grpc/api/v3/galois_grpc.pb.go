@@ -19,11 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	UnionProverAPI_Prove_FullMethodName            = "/union.galois.api.v3.UnionProverAPI/Prove"
-	UnionProverAPI_Verify_FullMethodName           = "/union.galois.api.v3.UnionProverAPI/Verify"
-	UnionProverAPI_GenerateContract_FullMethodName = "/union.galois.api.v3.UnionProverAPI/GenerateContract"
-	UnionProverAPI_QueryStats_FullMethodName       = "/union.galois.api.v3.UnionProverAPI/QueryStats"
-	UnionProverAPI_Poll_FullMethodName             = "/union.galois.api.v3.UnionProverAPI/Poll"
+	UnionProverAPI_Prove_FullMethodName             = "/union.galois.api.v3.UnionProverAPI/Prove"
+	UnionProverAPI_Verify_FullMethodName            = "/union.galois.api.v3.UnionProverAPI/Verify"
+	UnionProverAPI_GenerateContract_FullMethodName  = "/union.galois.api.v3.UnionProverAPI/GenerateContract"
+	UnionProverAPI_QueryStats_FullMethodName        = "/union.galois.api.v3.UnionProverAPI/QueryStats"
+	UnionProverAPI_Poll_FullMethodName              = "/union.galois.api.v3.UnionProverAPI/Poll"
+	UnionProverAPI_ProveMisbehaviour_FullMethodName = "/union.galois.api.v3.UnionProverAPI/ProveMisbehaviour"
 )
 
 // UnionProverAPIClient is the client API for UnionProverAPI service.
@@ -35,6 +36,7 @@ type UnionProverAPIClient interface {
 	GenerateContract(ctx context.Context, in *GenerateContractRequest, opts ...grpc.CallOption) (*GenerateContractResponse, error)
 	QueryStats(ctx context.Context, in *QueryStatsRequest, opts ...grpc.CallOption) (*QueryStatsResponse, error)
 	Poll(ctx context.Context, in *PollRequest, opts ...grpc.CallOption) (*PollResponse, error)
+	ProveMisbehaviour(ctx context.Context, in *ProveMisbehaviourRequest, opts ...grpc.CallOption) (*ProveMisbehaviourResponse, error)
 }
 
 type unionProverAPIClient struct {
@@ -90,6 +92,15 @@ func (c *unionProverAPIClient) Poll(ctx context.Context, in *PollRequest, opts .
 	return out, nil
 }
 
+func (c *unionProverAPIClient) ProveMisbehaviour(ctx context.Context, in *ProveMisbehaviourRequest, opts ...grpc.CallOption) (*ProveMisbehaviourResponse, error) {
+	out := new(ProveMisbehaviourResponse)
+	err := c.cc.Invoke(ctx, UnionProverAPI_ProveMisbehaviour_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UnionProverAPIServer is the server API for UnionProverAPI service.
 // All implementations must embed UnimplementedUnionProverAPIServer
 // for forward compatibility
@@ -99,6 +110,7 @@ type UnionProverAPIServer interface {
 	GenerateContract(context.Context, *GenerateContractRequest) (*GenerateContractResponse, error)
 	QueryStats(context.Context, *QueryStatsRequest) (*QueryStatsResponse, error)
 	Poll(context.Context, *PollRequest) (*PollResponse, error)
+	ProveMisbehaviour(context.Context, *ProveMisbehaviourRequest) (*ProveMisbehaviourResponse, error)
 	mustEmbedUnimplementedUnionProverAPIServer()
 }
 
@@ -121,6 +133,9 @@ func (UnimplementedUnionProverAPIServer) QueryStats(context.Context, *QueryStats
 func (UnimplementedUnionProverAPIServer) Poll(context.Context, *PollRequest) (*PollResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Poll not implemented")
 }
+func (UnimplementedUnionProverAPIServer) ProveMisbehaviour(context.Context, *ProveMisbehaviourRequest) (*ProveMisbehaviourResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProveMisbehaviour not implemented")
+}
 func (UnimplementedUnionProverAPIServer) mustEmbedUnimplementedUnionProverAPIServer() {}
 
 // UnsafeUnionProverAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -224,6 +239,24 @@ func _UnionProverAPI_Poll_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UnionProverAPI_ProveMisbehaviour_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProveMisbehaviourRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnionProverAPIServer).ProveMisbehaviour(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnionProverAPI_ProveMisbehaviour_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnionProverAPIServer).ProveMisbehaviour(ctx, req.(*ProveMisbehaviourRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UnionProverAPI_ServiceDesc is the grpc.ServiceDesc for UnionProverAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,6 +284,10 @@ var UnionProverAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Poll",
 			Handler:    _UnionProverAPI_Poll_Handler,
 		},
+		{
+			MethodName: "ProveMisbehaviour",
+			Handler:    _UnionProverAPI_ProveMisbehaviour_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v3/galois.proto",
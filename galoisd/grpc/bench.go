@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"fmt"
+	grpc "galois/grpc/api/v3"
+	lcgadget "galois/pkg/lightclient/nonadjacent"
+	"os"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/profile"
+	pprof "github.com/google/pprof/profile"
+)
+
+// Timings of a single prove/verify round trip
+type BenchRun struct {
+	NbOfValidators int     `json:"nb_of_validators"`
+	NbOfSignatures int     `json:"nb_of_signatures"`
+	WitnessSeconds float64 `json:"witness_seconds"`
+	ProveSeconds   float64 `json:"prove_seconds"`
+	VerifySeconds  float64 `json:"verify_seconds"`
+	ProofBytes     int     `json:"proof_bytes"`
+	// Peak resident set size of the process so far, hence monotonic across runs
+	PeakRSSBytes int64 `json:"peak_rss_bytes"`
+}
+
+// Peak resident set size of the current process
+func PeakRSS() int64 {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0
+	}
+	// Reported in bytes on darwin, kilobytes elsewhere
+	if runtime.GOOS == "darwin" {
+		return usage.Maxrss
+	}
+	return usage.Maxrss * 1024
+}
+
+func (p *proverServer) Stats() *grpc.QueryStatsResponse {
+	return p.bundle.stats()
+}
+
+// Prove and verify the request with the loaded bundle, bypassing the job queue.
+func (p *proverServer) Bench(req *grpc.ProveRequest) (*BenchRun, error) {
+	if err := validateCommit(req.TrustedCommit); err != nil {
+		return nil, err
+	}
+	if err := validateCommit(req.UntrustedCommit); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	witness, _, err := nonAdjacentWitness(req)
+	if err != nil {
+		return nil, err
+	}
+	witnessTime := time.Since(start)
+
+	start = time.Now()
+	proof, err := p.bundle.prove(witness)
+	if err != nil {
+		return nil, err
+	}
+	proveTime := time.Since(start)
+
+	start = time.Now()
+	err = p.bundle.verify(proof.CompressedContent, &lcgadget.Circuit{
+		InputsHash: witness.InputsHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not verify the proof %s", err)
+	}
+	verifyTime := time.Since(start)
+
+	return &BenchRun{
+		NbOfValidators: len(req.UntrustedCommit.Validators),
+		NbOfSignatures: len(req.UntrustedCommit.Signatures),
+		WitnessSeconds: witnessTime.Seconds(),
+		ProveSeconds:   proveTime.Seconds(),
+		VerifySeconds:  verifyTime.Seconds(),
+		ProofBytes:     len(proof.CompressedContent),
+		PeakRSSBytes:   PeakRSS(),
+	}, nil
+}
+
+// Gadgets the constraints are attributed to, the first gadget found in the
+// call stack of a constraint wins. Note that gnark only records the 20
+// innermost frames, deeply nested constraints may end up in "other".
+var gadgets = []struct {
+	name     string
+	patterns []string
+}{
+	{"sha256_inputs_hash", []string{"BlockHeaderAPI).InputsHash", "BlockHeaderAPI).VerifyInputs"}},
+	{"hash_to_g2", []string{"galois/pkg/emulated."}},
+	{"merkle", []string{"galois/pkg/merkle."}},
+	{"bls", []string{"galois/pkg/bls."}},
+	{"hash", []string{"std/hash/mimc.", "galois/pkg/poseidon2."}},
+	{"range_check", []string{"std/rangecheck.", "std/math/cmp."}},
+}
+
+// Number of constraints per gadget along with the total
+type ConstraintProfile struct {
+	Path          string         `json:"path"`
+	NbConstraints int            `json:"nb_constraints"`
+	Gadgets       map[string]int `json:"gadgets"`
+}
+
+func attributeGadget(sample *pprof.Sample) string {
+	for _, gadget := range gadgets {
+		for _, location := range sample.Location {
+			for _, line := range location.Line {
+				if line.Function == nil {
+					continue
+				}
+				for _, pattern := range gadget.patterns {
+					if strings.Contains(line.Function.SystemName, pattern) {
+						return gadget.name
+					}
+				}
+			}
+		}
+	}
+	return "other"
+}
+
+// Compile the circuit for the given backend while recording a gnark
+// constraint profile (pprof) at path, and summarize it per gadget.
+func ProfileConstraints(b Backend, circuit frontend.Circuit, path string) (*ConstraintProfile, error) {
+	p := profile.Start(profile.WithPath(path))
+	_, err := compile(b, circuit)
+	p.Stop()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	parsed, err := pprof.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse the constraint profile %s", err)
+	}
+
+	summary := &ConstraintProfile{
+		Path:    path,
+		Gadgets: make(map[string]int),
+	}
+	for _, sample := range parsed.Sample {
+		count := int(sample.Value[0])
+		summary.NbConstraints += count
+		summary.Gadgets[attributeGadget(sample)] += count
+	}
+	return summary, nil
+}
@@ -0,0 +1,478 @@
+package grpc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	grpc "galois/grpc/api/v3"
+	"io"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	backend_opts "github.com/consensys/gnark/backend"
+	backend "github.com/consensys/gnark/backend/groth16"
+	backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	plonk "github.com/consensys/gnark/backend/plonk"
+	plonk_bn254 "github.com/consensys/gnark/backend/plonk/bn254"
+	"github.com/consensys/gnark/constraint"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Proving system used by a circuit bundle
+type Backend string
+
+const (
+	// Requires a circuit specific trusted setup (phase 2)
+	BackendGroth16 Backend = "groth16"
+	// Only requires a universal KZG SRS
+	BackendPlonk Backend = "plonk"
+)
+
+// Location of a circuit bundle, i.e. the compiled circuit along with its
+// proving and verifying keys. Missing bundles are compiled and setup on load.
+type BundleConfig struct {
+	Backend Backend
+	CsPath  string
+	PkPath  string
+	VkPath  string
+	// Universal KZG SRS, only required to setup a missing PLONK bundle
+	SrsPath string
+}
+
+type circuitBundle interface {
+	// Prove the given assignment and serialize the proof in all the formats
+	// expected by the counterparties.
+	prove(assignment frontend.Circuit) (*grpc.ZeroKnowledgeProof, error)
+	// Verify a compressed proof against the public part of the assignment.
+	verify(compressedProof []byte, publicAssignment frontend.Circuit) error
+	exportSolidity(w io.Writer) error
+	stats() *grpc.QueryStatsResponse
+}
+
+func loadOrCreate(config BundleConfig, circuit frontend.Circuit) (circuitBundle, error) {
+	switch config.Backend {
+	case BackendGroth16:
+		return loadOrCreateGroth16(config, circuit)
+	case BackendPlonk:
+		return loadOrCreatePlonk(config, circuit)
+	default:
+		return nil, fmt.Errorf("Unknown backend: %s", config.Backend)
+	}
+}
+
+func bundleExists(config BundleConfig) bool {
+	for _, path := range []string{config.CsPath, config.PkPath, config.VkPath} {
+		if _, err := os.Stat(path); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+type groth16Bundle struct {
+	cs cs_bn254.R1CS
+	pk backend_bn254.ProvingKey
+	vk backend_bn254.VerifyingKey
+}
+
+func (b *groth16Bundle) prove(assignment frontend.Circuit) (*grpc.ZeroKnowledgeProof, error) {
+	privateWitness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, fmt.Errorf("Could not create witness %s", err)
+	}
+
+	proof, err := backend.Prove(constraint.R1CS(&b.cs), backend.ProvingKey(&b.pk), privateWitness, backend_opts.WithProverHashToFieldFunction(&cometblsHashToField{}))
+	if err != nil {
+		return nil, fmt.Errorf("Prover failed with %s", err)
+	}
+
+	publicWitness, err := privateWitness.Public()
+	if err != nil {
+		return nil, fmt.Errorf("Could not extract public inputs from witness %s", err)
+	}
+
+	var proofCommitment []byte
+	var commitmentPOK []byte
+	switch _proof := proof.(type) {
+	case *backend_bn254.Proof:
+		if len(b.vk.PublicAndCommitmentCommitted) != 1 {
+			return nil, fmt.Errorf("Expected a single proof commitment, got: %d", len(b.vk.PublicAndCommitmentCommitted))
+		}
+		proofCommitment = _proof.Commitments[0].Marshal()
+		commitmentPOK = _proof.CommitmentPok.Marshal()
+		break
+	default:
+		return nil, fmt.Errorf("Impossible: proof backend must be BN254 at this point")
+	}
+
+	publicInputs, err := publicWitness.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal public witness %s", err)
+	}
+
+	var proofBuffer bytes.Buffer
+	mem := bufio.NewWriter(&proofBuffer)
+	_, err = proof.WriteRawTo(mem)
+	if err != nil {
+		return nil, err
+	}
+	mem.Flush()
+	proofBz := proofBuffer.Bytes()
+
+	var compressedProofBuffer bytes.Buffer
+	mem = bufio.NewWriter(&compressedProofBuffer)
+	_, err = proof.WriteTo(mem)
+	if err != nil {
+		return nil, err
+	}
+	mem.Flush()
+	compressedProofBz := compressedProofBuffer.Bytes()
+
+	// Due to how gnark proves, we not only need the ZKP A/B/C points, but also a commitment hash and proof commitment.
+	// The proof is an uncompressed proof serialized by gnark, we extract A(G1)/B(G2)/C(G1) and then append the commitment and its POK.
+	// The EVM verifier has been extended to support this two extra public inputs.
+	evmProof := append(append(proofBz[:256], proofCommitment...), commitmentPOK...)
+
+	return &grpc.ZeroKnowledgeProof{
+		Content:           proofBz,
+		CompressedContent: compressedProofBz,
+		PublicInputs:      publicInputs,
+		EvmProof:          evmProof,
+		ProofType:         grpc.ProofType_GROTH16,
+	}, nil
+}
+
+func (b *groth16Bundle) verify(compressedProof []byte, publicAssignment frontend.Circuit) error {
+	var proof backend_bn254.Proof
+	_, err := proof.ReadFrom(bytes.NewReader(compressedProof))
+	if err != nil {
+		return fmt.Errorf("Failed to read compressed proof: %w", err)
+	}
+
+	publicWitness, err := frontend.NewWitness(publicAssignment, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		return fmt.Errorf("Unable to create public witness: %w", err)
+	}
+
+	return backend.Verify(
+		backend.Proof(&proof),
+		backend.VerifyingKey(&b.vk),
+		publicWitness,
+		backend_opts.WithVerifierHashToFieldFunction(&cometblsHashToField{}),
+	)
+}
+
+func (b *groth16Bundle) exportSolidity(w io.Writer) error {
+	return b.vk.ExportSolidity(w)
+}
+
+func (b *groth16Bundle) stats() *grpc.QueryStatsResponse {
+	return &grpc.QueryStatsResponse{
+		VariableStats: &grpc.VariableStats{
+			NbInternalVariables: uint32(b.cs.GetNbInternalVariables()),
+			NbSecretVariables:   uint32(b.cs.GetNbSecretVariables()),
+			NbPublicVariables:   uint32(b.cs.GetNbPublicVariables()),
+			NbConstraints:       uint32(b.cs.GetNbConstraints()),
+			NbCoefficients:      uint32(b.cs.GetNbCoefficients()),
+		},
+		ProvingKeyStats: &grpc.ProvingKeyStats{
+			NbG1: uint32(b.pk.NbG1()),
+			NbG2: uint32(b.pk.NbG2()),
+		},
+		VerifyingKeyStats: &grpc.VerifyingKeyStats{
+			NbG1:            uint32(b.vk.NbG1()),
+			NbG2:            uint32(b.vk.NbG2()),
+			NbPublicWitness: uint32(b.vk.NbPublicWitness()),
+		},
+		// Deprecated
+		CommitmentStats: &grpc.CommitmentStats{
+			NbPublicCommitted:  uint32(0),
+			NbPrivateCommitted: uint32(0),
+		},
+	}
+}
+
+func (b *groth16Bundle) logVerifyingKey() error {
+	var commitmentKeyBytes bytes.Buffer
+	mem := bufio.NewWriter(&commitmentKeyBytes)
+	_, err := b.vk.CommitmentKey.WriteRawTo(mem)
+	if err != nil {
+		return err
+	}
+	mem.Flush()
+	commitmentKey := commitmentKeyBytes.Bytes()
+
+	log.Debug().
+		Str("alpha", b.vk.G1.Alpha.String()).
+		Str("beta", b.vk.G1.Beta.String()).
+		Str("gamma", b.vk.G2.Gamma.String()).
+		Str("delta", b.vk.G2.Delta.String()).
+		Hex("pedersen", commitmentKey).
+		Msg("verifying_key")
+
+	return nil
+}
+
+func loadOrCreateGroth16(config BundleConfig, circuit frontend.Circuit) (*groth16Bundle, error) {
+	b := &groth16Bundle{}
+
+	if bundleExists(config) {
+		log.Info().Msg("Loading circuit...")
+
+		log.Debug().Msg("Loading R1CS...")
+		err := readFrom(config.CsPath, constraint.R1CS(&b.cs))
+		if err != nil {
+			return nil, err
+		}
+
+		log.Debug().Msg("Loading proving key...")
+		err = readFrom(config.PkPath, backend.ProvingKey(&b.pk))
+		if err != nil {
+			return nil, err
+		}
+
+		log.Debug().Msg("Loading verifying key...")
+		err = readFrom(config.VkPath, backend.VerifyingKey(&b.vk))
+		if err != nil {
+			return nil, err
+		}
+
+		return b, b.logVerifyingKey()
+	}
+
+	log.Info().Msg("Compiling circuit...")
+	r1csInstance, err := compile(BackendGroth16, circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	b.cs = *r1csInstance.(*cs_bn254.R1CS)
+
+	log.Debug().Msg("Setup PK/VK")
+	err = backend_bn254.Setup(&b.cs, &b.pk, &b.vk)
+	if err != nil {
+		return nil, err
+	}
+
+	err = saveTo(config.CsPath, r1csInstance)
+	if err != nil {
+		return nil, err
+	}
+	err = saveTo(config.PkPath, backend.ProvingKey(&b.pk))
+	if err != nil {
+		return nil, err
+	}
+	err = saveTo(config.VkPath, backend.VerifyingKey(&b.vk))
+	if err != nil {
+		return nil, err
+	}
+
+	return b, b.logVerifyingKey()
+}
+
+// The PLONK bundle sticks to the gnark hash to field for the BSB22
+// commitments, which is the one implemented by the exported Solidity verifier.
+type plonkBundle struct {
+	cs cs_bn254.SparseR1CS
+	pk plonk_bn254.ProvingKey
+	vk plonk_bn254.VerifyingKey
+}
+
+func (b *plonkBundle) prove(assignment frontend.Circuit) (*grpc.ZeroKnowledgeProof, error) {
+	privateWitness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, fmt.Errorf("Could not create witness %s", err)
+	}
+
+	proof, err := plonk.Prove(&b.cs, &b.pk, privateWitness)
+	if err != nil {
+		return nil, fmt.Errorf("Prover failed with %s", err)
+	}
+
+	publicWitness, err := privateWitness.Public()
+	if err != nil {
+		return nil, fmt.Errorf("Could not extract public inputs from witness %s", err)
+	}
+
+	publicInputs, err := publicWitness.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal public witness %s", err)
+	}
+
+	_proof, ok := proof.(*plonk_bn254.Proof)
+	if !ok {
+		return nil, fmt.Errorf("Impossible: proof backend must be BN254 at this point")
+	}
+
+	var proofBuffer bytes.Buffer
+	_, err = _proof.WriteRawTo(&proofBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	var compressedProofBuffer bytes.Buffer
+	_, err = _proof.WriteTo(&compressedProofBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpc.ZeroKnowledgeProof{
+		Content:           proofBuffer.Bytes(),
+		CompressedContent: compressedProofBuffer.Bytes(),
+		PublicInputs:      publicInputs,
+		EvmProof:          _proof.MarshalSolidity(),
+		ProofType:         grpc.ProofType_PLONK,
+	}, nil
+}
+
+func (b *plonkBundle) verify(compressedProof []byte, publicAssignment frontend.Circuit) error {
+	var proof plonk_bn254.Proof
+	_, err := proof.ReadFrom(bytes.NewReader(compressedProof))
+	if err != nil {
+		return fmt.Errorf("Failed to read compressed proof: %w", err)
+	}
+
+	publicWitness, err := frontend.NewWitness(publicAssignment, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		return fmt.Errorf("Unable to create public witness: %w", err)
+	}
+
+	return plonk.Verify(&proof, &b.vk, publicWitness)
+}
+
+func (b *plonkBundle) exportSolidity(w io.Writer) error {
+	return b.vk.ExportSolidity(w)
+}
+
+func (b *plonkBundle) stats() *grpc.QueryStatsResponse {
+	return &grpc.QueryStatsResponse{
+		VariableStats: &grpc.VariableStats{
+			NbInternalVariables: uint32(b.cs.GetNbInternalVariables()),
+			NbSecretVariables:   uint32(b.cs.GetNbSecretVariables()),
+			NbPublicVariables:   uint32(b.cs.GetNbPublicVariables()),
+			NbConstraints:       uint32(b.cs.GetNbConstraints()),
+			NbCoefficients:      uint32(b.cs.GetNbCoefficients()),
+		},
+		ProvingKeyStats: &grpc.ProvingKeyStats{
+			NbG1: uint32(len(b.pk.Kzg.G1) + len(b.pk.KzgLagrange.G1)),
+			NbG2: uint32(0),
+		},
+		VerifyingKeyStats: &grpc.VerifyingKeyStats{
+			// S1, S2, S3, Ql, Qr, Qm, Qo, Qk and the BSB22 commitments
+			NbG1:            uint32(len(b.vk.S) + 5 + len(b.vk.Qcp)),
+			NbG2:            uint32(len(b.vk.Kzg.G2)),
+			NbPublicWitness: uint32(b.vk.NbPublicWitness()),
+		},
+		// Deprecated
+		CommitmentStats: &grpc.CommitmentStats{
+			NbPublicCommitted:  uint32(0),
+			NbPrivateCommitted: uint32(0),
+		},
+	}
+}
+
+// Load the universal SRS and derive the canonical and lagrange SRS sized for
+// the given constraint system.
+func loadSRS(srsPath string, ccs constraint.ConstraintSystem) (*kzg_bn254.SRS, *kzg_bn254.SRS, error) {
+	if srsPath == "" {
+		return nil, nil, fmt.Errorf("A KZG SRS is required to setup a PLONK circuit")
+	}
+
+	log.Debug().Str("path", srsPath).Msg("Loading SRS...")
+	f, err := os.Open(srsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	var srs kzg_bn254.SRS
+	if _, err := srs.ReadFrom(bufio.NewReader(f)); err != nil {
+		return nil, nil, fmt.Errorf("Could not read the SRS: %w", err)
+	}
+
+	sizeLagrange := ecc.NextPowerOfTwo(uint64(ccs.GetNbConstraints() + ccs.GetNbPublicVariables()))
+	sizeCanonical := sizeLagrange + 3
+	if uint64(len(srs.Pk.G1)) < sizeCanonical {
+		return nil, nil, fmt.Errorf("The SRS is too small, expected at least %d G1 points, got: %d", sizeCanonical, len(srs.Pk.G1))
+	}
+
+	canonical := &kzg_bn254.SRS{Vk: srs.Vk}
+	canonical.Pk.G1 = srs.Pk.G1[:sizeCanonical]
+
+	log.Debug().Uint64("size", sizeLagrange).Msg("Computing lagrange SRS...")
+	lagrangeG1, err := kzg_bn254.ToLagrangeG1(srs.Pk.G1[:sizeLagrange])
+	if err != nil {
+		return nil, nil, err
+	}
+	lagrange := &kzg_bn254.SRS{Vk: srs.Vk}
+	lagrange.Pk.G1 = lagrangeG1
+
+	return canonical, lagrange, nil
+}
+
+func loadOrCreatePlonk(config BundleConfig, circuit frontend.Circuit) (*plonkBundle, error) {
+	b := &plonkBundle{}
+
+	if bundleExists(config) {
+		log.Info().Msg("Loading circuit...")
+
+		log.Debug().Msg("Loading SCS...")
+		err := readFrom(config.CsPath, &b.cs)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Debug().Msg("Loading proving key...")
+		err = readFrom(config.PkPath, &b.pk)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Debug().Msg("Loading verifying key...")
+		err = readFrom(config.VkPath, &b.vk)
+		if err != nil {
+			return nil, err
+		}
+
+		return b, nil
+	}
+
+	log.Info().Msg("Compiling circuit...")
+	scsInstance, err := compile(BackendPlonk, circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	b.cs = *scsInstance.(*cs_bn254.SparseR1CS)
+
+	canonical, lagrange, err := loadSRS(config.SrsPath, &b.cs)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().Msg("Setup PK/VK")
+	pk, vk, err := plonk_bn254.Setup(&b.cs, *canonical, *lagrange)
+	if err != nil {
+		return nil, err
+	}
+	b.pk = *pk
+	b.vk = *vk
+
+	err = saveTo(config.CsPath, &b.cs)
+	if err != nil {
+		return nil, err
+	}
+	err = saveTo(config.PkPath, &b.pk)
+	if err != nil {
+		return nil, err
+	}
+	err = saveTo(config.VkPath, &b.vk)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	grpc "galois/grpc/api/v3"
+
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+type squareCircuit struct {
+	Root   frontend.Variable
+	Square frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.Root, c.Root), c.Square)
+	return nil
+}
+
+// A universal SRS of the given size, saved in the given directory.
+func writeTestSRS(t *testing.T, dir string, size uint64) string {
+	srs, err := kzg_bn254.NewSRS(size, big.NewInt(42))
+	require.NoError(t, err)
+	path := filepath.Join(dir, "srs.bin")
+	require.NoError(t, saveTo(path, srs))
+	return path
+}
+
+func testPlonkConfig(dir string, srsPath string) BundleConfig {
+	return BundleConfig{
+		Backend: BackendPlonk,
+		CsPath:  filepath.Join(dir, "scs.bin"),
+		PkPath:  filepath.Join(dir, "pk.bin"),
+		VkPath:  filepath.Join(dir, "vk.bin"),
+		SrsPath: srsPath,
+	}
+}
+
+func TestLoadSRS(t *testing.T) {
+	dir := t.TempDir()
+	ccs, err := compile(BackendPlonk, &squareCircuit{})
+	require.NoError(t, err)
+	// Two constraints and the public input, padded to a power of two
+	sizeLagrange := uint64(4)
+
+	_, _, err = loadSRS("", ccs)
+	require.ErrorContains(t, err, "A KZG SRS is required to setup a PLONK circuit")
+
+	_, _, err = loadSRS(writeTestSRS(t, dir, sizeLagrange+2), ccs)
+	require.ErrorContains(t, err, "The SRS is too small, expected at least 7 G1 points, got: 6")
+
+	srsPath := writeTestSRS(t, dir, 64)
+	canonical, lagrange, err := loadSRS(srsPath, ccs)
+	require.NoError(t, err)
+
+	var srs kzg_bn254.SRS
+	require.NoError(t, readFrom(srsPath, &srs))
+	require.Equal(t, srs.Vk, canonical.Vk)
+	require.Equal(t, srs.Pk.G1[:sizeLagrange+3], canonical.Pk.G1)
+	require.Equal(t, srs.Vk, lagrange.Vk)
+	require.Len(t, lagrange.Pk.G1, int(sizeLagrange))
+	expected, err := kzg_bn254.ToLagrangeG1(srs.Pk.G1[:sizeLagrange])
+	require.NoError(t, err)
+	require.Equal(t, expected, lagrange.Pk.G1)
+
+	require.NoError(t, os.Truncate(srsPath, 100))
+	_, _, err = loadSRS(srsPath, ccs)
+	require.ErrorContains(t, err, "Could not read the SRS")
+}
+
+func TestPlonkBundle(t *testing.T) {
+	dir := t.TempDir()
+	config := testPlonkConfig(dir, writeTestSRS(t, dir, 64))
+
+	_, err := loadOrCreate(testPlonkConfig(t.TempDir(), ""), &squareCircuit{})
+	require.ErrorContains(t, err, "A KZG SRS is required to setup a PLONK circuit")
+
+	bundle, err := loadOrCreate(config, &squareCircuit{})
+	require.NoError(t, err)
+	require.True(t, bundleExists(config))
+
+	proof, err := bundle.prove(&squareCircuit{Root: 3, Square: 9})
+	require.NoError(t, err)
+	require.Equal(t, grpc.ProofType_PLONK, proof.ProofType)
+	require.NotEmpty(t, proof.EvmProof)
+	require.Greater(t, len(proof.Content), len(proof.CompressedContent))
+
+	require.NoError(t, bundle.verify(proof.CompressedContent, &squareCircuit{Square: 9}))
+	require.Error(t, bundle.verify(proof.CompressedContent, &squareCircuit{Square: 16}))
+	require.Error(t, bundle.verify(proof.CompressedContent[1:], &squareCircuit{Square: 9}))
+
+	_, err = bundle.prove(&squareCircuit{Root: 3, Square: 10})
+	require.Error(t, err)
+
+	stats := bundle.stats()
+	require.Equal(t, uint32(1), stats.VariableStats.NbPublicVariables)
+	require.Equal(t, uint32(1), stats.VerifyingKeyStats.NbPublicWitness)
+
+	// The saved bundle is loaded back without the SRS and verifies the same proofs
+	loaded, err := loadOrCreate(testPlonkConfig(dir, ""), &squareCircuit{})
+	require.NoError(t, err)
+	require.Equal(t, &bundle.(*plonkBundle).vk, &loaded.(*plonkBundle).vk)
+	require.NoError(t, loaded.verify(proof.CompressedContent, &squareCircuit{Square: 9}))
+	reproved, err := loaded.prove(&squareCircuit{Root: 4, Square: 16})
+	require.NoError(t, err)
+	require.NoError(t, bundle.verify(reproved.CompressedContent, &squareCircuit{Square: 16}))
+}
@@ -0,0 +1,230 @@
+package grpc
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	backend "github.com/consensys/gnark/backend/groth16"
+	backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	plonk_bn254 "github.com/consensys/gnark/backend/plonk/bn254"
+	"github.com/consensys/gnark/constraint"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+)
+
+// Compile the circuit with the exact same options used to setup a bundle.
+func compile(b Backend, circuit frontend.Circuit) (constraint.ConstraintSystem, error) {
+	switch b {
+	case BackendGroth16:
+		return frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit, frontend.WithCompressThreshold(300))
+	case BackendPlonk:
+		return frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, circuit)
+	default:
+		return nil, fmt.Errorf("Unknown backend: %s", b)
+	}
+}
+
+// Hash of the part of a constraint system the keys are derived from: the wire
+// layout, the coefficients and the constraints, along with the instructions of
+// the solver. The debug information is left out as it embeds source locations,
+// which would make the hash depend on the machine the circuit has been
+// compiled on.
+func ConstraintSystemHash(cs constraint.ConstraintSystem) []byte {
+	h := sha256.New()
+	write := func(xs ...int) {
+		for _, x := range xs {
+			binary.Write(h, binary.BigEndian, uint64(x))
+		}
+	}
+	write(cs.GetNbPublicVariables(), cs.GetNbSecretVariables(), cs.GetNbInternalVariables())
+	write(cs.GetNbCoefficients())
+	for i := 0; i < cs.GetNbCoefficients(); i++ {
+		binary.Write(h, binary.BigEndian, cs.GetCoefficient(i))
+	}
+	writeLinearExpression := func(l constraint.LinearExpression) {
+		write(len(l))
+		for _, t := range l {
+			write(int(t.CID), int(t.VID))
+		}
+	}
+	switch cs := cs.(type) {
+	case constraint.R1CS:
+		write(cs.GetNbConstraints())
+		for _, c := range cs.GetR1Cs() {
+			writeLinearExpression(c.L)
+			writeLinearExpression(c.R)
+			writeLinearExpression(c.O)
+		}
+	case constraint.SparseR1CS:
+		write(cs.GetNbConstraints())
+		for _, c := range cs.GetSparseR1Cs() {
+			write(int(c.XA), int(c.XB), int(c.XC), int(c.QL), int(c.QR), int(c.QO), int(c.QM), int(c.QC), int(c.Commitment))
+		}
+	}
+	writeCommitments(h, cs.GetCommitments())
+	writeInstructions(h, cs)
+	return h.Sum(nil)
+}
+
+// The hints are solver instructions rather than constraints: they change the
+// witness the prover computes, not the keys, but a circuit calling other hints
+// is another circuit. Each instruction is written with its calldata, which
+// holds the hint ID and wires of the hint calls, and the blueprints decoding
+// them are written by kind.
+func writeInstructions(h hash.Hash, cs constraint.ConstraintSystem) {
+	// Both the R1CS and the SparseR1CS are the bn254 system
+	system, ok := cs.(*cs_bn254.R1CS)
+	if !ok {
+		return
+	}
+	binary.Write(h, binary.BigEndian, uint64(len(system.Blueprints)))
+	for _, blueprint := range system.Blueprints {
+		fmt.Fprintf(h, "%T\n", blueprint)
+	}
+	binary.Write(h, binary.BigEndian, uint64(len(system.Instructions)))
+	var buffer []byte
+	for _, packed := range system.Instructions {
+		instruction := packed.Unpack(&system.System)
+		buffer = binary.BigEndian.AppendUint32(buffer[:0], uint32(packed.BlueprintID))
+		buffer = binary.BigEndian.AppendUint32(buffer, uint32(len(instruction.Calldata)))
+		for _, x := range instruction.Calldata {
+			buffer = binary.BigEndian.AppendUint32(buffer, x)
+		}
+		h.Write(buffer)
+	}
+}
+
+func writeCommitments(h hash.Hash, commitments constraint.Commitments) {
+	write := func(xs ...int) {
+		binary.Write(h, binary.BigEndian, uint64(len(xs)))
+		for _, x := range xs {
+			binary.Write(h, binary.BigEndian, uint64(x))
+		}
+	}
+	switch commitments := commitments.(type) {
+	case constraint.Groth16Commitments:
+		for _, c := range commitments {
+			write(c.PublicAndCommitmentCommitted...)
+			write(c.PrivateCommitted...)
+			write(c.CommitmentIndex, c.NbPublicCommitted)
+		}
+	case constraint.PlonkCommitments:
+		for _, c := range commitments {
+			write(c.Committed...)
+			write(c.CommitmentIndex)
+		}
+	}
+}
+
+// Public variables and commitment layout a verifying key has been setup
+// with, the public variables being counted as in the constraint system.
+type keyLayout struct {
+	nbPublicVariables int
+	commitments       string
+}
+
+func expectedKeyLayout(cs constraint.ConstraintSystem) keyLayout {
+	layout := keyLayout{nbPublicVariables: cs.GetNbPublicVariables()}
+	switch commitments := cs.GetCommitments().(type) {
+	case constraint.Groth16Commitments:
+		layout.commitments = fmt.Sprint(commitments.GetPublicAndCommitmentCommitted(commitments.CommitmentIndexes(), cs.GetNbPublicVariables()))
+	case constraint.PlonkCommitments:
+		layout.commitments = fmt.Sprint(commitments.CommitmentIndexes())
+	}
+	return layout
+}
+
+func groth16KeyLayout(vk *backend_bn254.VerifyingKey) keyLayout {
+	return keyLayout{
+		nbPublicVariables: len(vk.G1.K) - len(vk.PublicAndCommitmentCommitted),
+		commitments:       fmt.Sprint(vk.PublicAndCommitmentCommitted),
+	}
+}
+
+func plonkKeyLayout(vk *plonk_bn254.VerifyingKey) keyLayout {
+	indexes := make([]int, len(vk.CommitmentConstraintIndexes))
+	for i, index := range vk.CommitmentConstraintIndexes {
+		indexes[i] = int(index)
+	}
+	return keyLayout{
+		nbPublicVariables: int(vk.NbPublicVariables),
+		commitments:       fmt.Sprint(indexes),
+	}
+}
+
+// A difference between the circuit compiled from source and a deployed bundle
+type Mismatch struct {
+	Field    string
+	Source   string
+	Deployed string
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s:\n  - source:   %s\n  + deployed: %s", m.Field, m.Source, m.Deployed)
+}
+
+// Compile the circuit from source and compare it against the constraint
+// system and verifying key of a bundle. The proving key isn't loaded, it's
+// derived along with the verifying key and checked by the ceremony.
+func CheckBundle(config BundleConfig, circuit frontend.Circuit) ([]Mismatch, error) {
+	compiled, err := compile(config.Backend, circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployed constraint.ConstraintSystem
+	var layout keyLayout
+	switch config.Backend {
+	case BackendGroth16:
+		var cs cs_bn254.R1CS
+		err = readFrom(config.CsPath, &cs)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read the R1CS: %w", err)
+		}
+		var vk backend_bn254.VerifyingKey
+		err = readFrom(config.VkPath, backend.VerifyingKey(&vk))
+		if err != nil {
+			return nil, fmt.Errorf("Could not read the verifying key: %w", err)
+		}
+		deployed = &cs
+		layout = groth16KeyLayout(&vk)
+	case BackendPlonk:
+		var cs cs_bn254.SparseR1CS
+		err = readFrom(config.CsPath, &cs)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read the SCS: %w", err)
+		}
+		var vk plonk_bn254.VerifyingKey
+		err = readFrom(config.VkPath, &vk)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read the verifying key: %w", err)
+		}
+		deployed = &cs
+		layout = plonkKeyLayout(&vk)
+	}
+
+	var mismatches []Mismatch
+	compare := func(field string, source any, deployed any) {
+		s, d := fmt.Sprint(source), fmt.Sprint(deployed)
+		if s != d {
+			mismatches = append(mismatches, Mismatch{Field: field, Source: s, Deployed: d})
+		}
+	}
+	compare("constraint system hash", fmt.Sprintf("%x", ConstraintSystemHash(compiled)), fmt.Sprintf("%x", ConstraintSystemHash(deployed)))
+	compare("constraints", compiled.GetNbConstraints(), deployed.GetNbConstraints())
+	compare("public variables", compiled.GetNbPublicVariables(), deployed.GetNbPublicVariables())
+	compare("secret variables", compiled.GetNbSecretVariables(), deployed.GetNbSecretVariables())
+	compare("internal variables", compiled.GetNbInternalVariables(), deployed.GetNbInternalVariables())
+	compare("commitments", compiled.GetCommitments().CommitmentIndexes(), deployed.GetCommitments().CommitmentIndexes())
+
+	expected := expectedKeyLayout(compiled)
+	compare("verifying key public variables", expected.nbPublicVariables, layout.nbPublicVariables)
+	compare("verifying key commitment layout", expected.commitments, layout.commitments)
+
+	return mismatches, nil
+}
@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+// The square circuit with the root cubed instead, i.e. another circuit with
+// the same inputs.
+type cubeCircuit struct {
+	Root   frontend.Variable
+	Square frontend.Variable `gnark:",public"`
+}
+
+func (c *cubeCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.Root, c.Root, c.Root), c.Square)
+	return nil
+}
+
+func halveHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	outputs[0].Rsh(inputs[0], 1)
+	return nil
+}
+
+func otherHalveHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	outputs[0].Rsh(inputs[0], 1)
+	return nil
+}
+
+// A circuit whose constraints don't depend on the hint it calls
+type halveCircuit struct {
+	X    frontend.Variable `gnark:",public"`
+	hint solver.Hint
+}
+
+func (c *halveCircuit) Define(api frontend.API) error {
+	half, err := api.Compiler().NewHint(c.hint, 1, c.X)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(api.Add(half[0], half[0]), c.X)
+	return nil
+}
+
+func mismatchedFields(mismatches []Mismatch) []string {
+	var fields []string
+	for _, m := range mismatches {
+		fields = append(fields, m.Field)
+	}
+	return fields
+}
+
+func TestConstraintSystemHash(t *testing.T) {
+	hash := func(b Backend, circuit frontend.Circuit) []byte {
+		cs, err := compile(b, circuit)
+		require.NoError(t, err)
+		return ConstraintSystemHash(cs)
+	}
+
+	require.Equal(t, hash(BackendGroth16, &squareCircuit{}), hash(BackendGroth16, &squareCircuit{}))
+	require.NotEqual(t, hash(BackendGroth16, &squareCircuit{}), hash(BackendGroth16, &cubeCircuit{}))
+	require.NotEqual(t, hash(BackendGroth16, &squareCircuit{}), hash(BackendPlonk, &squareCircuit{}))
+
+	// The hints are part of the circuit, although the constraints are the same
+	for _, b := range []Backend{BackendGroth16, BackendPlonk} {
+		halve, err := compile(b, &halveCircuit{hint: halveHint})
+		require.NoError(t, err)
+		otherHalve, err := compile(b, &halveCircuit{hint: otherHalveHint})
+		require.NoError(t, err)
+		require.Equal(t, halve.GetNbConstraints(), otherHalve.GetNbConstraints())
+		require.NotEqual(t, ConstraintSystemHash(halve), ConstraintSystemHash(otherHalve))
+	}
+}
+
+func TestCheckBundle(t *testing.T) {
+	plonkDir := t.TempDir()
+	configs := []BundleConfig{
+		testGroth16Config(t.TempDir()),
+		testPlonkConfig(plonkDir, writeTestSRS(t, plonkDir, 64)),
+	}
+
+	for _, config := range configs {
+		t.Run(string(config.Backend), func(t *testing.T) {
+			_, err := loadOrCreate(config, &squareCircuit{})
+			require.NoError(t, err)
+
+			mismatches, err := CheckBundle(config, &squareCircuit{})
+			require.NoError(t, err)
+			require.Empty(t, mismatches)
+
+			mismatches, err = CheckBundle(config, &cubeCircuit{})
+			require.NoError(t, err)
+			require.Equal(t, []string{"constraint system hash", "constraints", "internal variables"}, mismatchedFields(mismatches))
+
+			missing := config
+			missing.CsPath = filepath.Join(t.TempDir(), "cs.bin")
+			_, err = CheckBundle(missing, &squareCircuit{})
+			require.ErrorContains(t, err, "Could not read the")
+		})
+	}
+
+	// A bundle setup with another hint only differs by its hash
+	config := testGroth16Config(t.TempDir())
+	_, err := loadOrCreate(config, &halveCircuit{hint: halveHint})
+	require.NoError(t, err)
+	mismatches, err := CheckBundle(config, &halveCircuit{hint: halveHint})
+	require.NoError(t, err)
+	require.Empty(t, mismatches)
+	mismatches, err = CheckBundle(config, &halveCircuit{hint: otherHalveHint})
+	require.NoError(t, err)
+	require.Equal(t, []string{"constraint system hash"}, mismatchedFields(mismatches))
+}
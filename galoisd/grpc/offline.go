@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	lcgadget "galois/pkg/lightclient/nonadjacent"
+
+	types "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	backend "github.com/consensys/gnark/backend/groth16"
+	backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// Convert a groth16 proof from the EVM layout light clients are given,
+// A‖B‖C‖commitment‖PoK with uncompressed points, to the gnark one.
+func parseEvmProof(evmProof []byte) (*backend_bn254.Proof, error) {
+	if len(evmProof) != evmProofSize {
+		return nil, fmt.Errorf("Expected an EVM proof of %d bytes, got: %d", evmProofSize, len(evmProof))
+	}
+	var proof backend_bn254.Proof
+	var commitment bn254.G1Affine
+	points := []struct {
+		name  string
+		point interface{ SetBytes([]byte) (int, error) }
+		at    int
+	}{
+		{"A", &proof.Ar, evmProofA},
+		{"B", &proof.Bs, evmProofA + bn254.SizeOfG1AffineUncompressed},
+		{"C", &proof.Krs, evmProofA + bn254.SizeOfG1AffineUncompressed + bn254.SizeOfG2AffineUncompressed},
+		{"commitment", &commitment, evmProofCommitment},
+		{"commitment PoK", &proof.CommitmentPok, evmProofPoK},
+	}
+	for _, p := range points {
+		_, err := p.point.SetBytes(evmProof[p.at:])
+		if err != nil {
+			return nil, fmt.Errorf("Could not read the proof %s: %w", p.name, err)
+		}
+	}
+	proof.Commitments = []bn254.G1Affine{commitment}
+	return &proof, nil
+}
+
+// The outcome of an offline verification along with the public inputs the
+// proof has been checked against.
+type OfflineVerification struct {
+	Fields     []InputsHashField
+	InputsHash []byte
+	// Only set for groth16, the public input derived from the proof commitment
+	CommitmentsHash []byte
+	Err             error
+}
+
+// Verify a light header proof with the verifying key alone, recomputing the
+// inputs hash the way the circuit does. The proof is serialized by gnark or,
+// for groth16, in the EVM layout.
+func VerifyOffline(b Backend, vkPath string, proof []byte, chainID string, header *types.Header, trustedValidatorsHash []byte) (*OfflineVerification, error) {
+	result := &OfflineVerification{
+		Fields:     InputsHashFields(chainID, header, trustedValidatorsHash),
+		InputsHash: InputsHash(chainID, header, trustedValidatorsHash),
+	}
+
+	var bundle circuitBundle
+	switch b {
+	case BackendGroth16:
+		groth16 := &groth16Bundle{}
+		err := readFrom(vkPath, backend.VerifyingKey(&groth16.vk))
+		if err != nil {
+			return nil, fmt.Errorf("Could not read the verifying key: %w", err)
+		}
+		var parsed backend_bn254.Proof
+		if len(proof) == evmProofSize {
+			evmProof, err := parseEvmProof(proof)
+			if err != nil {
+				return nil, err
+			}
+			parsed = *evmProof
+		} else {
+			_, err := parsed.ReadFrom(bytes.NewReader(proof))
+			if err != nil {
+				return nil, fmt.Errorf("Could not read the proof: %w", err)
+			}
+		}
+		if len(parsed.Commitments) != 1 {
+			return nil, fmt.Errorf("Expected a single proof commitment, got: %d", len(parsed.Commitments))
+		}
+		commitmentsHash := cometbn254.HashToField(parsed.Commitments[0].Marshal())
+		commitmentsHashBytes := commitmentsHash.Bytes()
+		result.CommitmentsHash = commitmentsHashBytes[:]
+
+		var buffer bytes.Buffer
+		mem := bufio.NewWriter(&buffer)
+		_, err = parsed.WriteTo(mem)
+		if err != nil {
+			return nil, err
+		}
+		mem.Flush()
+		proof = buffer.Bytes()
+		bundle = groth16
+	case BackendPlonk:
+		plonk := &plonkBundle{}
+		err := readFrom(vkPath, &plonk.vk)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read the verifying key: %w", err)
+		}
+		bundle = plonk
+	default:
+		return nil, fmt.Errorf("Unknown backend: %s", b)
+	}
+
+	result.Err = bundle.verify(proof, &lcgadget.Circuit{
+		InputsHash: result.InputsHash,
+	})
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	grpc "galois/grpc/api/v3"
+
+	types "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/stretchr/testify/require"
+)
+
+func testGroth16Config(dir string) BundleConfig {
+	return BundleConfig{
+		Backend: BackendGroth16,
+		CsPath:  filepath.Join(dir, "r1cs.bin"),
+		PkPath:  filepath.Join(dir, "pk.bin"),
+		VkPath:  filepath.Join(dir, "vk.bin"),
+	}
+}
+
+// A light header proven with a throwaway setup of the stand-in circuit, which
+// has the public witness of the light client one.
+func proveTestHeader(t *testing.T, config BundleConfig) (string, *types.Header, []byte, *grpc.ZeroKnowledgeProof) {
+	chainID := "union-devnet-1337"
+	header := &types.Header{
+		Height:             3405691582,
+		Time:               time.Unix(1710783278, 499600406),
+		ValidatorsHash:     bytes.Repeat([]byte{0x1B}, 32),
+		NextValidatorsHash: bytes.Repeat([]byte{0x2C}, 32),
+		AppHash:            bytes.Repeat([]byte{0x3A}, 32),
+	}
+	trustedValidatorsHash := bytes.Repeat([]byte{0x4D}, 32)
+
+	bundle, err := loadOrCreate(config, &standInCircuit{})
+	require.NoError(t, err)
+	inputsHash := InputsHash(chainID, header, trustedValidatorsHash)
+	proof, err := bundle.prove(&standInCircuit{Preimage: inputsHash, InputsHash: inputsHash})
+	require.NoError(t, err)
+	return chainID, header, trustedValidatorsHash, proof
+}
+
+func TestParseEvmProof(t *testing.T) {
+	config := testGroth16Config(t.TempDir())
+	_, _, _, proof := proveTestHeader(t, config)
+	require.Len(t, proof.EvmProof, evmProofSize)
+
+	// The EVM layout holds the same points as the gnark encoding
+	parsed, err := parseEvmProof(proof.EvmProof)
+	require.NoError(t, err)
+	var expected backend_bn254.Proof
+	_, err = expected.ReadFrom(bytes.NewReader(proof.CompressedContent))
+	require.NoError(t, err)
+	require.Equal(t, &expected, parsed)
+
+	_, err = parseEvmProof(proof.EvmProof[1:])
+	require.ErrorContains(t, err, "Expected an EVM proof of 384 bytes, got: 383")
+
+	offCurve := bytes.Clone(proof.EvmProof)
+	offCurve[evmProofCommitment+63] ^= 1
+	_, err = parseEvmProof(offCurve)
+	require.ErrorContains(t, err, "Could not read the proof commitment")
+}
+
+func TestVerifyOffline(t *testing.T) {
+	config := testGroth16Config(t.TempDir())
+	chainID, header, trustedValidatorsHash, proof := proveTestHeader(t, config)
+	fields := InputsHashFields(chainID, header, trustedValidatorsHash)
+
+	// Both the EVM layout and the gnark encoding of the proof are accepted
+	for _, encoded := range [][]byte{proof.EvmProof, proof.CompressedContent} {
+		result, err := VerifyOffline(BackendGroth16, config.VkPath, encoded, chainID, header, trustedValidatorsHash)
+		require.NoError(t, err)
+		require.NoError(t, result.Err)
+		require.Equal(t, fields, result.Fields)
+		require.Equal(t, InputsHash(chainID, header, trustedValidatorsHash), result.InputsHash)
+		commitmentsHash := cometbn254.HashToField(proof.EvmProof[evmProofCommitment:evmProofPoK])
+		commitmentsHashBytes := commitmentsHash.Bytes()
+		require.Equal(t, commitmentsHashBytes[:], result.CommitmentsHash)
+	}
+
+	// A tampered header fails the verification, which reports the inputs the
+	// proof has been checked against
+	tampered := *header
+	tampered.Height++
+	result, err := VerifyOffline(BackendGroth16, config.VkPath, proof.EvmProof, chainID, &tampered, trustedValidatorsHash)
+	require.NoError(t, err)
+	require.Error(t, result.Err)
+	require.Equal(t, InputsHashFields(chainID, &tampered, trustedValidatorsHash), result.Fields)
+	require.NotEqual(t, fields, result.Fields)
+
+	result, err = VerifyOffline(BackendGroth16, config.VkPath, proof.EvmProof, chainID+"-1", header, trustedValidatorsHash)
+	require.NoError(t, err)
+	require.Error(t, result.Err)
+
+	_, err = VerifyOffline(BackendGroth16, filepath.Join(t.TempDir(), "vk.bin"), proof.EvmProof, chainID, header, trustedValidatorsHash)
+	require.ErrorContains(t, err, "Could not read the verifying key")
+	_, err = VerifyOffline(BackendGroth16, config.VkPath, proof.CompressedContent[1:], chainID, header, trustedValidatorsHash)
+	require.ErrorContains(t, err, "Could not read the proof")
+	_, err = VerifyOffline(Backend("stark"), config.VkPath, proof.EvmProof, chainID, header, trustedValidatorsHash)
+	require.ErrorContains(t, err, "Unknown backend: stark")
+
+	t.Run("plonk", func(t *testing.T) {
+		dir := t.TempDir()
+		config := testPlonkConfig(dir, writeTestSRS(t, dir, 64))
+		chainID, header, trustedValidatorsHash, proof := proveTestHeader(t, config)
+
+		result, err := VerifyOffline(BackendPlonk, config.VkPath, proof.CompressedContent, chainID, header, trustedValidatorsHash)
+		require.NoError(t, err)
+		require.NoError(t, result.Err)
+		require.Nil(t, result.CommitmentsHash)
+
+		tampered := *header
+		tampered.AppHash = flipLastBit(header.AppHash)
+		result, err = VerifyOffline(BackendPlonk, config.VkPath, proof.CompressedContent, chainID, &tampered, trustedValidatorsHash)
+		require.NoError(t, err)
+		require.Error(t, result.Err)
+		require.Equal(t, InputsHashFields(chainID, &tampered, trustedValidatorsHash), result.Fields)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	grpc "galois/grpc/api/v3"
 	"galois/pkg/lightclient"
+	"galois/pkg/lightclient/misbehaviour"
 	lcgadget "galois/pkg/lightclient/nonadjacent"
 	"io"
 	"math/big"
@@ -21,29 +22,23 @@ import (
 	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
 	ce "github.com/cometbft/cometbft/crypto/encoding"
 	"github.com/cometbft/cometbft/crypto/merkle"
-	"github.com/consensys/gnark-crypto/ecc"
+	comettypes "github.com/cometbft/cometbft/types"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
-	backend_opts "github.com/consensys/gnark/backend"
-	backend "github.com/consensys/gnark/backend/groth16"
-	backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
-	"github.com/consensys/gnark/constraint"
-	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
-	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/frontend/cs/r1cs"
 	gadget "github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/unionlabs/union/11-cometbls/inputshash"
 
 	"github.com/rs/zerolog/log"
 )
 
 type proverServer struct {
 	grpc.UnimplementedUnionProverAPIServer
-	cs      cs_bn254.R1CS
-	pk      backend_bn254.ProvingKey
-	vk      backend_bn254.VerifyingKey
+	bundle  circuitBundle
 	maxJobs uint32
 	nbJobs  atomic.Uint32
 	results sync.Map
+	// Optional, only set if the misbehaviour circuit has been configured
+	misbehaviour circuitBundle
 }
 
 type cometblsHashToField struct {
@@ -127,205 +122,186 @@ func AggregateSignatures(signatures [][]byte) (bn254.G2Affine, error) {
 	return aggregatedSignature, nil
 }
 
-func (p *proverServer) Poll(ctx context.Context, pollReq *grpc.PollRequest) (*grpc.PollResponse, error) {
-	req := pollReq.Request
-
-	if len(req.TrustedCommit.Validators) > lightclient.MaxVal {
-		return nil, fmt.Errorf("The circuit can handle a maximum of %d validators", lightclient.MaxVal)
+func validateCommit(commit *grpc.ValidatorSetCommit) error {
+	if len(commit.Validators) > lightclient.MaxVal {
+		return fmt.Errorf("The circuit can handle a maximum of %d validators", lightclient.MaxVal)
 	}
-	if len(req.UntrustedCommit.Validators) > lightclient.MaxVal {
-		return nil, fmt.Errorf("The circuit can handle a maximum of %d validators", lightclient.MaxVal)
+	if len(commit.Signatures) > len(commit.Validators) {
+		return fmt.Errorf("More signatures than validators")
 	}
-	if len(req.TrustedCommit.Signatures) > len(req.TrustedCommit.Signatures) {
-		return nil, fmt.Errorf("More signatures than validators")
-	}
-	if len(req.UntrustedCommit.Signatures) > len(req.UntrustedCommit.Signatures) {
-		return nil, fmt.Errorf("More signatures than validators")
+	return nil
+}
+
+func commitInput(commit *grpc.ValidatorSetCommit) (lcgadget.TendermintNonAdjacentLightClientInput, []byte, error) {
+	validators, validatorsRoot, err := MarshalValidators(commit.Validators)
+	if err != nil {
+		return lcgadget.TendermintNonAdjacentLightClientInput{}, nil, fmt.Errorf("Could not marshal validators %s", err)
 	}
 
-	reqJson, err := json.Marshal(req)
+	aggregatedSignature, err := AggregateSignatures(commit.Signatures)
 	if err != nil {
-		return nil, err
+		return lcgadget.TendermintNonAdjacentLightClientInput{}, nil, fmt.Errorf("Could not aggregate signature %s", err)
 	}
-	proveKey := sha256.Sum256(reqJson)
 
-	prove := func() (*grpc.ProveResponse, error) {
+	return lcgadget.TendermintNonAdjacentLightClientInput{
+		Sig:           gadget.NewG2Affine(aggregatedSignature),
+		Validators:    validators,
+		NbOfVal:       len(commit.Validators),
+		NbOfSignature: len(commit.Signatures),
+		Bitmap:        new(big.Int).SetBytes(commit.Bitmap),
+	}, validatorsRoot, nil
+}
 
-		log.Debug().Msg("Marshaling trusted validators...")
-		trustedValidators, trustedValidatorsRoot, err := MarshalValidators(req.TrustedCommit.Validators)
-		if err != nil {
-			return nil, fmt.Errorf("Could not marshal trusted validators %s", err)
-		}
+func uncons(b []byte) lightclient.UnconsHash {
+	return lightclient.UnconsHash{
+		Head: b[0],
+		Tail: b[1:],
+	}
+}
 
-		log.Debug().Msg("Aggregating trusted signature...")
-		trustedAggregatedSignature, err := AggregateSignatures(req.TrustedCommit.Signatures)
-		if err != nil {
-			return nil, fmt.Errorf("Could not aggregate trusted signature %s", err)
-		}
+func toBlockVote(vote *types.CanonicalVote) lightclient.BlockVote {
+	return lightclient.BlockVote{
+		BlockPartSetHeaderTotal: vote.BlockID.PartSetHeader.Total,
+		BlockPartSetHeaderHash:  uncons(vote.BlockID.PartSetHeader.Hash),
+		Round:                   vote.Round,
+	}
+}
 
-		log.Debug().Msg("Marshaling untrusted validators...")
-		untrustedValidators, _, err := MarshalValidators(req.UntrustedCommit.Validators)
-		if err != nil {
-			return nil, fmt.Errorf("Could not marshal untrusted validators %s", err)
-		}
+func toBlockHeader(header *types.Header) lightclient.BlockHeader {
+	return lightclient.BlockHeader{
+		VersionBlock:                header.Version.Block,
+		VersionApp:                  header.Version.App,
+		ChainID:                     []byte(header.ChainID),
+		Height:                      header.Height,
+		TimeSecs:                    header.Time.Unix(),
+		TimeNanos:                   header.Time.Nanosecond(),
+		LastBlockHash:               header.LastBlockId.Hash,
+		LastBlockPartSetHeaderTotal: header.LastBlockId.PartSetHeader.Total,
+		LastBlockPartSetHeaderHash:  uncons(header.LastBlockId.PartSetHeader.Hash),
+		LastCommitHash:              uncons(header.LastCommitHash),
+		DataHash:                    uncons(header.DataHash),
+		ValidatorsHash:              header.ValidatorsHash,
+		NextValidatorsHash:          header.NextValidatorsHash,
+		ConsensusHash:               uncons(header.ConsensusHash),
+		AppHash:                     uncons(header.AppHash),
+		LastResultsHash:             uncons(header.LastResultsHash),
+		EvidenceHash:                uncons(header.EvidenceHash),
+		ProposerAddress:             uncons(header.ProposerAddress),
+	}
+}
 
-		log.Debug().Msg("Aggregating untrusted signature...")
-		untrustedAggregatedSignature, err := AggregateSignatures(req.UntrustedCommit.Signatures)
-		if err != nil {
-			return nil, fmt.Errorf("Could not aggregate untrusted signature %s", err)
+// Reserve a proving slot, returns false if all the slots are taken.
+func (p *proverServer) acquireJob() bool {
+	for true {
+		nbJobs := p.nbJobs.Load()
+		if nbJobs >= p.maxJobs {
+			return false
 		}
-
-		trustedInput := lcgadget.TendermintNonAdjacentLightClientInput{
-			Sig:           gadget.NewG2Affine(trustedAggregatedSignature),
-			Validators:    trustedValidators,
-			NbOfVal:       len(req.TrustedCommit.Validators),
-			NbOfSignature: len(req.TrustedCommit.Signatures),
-			Bitmap:        new(big.Int).SetBytes(req.TrustedCommit.Bitmap),
+		if swapped := p.nbJobs.CompareAndSwap(nbJobs, nbJobs+1); swapped {
+			return true
 		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	panic("impossible; qed;")
+}
 
-		untrustedInput := lcgadget.TendermintNonAdjacentLightClientInput{
-			Sig:           gadget.NewG2Affine(untrustedAggregatedSignature),
-			Validators:    untrustedValidators,
-			NbOfVal:       len(req.UntrustedCommit.Validators),
-			NbOfSignature: len(req.UntrustedCommit.Signatures),
-			Bitmap:        new(big.Int).SetBytes(req.UntrustedCommit.Bitmap),
+func (p *proverServer) releaseJob() {
+	for true {
+		value := p.nbJobs.Load()
+		if swapped := p.nbJobs.CompareAndSwap(value, value-1); swapped {
+			break
 		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
 
-		uncons := func(b []byte) lightclient.UnconsHash {
-			return lightclient.UnconsHash{
-				Head: b[0],
-				Tail: b[1:],
-			}
-		}
+// A field of the inputs hash preimage along with its encoding
+type InputsHashField = inputshash.Field
+
+func lightHeader(chainID string, h *types.Header) inputshash.LightHeader {
+	return inputshash.LightHeader{
+		ChainId:            chainID,
+		Height:             h.Height,
+		Time:               h.Time,
+		ValidatorsHash:     h.ValidatorsHash,
+		NextValidatorsHash: h.NextValidatorsHash,
+		AppHash:            h.AppHash,
+	}
+}
 
-		getInputsHash := func(chainID string, h *types.Header, trustedValidatorsHash []byte) []byte {
-			buff := []byte{}
-			var padded [32]byte
-			writeI64 := func(x int64) {
-				big.NewInt(x).FillBytes(padded[:])
-				buff = append(buff, padded[:]...)
-			}
-			writeMiMCHash := func(b []byte) {
-				big.NewInt(0).SetBytes(b).FillBytes(padded[:])
-				buff = append(buff, padded[:]...)
-			}
-			writeHash := func(b []byte) {
-				buff = append(buff, b...)
-			}
-			writeMiMCHash([]byte(chainID))
-			writeI64(h.Height)
-			writeI64(h.Time.Unix())
-			writeI64(int64(h.Time.Nanosecond()))
-			writeMiMCHash(h.ValidatorsHash)
-			writeMiMCHash(h.NextValidatorsHash)
-			writeHash(h.AppHash)
-			writeMiMCHash(trustedValidatorsHash)
-			hash := sha256.Sum256(buff)
-			return hash[1:]
-		}
+// The preimage of the inputs hash field by field, in the order the circuit
+// hashes them.
+func InputsHashFields(chainID string, h *types.Header, trustedValidatorsHash []byte) []InputsHashField {
+	return inputshash.Fields(lightHeader(chainID, h), trustedValidatorsHash)
+}
 
-		inputsHash := getInputsHash(req.Vote.ChainID, req.UntrustedHeader, trustedValidatorsRoot)
-
-		log.Debug().Hex("request_hash", proveKey[:]).Hex("inputs_hash", inputsHash).Send()
-
-		witness := lcgadget.Circuit{
-			DomainSeparationTag: []byte(cometbn254.CometblsSigDST),
-			TrustedInput:        trustedInput,
-			TrustedValRoot:      trustedValidatorsRoot,
-			UntrustedInput:      untrustedInput,
-			Vote: lightclient.BlockVote{
-				BlockPartSetHeaderTotal: req.Vote.BlockID.PartSetHeader.Total,
-				BlockPartSetHeaderHash:  uncons(req.Vote.BlockID.PartSetHeader.Hash),
-				Round:                   req.Vote.Round,
-			},
-			Header: lightclient.BlockHeader{
-				VersionBlock:                req.UntrustedHeader.Version.Block,
-				VersionApp:                  req.UntrustedHeader.Version.App,
-				ChainID:                     []byte(req.UntrustedHeader.ChainID),
-				Height:                      req.UntrustedHeader.Height,
-				TimeSecs:                    req.UntrustedHeader.Time.Unix(),
-				TimeNanos:                   req.UntrustedHeader.Time.Nanosecond(),
-				LastBlockHash:               req.UntrustedHeader.LastBlockId.Hash,
-				LastBlockPartSetHeaderTotal: req.UntrustedHeader.LastBlockId.PartSetHeader.Total,
-				LastBlockPartSetHeaderHash:  uncons(req.UntrustedHeader.LastBlockId.PartSetHeader.Hash),
-				LastCommitHash:              uncons(req.UntrustedHeader.LastCommitHash),
-				DataHash:                    uncons(req.UntrustedHeader.DataHash),
-				ValidatorsHash:              req.UntrustedHeader.ValidatorsHash,
-				NextValidatorsHash:          req.UntrustedHeader.NextValidatorsHash,
-				ConsensusHash:               uncons(req.UntrustedHeader.ConsensusHash),
-				AppHash:                     uncons(req.UntrustedHeader.AppHash),
-				LastResultsHash:             uncons(req.UntrustedHeader.LastResultsHash),
-				EvidenceHash:                uncons(req.UntrustedHeader.EvidenceHash),
-				ProposerAddress:             uncons(req.UntrustedHeader.ProposerAddress),
-			},
-			InputsHash: inputsHash,
-		}
+// The sole public input of the non-adjacent circuit, the sha256 of the light
+// header and the trusted validators hash with the most significant byte
+// truncated to fit the scalar field.
+func InputsHash(chainID string, h *types.Header, trustedValidatorsHash []byte) []byte {
+	return inputshash.Hash(lightHeader(chainID, h), trustedValidatorsHash)
+}
 
-		privateWitness, err := frontend.NewWitness(&witness, ecc.BN254.ScalarField())
-		if err != nil {
-			return nil, fmt.Errorf("Could not create witness %s", err)
-		}
+// Assign the non-adjacent circuit from a prove request, also returns the
+// trusted validator set root.
+func nonAdjacentWitness(req *grpc.ProveRequest) (*lcgadget.Circuit, []byte, error) {
+	log.Debug().Msg("Marshaling trusted commit...")
+	trustedInput, trustedValidatorsRoot, err := commitInput(req.TrustedCommit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not marshal trusted commit %s", err)
+	}
 
-		log.Debug().Hex("request_hash", proveKey[:]).Msg("proving")
-		proof, err := backend.Prove(constraint.R1CS(&p.cs), backend.ProvingKey(&p.pk), privateWitness, backend_opts.WithProverHashToFieldFunction(&cometblsHashToField{}))
-		if err != nil {
-			return nil, fmt.Errorf("Prover failed with %s", err)
-		}
+	log.Debug().Msg("Marshaling untrusted commit...")
+	untrustedInput, _, err := commitInput(req.UntrustedCommit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not marshal untrusted commit %s", err)
+	}
 
-		publicWitness, err := privateWitness.Public()
-		if err != nil {
-			return nil, fmt.Errorf("Could not extract public inputs from witness %s", err)
-		}
+	inputsHash := InputsHash(req.Vote.ChainID, req.UntrustedHeader, trustedValidatorsRoot)
+
+	return &lcgadget.Circuit{
+		DomainSeparationTag: []byte(cometbn254.CometblsSigDST),
+		TrustedInput:        trustedInput,
+		TrustedValRoot:      trustedValidatorsRoot,
+		UntrustedInput:      untrustedInput,
+		Vote:                toBlockVote(req.Vote),
+		Header:              toBlockHeader(req.UntrustedHeader),
+		InputsHash:          inputsHash,
+	}, trustedValidatorsRoot, nil
+}
 
-		var proofCommitment []byte
-		var commitmentPOK []byte
-		switch _proof := proof.(type) {
-		case *backend_bn254.Proof:
-			if len(p.vk.PublicAndCommitmentCommitted) != 1 {
-				return nil, fmt.Errorf("Expected a single proof commitment, got: %d", len(p.vk.PublicAndCommitmentCommitted))
-			}
-			proofCommitment = _proof.Commitments[0].Marshal()
-			commitmentPOK = _proof.CommitmentPok.Marshal()
-			break
-		default:
-			return nil, fmt.Errorf("Impossible: proof backend must be BN254 at this point")
-		}
+func (p *proverServer) Poll(ctx context.Context, pollReq *grpc.PollRequest) (*grpc.PollResponse, error) {
+	req := pollReq.Request
 
-		publicInputs, err := publicWitness.MarshalBinary()
-		if err != nil {
-			return nil, fmt.Errorf("Could not marshal public witness %s", err)
-		}
+	if err := validateCommit(req.TrustedCommit); err != nil {
+		return nil, err
+	}
+	if err := validateCommit(req.UntrustedCommit); err != nil {
+		return nil, err
+	}
 
-		var proofBuffer bytes.Buffer
-		mem := bufio.NewWriter(&proofBuffer)
-		_, err = proof.WriteRawTo(mem)
+	reqJson, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	proveKey := sha256.Sum256(reqJson)
+
+	prove := func() (*grpc.ProveResponse, error) {
+		witness, trustedValidatorsRoot, err := nonAdjacentWitness(req)
 		if err != nil {
 			return nil, err
 		}
-		mem.Flush()
-		proofBz := proofBuffer.Bytes()
 
-		var compressedProofBuffer bytes.Buffer
-		mem = bufio.NewWriter(&compressedProofBuffer)
-		_, err = proof.WriteTo(mem)
+		log.Debug().Hex("request_hash", proveKey[:]).Hex("inputs_hash", witness.InputsHash.([]byte)).Send()
+
+		log.Debug().Hex("request_hash", proveKey[:]).Msg("proving")
+		proof, err := p.bundle.prove(witness)
 		if err != nil {
 			return nil, err
 		}
-		mem.Flush()
-		compressedProofBz := compressedProofBuffer.Bytes()
-
-		// Due to how gnark proves, we not only need the ZKP A/B/C points, but also a commitment hash and proof commitment.
-		// The proof is an uncompressed proof serialized by gnark, we extract A(G1)/B(G2)/C(G1) and then append the commitment and its POK.
-		// The EVM verifier has been extended to support this two extra public inputs.
-		evmProof := append(append(proofBz[:256], proofCommitment...), commitmentPOK...)
 
 		proveRes := grpc.ProveResponse{
-			Proof: &grpc.ZeroKnowledgeProof{
-				Content:           proofBz,
-				CompressedContent: compressedProofBz,
-				PublicInputs:      publicInputs,
-				EvmProof:          evmProof,
-			},
+			Proof:                   proof,
 			TrustedValidatorSetRoot: trustedValidatorsRoot,
 		}
 
@@ -363,17 +339,9 @@ func (p *proverServer) Poll(ctx context.Context, pollReq *grpc.PollRequest) (*gr
 	} else {
 		log.Info().Hex("request_hash", proveKey[:]).Msg("new")
 
-		for true {
-			nbJobs := p.nbJobs.Load()
-			if nbJobs >= p.maxJobs {
-				p.results.Delete(proveKey)
-				return nil, fmt.Errorf("busy_building")
-			} else {
-				if swapped := p.nbJobs.CompareAndSwap(nbJobs, nbJobs+1); swapped {
-					break
-				}
-			}
-			time.Sleep(10 * time.Millisecond)
+		if !p.acquireJob() {
+			p.results.Delete(proveKey)
+			return nil, fmt.Errorf("busy_building")
 		}
 
 		go func() {
@@ -386,13 +354,7 @@ func (p *proverServer) Poll(ctx context.Context, pollReq *grpc.PollRequest) (*gr
 				log.Info().Str("action", "prove").Hex("request_hash", proveKey[:]).RawJSON("request", reqJson).RawJSON("response", resJson).Send()
 				p.results.Store(proveKey, proveRes)
 			}
-			for true {
-				value := p.nbJobs.Load()
-				if swapped := p.nbJobs.CompareAndSwap(value, value-1); swapped {
-					break
-				}
-				time.Sleep(10 * time.Millisecond)
-			}
+			p.releaseJob()
 		}()
 	}
 
@@ -403,43 +365,59 @@ func (p *proverServer) Poll(ctx context.Context, pollReq *grpc.PollRequest) (*gr
 	}, nil
 }
 
-func (p *proverServer) Verify(ctx context.Context, req *grpc.VerifyRequest) (*grpc.VerifyResponse, error) {
-	log.Debug().Msg("Verifying...")
-
-	var proof backend_bn254.Proof
-	_, err := proof.ReadFrom(bytes.NewReader(req.Proof.CompressedContent))
-	if err != nil {
-		return nil, fmt.Errorf("Failed to read compressed proof: %w", err)
+// The inputs hash a proof is verified against, either given by the caller or
+// derived from the light header and the trusted validator set root.
+func verifyInputsHash(req *grpc.VerifyRequest) ([]byte, error) {
+	switch inputs := req.Inputs.(type) {
+	case *grpc.VerifyRequest_InputsHash:
+		return inputs.InputsHash, nil
+	case *grpc.VerifyRequest_LightHeader:
+		h := inputs.LightHeader.UntrustedHeader
+		if h == nil || h.Time == nil {
+			return nil, fmt.Errorf("The light header and its time are required")
+		}
+		header := inputshash.LightHeader{
+			ChainId:            h.ChainId,
+			Height:             h.Height,
+			Time:               h.Time.AsTime(),
+			ValidatorsHash:     h.ValidatorsHash,
+			NextValidatorsHash: h.NextValidatorsHash,
+			AppHash:            h.AppHash,
+		}
+		trustedValidatorsHash := inputs.LightHeader.TrustedValidatorSetRoot
+		if err := inputshash.Validate(header, trustedValidatorsHash); err != nil {
+			return nil, err
+		}
+		return inputshash.Hash(header, trustedValidatorsHash), nil
+	default:
+		return nil, fmt.Errorf("Either the inputs hash or the light header is required")
 	}
+}
 
-	witness := lcgadget.Circuit{
-		InputsHash: req.InputsHash,
-	}
+func (p *proverServer) Verify(ctx context.Context, req *grpc.VerifyRequest) (*grpc.VerifyResponse, error) {
+	log.Debug().Msg("Verifying...")
 
-	publicWitness, err := frontend.NewWitness(&witness, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	reqJson, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("Unable to create private witness: %w", err)
+		return nil, err
 	}
 
-	reqJson, err := json.Marshal(req)
+	inputsHash, err := verifyInputsHash(req)
 	if err != nil {
 		return nil, err
 	}
 
-	err = backend.Verify(
-		backend.Proof(&proof),
-		backend.VerifyingKey(&p.vk),
-		publicWitness,
-		backend_opts.WithVerifierHashToFieldFunction(&cometblsHashToField{}),
-	)
+	err = p.bundle.verify(req.Proof.CompressedContent, &lcgadget.Circuit{
+		InputsHash: inputsHash,
+	})
 
 	if err != nil {
-		log.Error().RawJSON("request", reqJson).Hex("inputs_hash", req.InputsHash).Str("action", "verify").Err(err).Send()
+		log.Error().RawJSON("request", reqJson).Hex("inputs_hash", inputsHash).Str("action", "verify").Err(err).Send()
 		return &grpc.VerifyResponse{
 			Valid: false,
 		}, nil
 	} else {
-		log.Info().RawJSON("request", reqJson).Hex("inputs_hash", req.InputsHash).Str("action", "verify").Send()
+		log.Info().RawJSON("request", reqJson).Hex("inputs_hash", inputsHash).Str("action", "verify").Send()
 		return &grpc.VerifyResponse{
 			Valid: true,
 		}, nil
@@ -451,7 +429,7 @@ func (p *proverServer) GenerateContract(ctx context.Context, req *grpc.GenerateC
 
 	var buffer bytes.Buffer
 	mem := bufio.NewWriter(&buffer)
-	err := p.vk.ExportSolidity(mem)
+	err := p.bundle.exportSolidity(mem)
 	if err != nil {
 		return nil, err
 	}
@@ -465,29 +443,7 @@ func (p *proverServer) GenerateContract(ctx context.Context, req *grpc.GenerateC
 func (p *proverServer) QueryStats(ctx context.Context, req *grpc.QueryStatsRequest) (*grpc.QueryStatsResponse, error) {
 	log.Debug().Msg("Querying stats...")
 
-	return &grpc.QueryStatsResponse{
-		VariableStats: &grpc.VariableStats{
-			NbInternalVariables: uint32(p.cs.GetNbInternalVariables()),
-			NbSecretVariables:   uint32(p.cs.GetNbSecretVariables()),
-			NbPublicVariables:   uint32(p.cs.GetNbPublicVariables()),
-			NbConstraints:       uint32(p.cs.GetNbConstraints()),
-			NbCoefficients:      uint32(p.cs.GetNbCoefficients()),
-		},
-		ProvingKeyStats: &grpc.ProvingKeyStats{
-			NbG1: uint32(p.pk.NbG1()),
-			NbG2: uint32(p.pk.NbG2()),
-		},
-		VerifyingKeyStats: &grpc.VerifyingKeyStats{
-			NbG1:            uint32(p.vk.NbG1()),
-			NbG2:            uint32(p.vk.NbG2()),
-			NbPublicWitness: uint32(p.vk.NbPublicWitness()),
-		},
-		// Deprecated
-		CommitmentStats: &grpc.CommitmentStats{
-			NbPublicCommitted:  uint32(0),
-			NbPrivateCommitted: uint32(0),
-		},
-	}, nil
+	return p.bundle.stats(), nil
 }
 
 // Deprecated in favor of the Poll api
@@ -511,112 +467,104 @@ func (p *proverServer) Prove(ctx context.Context, req *grpc.ProveRequest) (*grpc
 	panic("impossible; qed;")
 }
 
-func loadOrCreate(r1csPath string, pkPath string, vkPath string) (cs_bn254.R1CS, backend_bn254.ProvingKey, backend_bn254.VerifyingKey, error) {
-	cs := cs_bn254.R1CS{}
-	pk := backend_bn254.ProvingKey{}
-	vk := backend_bn254.VerifyingKey{}
-
-	if _, err := os.Stat(r1csPath); err == nil {
-		if _, err = os.Stat(pkPath); err == nil {
-			if _, err = os.Stat(vkPath); err == nil {
-				log.Info().Msg("Loading circuit...")
-
-				log.Debug().Msg("Loading R1CS...")
-				err := readFrom(r1csPath, constraint.R1CS(&cs))
-				if err != nil {
-					return cs, pk, vk, err
-				}
-
-				log.Debug().Msg("Loading proving key...")
-				err = readFrom(pkPath, backend.ProvingKey(&pk))
-				if err != nil {
-					return cs, pk, vk, err
-				}
-
-				log.Debug().Msg("Loading verifying key...")
-				err = readFrom(vkPath, backend.VerifyingKey(&vk))
-				if err != nil {
-					return cs, pk, vk, err
-				}
-
-				var commitmentKeyBytes bytes.Buffer
-				mem := bufio.NewWriter(&commitmentKeyBytes)
-				_, err = vk.CommitmentKey.WriteRawTo(mem)
-				if err != nil {
-					return cs, pk, vk, err
-				}
-				mem.Flush()
-				commitmentKey := commitmentKeyBytes.Bytes()
-
-				log.Debug().
-					Str("alpha", vk.G1.Alpha.String()).
-					Str("beta", vk.G1.Beta.String()).
-					Str("gamma", vk.G2.Gamma.String()).
-					Str("delta", vk.G2.Delta.String()).
-					Hex("pedersen", commitmentKey).
-					Msg("verifying_key")
-
-				return cs, pk, vk, nil
-			}
-		}
+// Prove that the trusted validator set signed two distinct headers at the same height
+func (p *proverServer) ProveMisbehaviour(ctx context.Context, req *grpc.ProveMisbehaviourRequest) (*grpc.ProveMisbehaviourResponse, error) {
+	if p.misbehaviour == nil {
+		return nil, fmt.Errorf("The misbehaviour circuit is not configured")
 	}
 
-	var circuit lcgadget.Circuit
-
-	log.Info().Msg("Compiling circuit...")
-	r1csInstance, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit, frontend.WithCompressThreshold(300))
-	if err != nil {
-		return cs, pk, vk, err
+	headers := []*grpc.ConflictingHeader{req.Header_1, req.Header_2}
+	for _, header := range headers {
+		if header == nil || header.Vote == nil || header.Header == nil || header.TrustedCommit == nil {
+			return nil, fmt.Errorf("Incomplete conflicting header")
+		}
+		if err := validateCommit(header.TrustedCommit); err != nil {
+			return nil, err
+		}
+	}
+	if req.Header_1.Header.ChainID != req.Header_2.Header.ChainID {
+		return nil, fmt.Errorf("Conflicting headers must belong to the same chain")
+	}
+	if req.Header_1.Header.Height != req.Header_2.Header.Height {
+		return nil, fmt.Errorf("Conflicting headers must be at the same height")
 	}
 
-	cs = *r1csInstance.(*cs_bn254.R1CS)
+	var conflictingHeaders [2]misbehaviour.ConflictingHeader
+	var blockHashes [2][]byte
+	var trustedValidatorsRoot []byte
+	for i, header := range headers {
+		trustedInput, validatorsRoot, err := commitInput(header.TrustedCommit)
+		if err != nil {
+			return nil, fmt.Errorf("Could not marshal trusted commit %s", err)
+		}
+		if trustedValidatorsRoot != nil && !bytes.Equal(trustedValidatorsRoot, validatorsRoot) {
+			return nil, fmt.Errorf("Conflicting headers must be signed by the same trusted validator set")
+		}
+		trustedValidatorsRoot = validatorsRoot
 
-	log.Debug().Msg("Setup PK/VK")
-	err = backend_bn254.Setup(&cs, &pk, &vk)
-	if err != nil {
-		return cs, pk, vk, err
+		cometblsHeader, err := comettypes.HeaderFromProto(header.Header)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid header %s", err)
+		}
+		blockHashes[i] = cometblsHeader.Hash()
+
+		conflictingHeaders[i] = misbehaviour.ConflictingHeader{
+			TrustedInput: trustedInput,
+			Vote:         toBlockVote(header.Vote),
+			Header:       toBlockHeader(header.Header),
+		}
+	}
+	if bytes.Equal(blockHashes[0], blockHashes[1]) {
+		return nil, fmt.Errorf("Conflicting headers must be distinct")
 	}
 
-	err = saveTo(r1csPath, r1csInstance)
-	if err != nil {
-		return cs, pk, vk, err
+	witness := misbehaviour.Circuit{
+		Header1:        conflictingHeaders[0],
+		Header2:        conflictingHeaders[1],
+		TrustedValRoot: trustedValidatorsRoot,
+		BlockHash1:     blockHashes[0],
+		BlockHash2:     blockHashes[1],
 	}
-	err = saveTo(pkPath, backend.ProvingKey(&pk))
-	if err != nil {
-		return cs, pk, vk, err
+
+	if !p.acquireJob() {
+		return nil, fmt.Errorf("busy_building")
 	}
-	err = saveTo(vkPath, backend.VerifyingKey(&vk))
+	defer p.releaseJob()
+
+	log.Debug().Hex("block_hash_1", blockHashes[0]).Hex("block_hash_2", blockHashes[1]).Msg("proving misbehaviour")
+	proof, err := p.misbehaviour.prove(&witness)
 	if err != nil {
-		return cs, pk, vk, err
+		log.Error().Str("action", "prove_misbehaviour").Hex("block_hash_1", blockHashes[0]).Hex("block_hash_2", blockHashes[1]).Err(err).Send()
+		return nil, fmt.Errorf("failed to generate proof: %v", err)
 	}
+	log.Info().Str("action", "prove_misbehaviour").Hex("block_hash_1", blockHashes[0]).Hex("block_hash_2", blockHashes[1]).Send()
+
+	return &grpc.ProveMisbehaviourResponse{
+		Proof:                   proof,
+		TrustedValidatorSetRoot: trustedValidatorsRoot,
+		BlockHash_1:             blockHashes[0],
+		BlockHash_2:             blockHashes[1],
+	}, nil
+}
 
-	var commitmentKeyBytes bytes.Buffer
-	mem := bufio.NewWriter(&commitmentKeyBytes)
-	_, err = vk.CommitmentKey.WriteRawTo(mem)
+func NewProverServer(maxJobs uint32, config BundleConfig) (*proverServer, error) {
+	bundle, err := loadOrCreate(config, &lcgadget.Circuit{})
 	if err != nil {
-		return cs, pk, vk, err
+		return nil, err
 	}
-	mem.Flush()
-	commitmentKey := commitmentKeyBytes.Bytes()
 
-	log.Debug().
-		Str("alpha", vk.G1.Alpha.String()).
-		Str("beta", vk.G1.Beta.String()).
-		Str("gamma", vk.G2.Gamma.String()).
-		Str("delta", vk.G2.Delta.String()).
-		Hex("pedersen", commitmentKey).
-		Msg("verifying_key")
-
-	return cs, pk, vk, nil
+	return &proverServer{bundle: bundle, maxJobs: maxJobs}, nil
 }
 
-func NewProverServer(maxJobs uint32, r1csPath string, pkPath string, vkPath string) (*proverServer, error) {
-	cs, pk, vk, err := loadOrCreate(r1csPath, pkPath, vkPath)
+// Load (or compile and setup) the misbehaviour circuit, enabling the ProveMisbehaviour api
+func (p *proverServer) WithMisbehaviour(config BundleConfig) error {
+	bundle, err := loadOrCreate(config, &misbehaviour.Circuit{})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &proverServer{cs: cs, pk: pk, vk: vk, maxJobs: maxJobs}, nil
+	p.misbehaviour = bundle
+	return nil
 }
 
 func readFrom(file string, obj io.ReaderFrom) error {
@@ -0,0 +1,354 @@
+package grpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	grpc "galois/grpc/api/v3"
+	lcgadget "galois/pkg/lightclient/nonadjacent"
+	"strings"
+	"time"
+
+	types "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Offsets of the points in an EVM proof: A‖B‖C‖commitment‖PoK
+const (
+	evmProofA          = 0
+	evmProofCommitment = 256
+	evmProofPoK        = 320
+	evmProofSize       = 384
+)
+
+// Offsets of the points in the raw encoding of a PLONK proof:
+// [L]1‖[R]1‖[O]1‖[Z]1‖…
+const (
+	plonkProofL = 0
+	plonkProofR = bn254.SizeOfG1AffineUncompressed
+	plonkProofZ = 3 * bn254.SizeOfG1AffineUncompressed
+)
+
+// A case every verifier implementation (Solidity, 11-cometbls...) must agree
+// on. The binary fields are hex encoded and the hashes are big endian scalar
+// field elements. A groth16 proof is given in the EVM layout along with its
+// commitments hash, a PLONK one in the raw gnark encoding light clients parse.
+type TestVector struct {
+	Description           string `json:"description"`
+	ChainID               string `json:"chain_id"`
+	Height                int64  `json:"height"`
+	TimeSeconds           int64  `json:"time_seconds"`
+	TimeNanos             int64  `json:"time_nanos"`
+	ValidatorsHash        string `json:"validators_hash"`
+	NextValidatorsHash    string `json:"next_validators_hash"`
+	AppHash               string `json:"app_hash"`
+	TrustedValidatorsHash string `json:"trusted_validators_hash"`
+	EvmProof              string `json:"evm_proof,omitempty"`
+	Proof                 string `json:"proof,omitempty"`
+	InputsHash            string `json:"inputs_hash"`
+	CommitmentsHash       string `json:"commitments_hash,omitempty"`
+	Valid                 bool   `json:"valid"`
+}
+
+// A corpus of test vectors along with the verifying key the proofs have been
+// generated with. The corpora without backend are groth16 ones.
+type TestVectors struct {
+	Backend      Backend      `json:"backend,omitempty"`
+	VerifyingKey string       `json:"verifying_key"`
+	Vectors      []TestVector `json:"vectors"`
+}
+
+// The light header and the proof a verifier is given
+type vectorInputs struct {
+	chainID               string
+	header                types.Header
+	trustedValidatorsHash []byte
+	backend               Backend
+	proof                 []byte
+}
+
+func (v vectorInputs) encode(description string, valid bool) TestVector {
+	var inputsHash fr.Element
+	inputsHash.SetBytes(InputsHash(v.chainID, &v.header, v.trustedValidatorsHash))
+	inputsHashBytes := inputsHash.Bytes()
+	vector := TestVector{
+		Description:           description,
+		ChainID:               v.chainID,
+		Height:                v.header.Height,
+		TimeSeconds:           v.header.Time.Unix(),
+		TimeNanos:             int64(v.header.Time.Nanosecond()),
+		ValidatorsHash:        hex.EncodeToString(v.header.ValidatorsHash),
+		NextValidatorsHash:    hex.EncodeToString(v.header.NextValidatorsHash),
+		AppHash:               hex.EncodeToString(v.header.AppHash),
+		TrustedValidatorsHash: hex.EncodeToString(v.trustedValidatorsHash),
+		InputsHash:            hex.EncodeToString(inputsHashBytes[:]),
+		Valid:                 valid,
+	}
+	if v.backend == BackendGroth16 {
+		commitmentsHash := cometbn254.HashToField(v.proof[evmProofCommitment:evmProofPoK])
+		commitmentsHashBytes := commitmentsHash.Bytes()
+		vector.EvmProof = hex.EncodeToString(v.proof)
+		vector.CommitmentsHash = hex.EncodeToString(commitmentsHashBytes[:])
+	} else {
+		vector.Proof = hex.EncodeToString(v.proof)
+	}
+	return vector
+}
+
+func decodeVector(backend Backend, vector TestVector) (vectorInputs, error) {
+	decode := func(field string, s string) ([]byte, error) {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("Could not decode the %s of '%s': %w", field, vector.Description, err)
+		}
+		return b, nil
+	}
+	v := vectorInputs{
+		chainID: vector.ChainID,
+		header: types.Header{
+			Height: vector.Height,
+			Time:   time.Unix(vector.TimeSeconds, vector.TimeNanos),
+		},
+		backend: backend,
+	}
+	var err error
+	if v.header.ValidatorsHash, err = decode("validators hash", vector.ValidatorsHash); err != nil {
+		return vectorInputs{}, err
+	}
+	if v.header.NextValidatorsHash, err = decode("next validators hash", vector.NextValidatorsHash); err != nil {
+		return vectorInputs{}, err
+	}
+	if v.header.AppHash, err = decode("app hash", vector.AppHash); err != nil {
+		return vectorInputs{}, err
+	}
+	if v.trustedValidatorsHash, err = decode("trusted validators hash", vector.TrustedValidatorsHash); err != nil {
+		return vectorInputs{}, err
+	}
+	switch backend {
+	case BackendGroth16:
+		if v.proof, err = decode("proof", vector.EvmProof); err != nil {
+			return vectorInputs{}, err
+		}
+		if len(v.proof) != evmProofSize {
+			return vectorInputs{}, fmt.Errorf("Expected an EVM proof of %d bytes for '%s', got: %d", evmProofSize, vector.Description, len(v.proof))
+		}
+	case BackendPlonk:
+		if v.proof, err = decode("proof", vector.Proof); err != nil {
+			return vectorInputs{}, err
+		}
+		if len(v.proof) <= plonkProofZ+bn254.SizeOfG1AffineUncompressed {
+			return vectorInputs{}, fmt.Errorf("The PLONK proof of '%s' is too short: %d bytes", vector.Description, len(v.proof))
+		}
+	default:
+		return vectorInputs{}, fmt.Errorf("Unknown backend: %s", backend)
+	}
+	return v, nil
+}
+
+func flipLastBit(b []byte) []byte {
+	flipped := append([]byte{}, b...)
+	flipped[len(flipped)-1] ^= 1
+	return flipped
+}
+
+func negateG1(b []byte) error {
+	var p bn254.G1Affine
+	_, err := p.SetBytes(b[:bn254.SizeOfG1AffineUncompressed])
+	if err != nil {
+		return err
+	}
+	p.Neg(&p)
+	copy(b, p.Marshal())
+	return nil
+}
+
+func swap(b []byte, i int, j int, size int) {
+	tmp := append([]byte{}, b[i:i+size]...)
+	copy(b[i:], b[j:j+size])
+	copy(b[j:], tmp)
+}
+
+type vectorMutation struct {
+	description string
+	mutate      func(v *vectorInputs) error
+}
+
+// Invalid cases derived from a valid one, either the light header or the
+// proof is tampered with. The tampered points remain on the curve so that
+// the verification, and not the decoding, is what fails.
+var mutations = []vectorMutation{
+	{"wrong chain id", func(v *vectorInputs) error {
+		v.chainID = v.chainID + "-1"
+		return nil
+	}},
+	{"chain id longer than 31 bytes", func(v *vectorInputs) error {
+		v.chainID = fmt.Sprintf("%-32s", v.chainID)
+		return nil
+	}},
+	{"wrong height", func(v *vectorInputs) error {
+		v.header.Height++
+		return nil
+	}},
+	{"wrong time", func(v *vectorInputs) error {
+		v.header.Time = v.header.Time.Add(time.Nanosecond)
+		return nil
+	}},
+	{"wrong validators hash", func(v *vectorInputs) error {
+		v.header.ValidatorsHash = flipLastBit(v.header.ValidatorsHash)
+		return nil
+	}},
+	{"wrong next validators hash", func(v *vectorInputs) error {
+		v.header.NextValidatorsHash = flipLastBit(v.header.NextValidatorsHash)
+		return nil
+	}},
+	{"wrong app hash", func(v *vectorInputs) error {
+		v.header.AppHash = flipLastBit(v.header.AppHash)
+		return nil
+	}},
+	{"wrong trusted validators hash", func(v *vectorInputs) error {
+		v.trustedValidatorsHash = flipLastBit(v.trustedValidatorsHash)
+		return nil
+	}},
+}
+
+// The mutations of the proof, whose layout depends on the backend
+var proofMutations = map[Backend][]vectorMutation{
+	BackendGroth16: {
+		{"negated proof A", func(v *vectorInputs) error {
+			return negateG1(v.proof[evmProofA:])
+		}},
+		{"swapped proof commitment and PoK", func(v *vectorInputs) error {
+			swap(v.proof, evmProofCommitment, evmProofPoK, evmProofSize-evmProofPoK)
+			return nil
+		}},
+	},
+	BackendPlonk: {
+		{"negated proof Z", func(v *vectorInputs) error {
+			return negateG1(v.proof[plonkProofZ:])
+		}},
+		{"swapped proof L and R", func(v *vectorInputs) error {
+			swap(v.proof, plonkProofL, plonkProofR, bn254.SizeOfG1AffineUncompressed)
+			return nil
+		}},
+	},
+}
+
+// Prefix of the description of a valid vector, followed by where the proof
+// comes from
+const validPrefix = "valid: "
+
+func deriveVectors(valid vectorInputs, source string) ([]TestVector, error) {
+	vectors := []TestVector{valid.encode(validPrefix+source, true)}
+	for _, mutation := range append(mutations, proofMutations[valid.backend]...) {
+		invalid := valid
+		invalid.proof = append([]byte{}, valid.proof...)
+		err := mutation.mutate(&invalid)
+		if err != nil {
+			return nil, fmt.Errorf("Could not derive '%s' from '%s': %w", mutation.description, source, err)
+		}
+		vectors = append(vectors, invalid.encode(mutation.description+": "+source, false))
+	}
+	return vectors, nil
+}
+
+// Derive the invalid cases from a valid vector of a corpus of the given
+// backend again, e.g. after adding a mutation, without proving.
+func DeriveTestVectors(backend Backend, valid TestVector) ([]TestVector, error) {
+	if !valid.Valid {
+		return nil, fmt.Errorf("Can't derive test vectors from the invalid case '%s'", valid.Description)
+	}
+	if backend == "" {
+		backend = BackendGroth16
+	}
+	v, err := decodeVector(backend, valid)
+	if err != nil {
+		return nil, err
+	}
+	return deriveVectors(v, strings.TrimPrefix(valid.Description, validPrefix))
+}
+
+// Prove the request with the loaded bundle and derive the test vectors from
+// the proof, the first vector being the valid one. The source names where the
+// proof comes from in the descriptions.
+func (p *proverServer) TestVectors(req *grpc.ProveRequest, source string) ([]TestVector, error) {
+	return testVectors(p.bundle, req, source, func(witness *lcgadget.Circuit) frontend.Circuit {
+		return witness
+	})
+}
+
+// Stands for the light client circuit in the corpora of the PLONK verifiers,
+// as the setup of the light client circuit doesn't fit in a development
+// machine. It has the same single public input, the inputs hash, and commits
+// to it so that its proofs carry a BSB22 commitment like the light client ones.
+type standInCircuit struct {
+	Preimage   frontend.Variable
+	InputsHash frontend.Variable `gnark:",public"`
+}
+
+func (c *standInCircuit) Define(api frontend.API) error {
+	committer, ok := api.(frontend.Committer)
+	if !ok {
+		return fmt.Errorf("The builder doesn't support commitments")
+	}
+	commitment, err := committer.Commit(c.Preimage)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(commitment, 0)
+	api.AssertIsEqual(c.Preimage, c.InputsHash)
+	return nil
+}
+
+// Same as TestVectors with the stand-in circuit, loaded (or compiled and
+// setup) from the given bundle instead of the light client one.
+func StandInTestVectors(config BundleConfig, req *grpc.ProveRequest, source string) ([]TestVector, error) {
+	bundle, err := loadOrCreate(config, &standInCircuit{})
+	if err != nil {
+		return nil, err
+	}
+	return testVectors(bundle, req, source, func(witness *lcgadget.Circuit) frontend.Circuit {
+		return &standInCircuit{
+			Preimage:   witness.InputsHash,
+			InputsHash: witness.InputsHash,
+		}
+	})
+}
+
+func testVectors(bundle circuitBundle, req *grpc.ProveRequest, source string, assign func(*lcgadget.Circuit) frontend.Circuit) ([]TestVector, error) {
+	if err := validateCommit(req.TrustedCommit); err != nil {
+		return nil, err
+	}
+	if err := validateCommit(req.UntrustedCommit); err != nil {
+		return nil, err
+	}
+
+	witness, trustedValidatorsHash, err := nonAdjacentWitness(req)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := bundle.prove(assign(witness))
+	if err != nil {
+		return nil, err
+	}
+
+	valid := vectorInputs{
+		chainID:               req.Vote.ChainID,
+		header:                *req.UntrustedHeader,
+		trustedValidatorsHash: trustedValidatorsHash,
+	}
+	switch proof.ProofType {
+	case grpc.ProofType_GROTH16:
+		if len(proof.EvmProof) != evmProofSize {
+			return nil, fmt.Errorf("Expected an EVM proof of %d bytes, got: %d", evmProofSize, len(proof.EvmProof))
+		}
+		valid.backend, valid.proof = BackendGroth16, proof.EvmProof
+	case grpc.ProofType_PLONK:
+		// The Solidity encoding omits a claimed value the light clients check
+		valid.backend, valid.proof = BackendPlonk, proof.Content
+	default:
+		return nil, fmt.Errorf("Unknown proof type: %s", proof.ProofType)
+	}
+	return deriveVectors(valid, source)
+}
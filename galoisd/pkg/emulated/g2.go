@@ -1,6 +1,7 @@
 package g2
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -15,6 +16,8 @@ import (
 
 const (
 	MiMCBlockSize = 256
+	// L = ceil((ceil(log2(p)) + k) / 8), k = 128 being the security parameter
+	HashToFieldSize = 48
 )
 
 var (
@@ -25,6 +28,32 @@ var (
 	// bTwistCurveCoeff b coeff of the twist (defined over 𝔽p²) curve
 	bTwistCurveCoeff bn254.E2
 	B                fields_bn254.E2
+
+	// SvdW constants
+	// c1 = g(Z)
+	// c2 = -Z / 2
+	// c3 = sqrt(-g(Z) * (3 * Z² + 4 * A))     # sgn0(c3) MUST equal 0
+	// c4 = -4 * g(Z) / (3 * Z² + 4 * A)
+	svdwZ = bn254.E2{
+		A0: fp.Element{15230403791020821917, 754611498739239741, 7381016538464732716, 1011752739694698287},
+		A1: fp.Element{0},
+	}
+	svdwC1 = bn254.E2{
+		A0: fp.Element{15219334786797146878, 8431472696017589261, 15336528771359260718, 196732871012706162},
+		A1: fp.Element{4100506350182530919, 7345568344173317438, 15513160039642431658, 90557763186888013},
+	}
+	svdwC2 = bn254.E2{
+		A0: fp.Element{12997850613838968789, 14304628359724097447, 2950087706404981016, 1237622763554136189},
+		A1: fp.Element{0},
+	}
+	svdwC3 = bn254.E2{
+		A0: fp.Element{12298500088583694207, 17447120171744064890, 14097510924717921191, 2278398337453771183},
+		A1: fp.Element{4693446565795584099, 18320164443970680666, 6792758484113206563, 2989688171181581768},
+	}
+	svdwC4 = bn254.E2{
+		A0: fp.Element{7191623630069643826, 8333948550768170742, 13001081703983517696, 2062355016518372226},
+		A1: fp.Element{11163104453509316115, 7271947710149976975, 4894807947557820282, 3366254582553786647},
+	}
 )
 
 func init() {
@@ -58,10 +87,10 @@ func hintSqrt(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error
 		})
 }
 
-// Hint legendre, caller must check that the result is valid, i.e. sqrt and verify root or no root
-// Return 1 if square, 0 otherwise
+// Hint legendre, caller must check that the result is valid, see isSquare
+// Return 1 if square (including zero), 0 otherwise
 func hintLegendre(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
-	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+	return emulated.UnwrapHintWithNativeOutput(nativeInputs, nativeOutputs,
 		func(mod *big.Int, inputs, outputs []*big.Int) error {
 			var a bn254.E2
 
@@ -72,6 +101,8 @@ func hintLegendre(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) er
 
 			if x == -1 {
 				x = 0
+			} else {
+				x = 1
 			}
 
 			outputs[0] = big.NewInt(int64(x))
@@ -122,14 +153,24 @@ func (e *EmulatedAPI) AssertIsEqual(p, q *gadget.G2Affine) {
 	e.ext2.AssertIsEqual(&p.P.Y, &q.P.Y)
 }
 
+// Reduce x to its canonical representation, i.e. strictly less than the modulus
+func (e *EmulatedAPI) canonical(x *emulated.Element[emulated.BN254Fp]) *emulated.Element[emulated.BN254Fp] {
+	r := e.field.Reduce(x)
+	e.field.AssertIsInRange(r)
+	return r
+}
+
 // https://datatracker.ietf.org/doc/html/rfc9380#name-the-sgn0-function
+//
+// The parity must be computed on the canonical representation, otherwise the
+// prover could flip it by providing x + p.
 func (e *EmulatedAPI) g2Sgn0Circuit(z *fields_bn254.E2) frontend.Variable {
-	a0b := e.field.ToBits(&z.A0)
+	a0b := e.field.ToBits(e.canonical(&z.A0))
 
 	sign_0 := a0b[0]
 	zero_0 := e.field.IsZero(&z.A0)
 
-	a1b := e.field.ToBits(&z.A1)
+	a1b := e.field.ToBits(e.canonical(&z.A1))
 
 	sign_1 := a1b[0]
 	sign := e.api.Or(sign_0, e.api.And(zero_0, sign_1))
@@ -137,78 +178,63 @@ func (e *EmulatedAPI) g2Sgn0Circuit(z *fields_bn254.E2) frontend.Variable {
 	return sign
 }
 
-// Union whitepaper: (2) svdw
-//
-// https://datatracker.ietf.org/doc/html/rfc9380#straightline-svdw
-//
-// WARNING: this function is partial, the legendre hint only check the root branch. If you are going to reuse this function, make sure you are aware of this edge case.
-func (e *EmulatedAPI) MapToCurve(u *fields_bn254.E2) *gadget.G2Affine {
-	// NOTE: up to the caller to call legendre if the root is not guarantee to exist
-	sqrt := func(x *fields_bn254.E2) *fields_bn254.E2 {
-		roots, err := e.field.NewHint(hintSqrt, 2, &x.A0, &x.A1)
-		if err != nil {
-			panic(err)
-		}
-		root := &fields_bn254.E2{
-			A0: *roots[0],
-			A1: *roots[1],
-		}
-		// Ensure valid root
-		e.ext2.AssertIsEqual(x, e.ext2.Square(root))
-		return root
+// Returns a square root of x, failing if x is not a square
+func (e *EmulatedAPI) sqrt(x *fields_bn254.E2) *fields_bn254.E2 {
+	roots, err := e.field.NewHint(hintSqrt, 2, &x.A0, &x.A1)
+	if err != nil {
+		panic(err)
+	}
+	root := &fields_bn254.E2{
+		A0: *roots[0],
+		A1: *roots[1],
 	}
+	// Ensure valid root
+	e.ext2.AssertIsEqual(x, e.ext2.Square(root))
+	return root
+}
 
-	legendre := func(x *fields_bn254.E2) (frontend.Variable, *fields_bn254.E2) {
-		legendres, err := e.field.NewHint(hintLegendre, 1, &x.A0, &x.A1)
-		if err != nil {
-			panic(err)
-		}
-		legendre := legendres[0].Limbs[0]
-		roots, err := e.field.NewHint(hintSqrt, 2, &x.A0, &x.A1)
-		if err != nil {
-			panic(err)
-		}
-		root := &fields_bn254.E2{
-			A0: *roots[0],
-			A1: *roots[1],
-		}
-		// Ensure valid legendre
-		e.api.AssertIsBoolean(legendre)
-		// Ensure valid branch
-		e.ext2.AssertIsEqual(x, e.ext2.Select(legendre, e.ext2.Square(root), x))
-		return legendre, root
+// https://datatracker.ietf.org/doc/html/rfc9380#name-utility-functions is_square
+//
+// The hint claims whether x is a square, the claim is then proven for both
+// branches by exhibiting a square root of either x or ξx. ξ = 9 + u being a
+// quadratic non residue of 𝔽p², ξx is a square if and only if x is not.
+func (e *EmulatedAPI) isSquare(x *fields_bn254.E2) frontend.Variable {
+	legendres, err := e.field.NewHintWithNativeOutput(hintLegendre, 1, &x.A0, &x.A1)
+	if err != nil {
+		panic(err)
 	}
+	isSquare := legendres[0]
+	e.assertIsSquare(x, isSquare)
+	return isSquare
+}
+
+func (e *EmulatedAPI) assertIsSquare(x *fields_bn254.E2, isSquare frontend.Variable) {
+	e.api.AssertIsBoolean(isSquare)
+	// Zero is a square, the non square branch would otherwise accept it as ξ0 = 0
+	e.api.AssertIsEqual(e.api.And(e.ext2.IsZero(x), e.api.Sub(1, isSquare)), 0)
+	e.sqrt(e.ext2.Select(isSquare, x, e.ext2.MulByNonResidue(x)))
+}
+
+// https://datatracker.ietf.org/doc/html/rfc9380#name-utility-functions inv0
+func (e *EmulatedAPI) inv0(x *fields_bn254.E2) *fields_bn254.E2 {
+	isZero := e.ext2.IsZero(x)
+	inv := e.ext2.Inverse(e.ext2.Select(isZero, e.ext2.One(), x))
+	return e.ext2.Select(isZero, e.ext2.Zero(), inv)
+}
 
+// Union whitepaper: (2) svdw
+//
+// https://datatracker.ietf.org/doc/html/rfc9380#straightline-svdw
+func (e *EmulatedAPI) MapToCurve(u *fields_bn254.E2) *gadget.G2Affine {
 	var tv1, tv2, tv3, tv4 *fields_bn254.E2
 	var x1, x2, x3, gx1, gx2, gx, x, y *fields_bn254.E2
 	var one *fields_bn254.E2
 
-	//constants
-	//c1 = g(Z)
-	//c2 = -Z / 2
-	//c3 = sqrt(-g(Z) * (3 * Z² + 4 * A))     # sgn0(c3) MUST equal 0
-	//c4 = -4 * g(Z) / (3 * Z² + 4 * A)
-
-	Z := fields_bn254.FromE2(&bn254.E2{
-		A0: fp.Element{15230403791020821917, 754611498739239741, 7381016538464732716, 1011752739694698287},
-		A1: fp.Element{0},
-	})
-	c1 := fields_bn254.FromE2(&bn254.E2{
-		A0: fp.Element{15219334786797146878, 8431472696017589261, 15336528771359260718, 196732871012706162},
-		A1: fp.Element{4100506350182530919, 7345568344173317438, 15513160039642431658, 90557763186888013},
-	})
-	c2 := fields_bn254.FromE2(&bn254.E2{
-		A0: fp.Element{12997850613838968789, 14304628359724097447, 2950087706404981016, 1237622763554136189},
-		A1: fp.Element{0},
-	})
-	c3 := fields_bn254.FromE2(&bn254.E2{
-		A0: fp.Element{12298500088583694207, 17447120171744064890, 14097510924717921191, 2278398337453771183},
-		A1: fp.Element{4693446565795584099, 18320164443970680666, 6792758484113206563, 2989688171181581768},
-	})
-	c4 := fields_bn254.FromE2(&bn254.E2{
-		A0: fp.Element{7191623630069643826, 8333948550768170742, 13001081703983517696, 2062355016518372226},
-		A1: fp.Element{11163104453509316115, 7271947710149976975, 4894807947557820282, 3366254582553786647},
-	})
+	Z := fields_bn254.FromE2(&svdwZ)
+	c1 := fields_bn254.FromE2(&svdwC1)
+	c2 := fields_bn254.FromE2(&svdwC2)
+	c3 := fields_bn254.FromE2(&svdwC3)
+	c4 := fields_bn254.FromE2(&svdwC4)
 
 	one = e.ext2.One()
 
@@ -223,7 +249,7 @@ func (e *EmulatedAPI) MapToCurve(u *fields_bn254.E2) *gadget.G2Affine {
 	// 5.  tv3 = tv1 * tv2
 	tv3 = e.ext2.Mul(tv1, tv2)
 	// 6.  tv3 = inv0(tv3)
-	tv3 = e.ext2.Inverse(tv3)
+	tv3 = e.inv0(tv3)
 	// 7.  tv4 = u * tv1
 	tv4 = e.ext2.Mul(u, tv1)
 	// 8.  tv4 = tv4 * tv3
@@ -241,7 +267,7 @@ func (e *EmulatedAPI) MapToCurve(u *fields_bn254.E2) *gadget.G2Affine {
 	// 14. gx1 = gx1 + B
 	gx1 = e.ext2.Add(gx1, &B)
 	// 15.  e1 = is_square(gx1)
-	e1, _ := legendre(gx1)
+	e1 := e.isSquare(gx1)
 	// 16.  x2 = c2 + tv4
 	x2 = e.ext2.Add(&c2, tv4)
 	// 17. gx2 = x2^2
@@ -253,7 +279,7 @@ func (e *EmulatedAPI) MapToCurve(u *fields_bn254.E2) *gadget.G2Affine {
 	// 20. gx2 = gx2 + B
 	gx2 = e.ext2.Add(gx2, &B)
 	// 21.  e2 = is_square(gx2) AND NOT e1   # Avoid short-circuit logic ops
-	gx2Square, _ := legendre(gx2)
+	gx2Square := e.isSquare(gx2)
 	e2 := e.api.And(gx2Square, e.api.Select(e1, 0, 1))
 	// 22.  x3 = tv2^2
 	x3 = e.ext2.Square(tv2)
@@ -278,7 +304,7 @@ func (e *EmulatedAPI) MapToCurve(u *fields_bn254.E2) *gadget.G2Affine {
 	// 32.  gx = gx + B
 	gx = e.ext2.Add(gx, &B)
 	// 33.   y = sqrt(gx)
-	y = sqrt(gx)
+	y = e.sqrt(gx)
 	// 34.  e3 = sgn0(u) == sgn0(y)
 	e3 := e.api.IsZero(e.api.Xor(e.g2Sgn0Circuit(u), e.g2Sgn0Circuit(y)))
 	// 35.   y = CMOV(-y, y, e3)       # Select correct sign of y
@@ -475,13 +501,27 @@ func (e *EmulatedAPI) ClearCofactor(Q *gadget.G2Affine) *gadget.G2Affine {
 // Union whitepaper: (1), (2) M ◦ H_{mimc^4}
 //
 // https://datatracker.ietf.org/doc/html/rfc9380#name-encoding-byte-strings-to-el
-
-// WARNING: this function calls a partial MapToCurve, read it's documentation before using it.
+//
+// The message and domain separation tag are bn254 F_r elements (usually MiMC
+// hashes), see HashToG2Bytes for arbitrary byte strings.
 func (e *EmulatedAPI) HashToG2(message frontend.Variable, dst frontend.Variable) (*gadget.G2Affine, error) {
 	u, err := e.HashToField(message, dst)
 	if err != nil {
 		return nil, err
 	}
+	return e.mapToG2(u), nil
+}
+
+// Same as HashToG2, over arbitrary byte strings (one byte per variable).
+func (e *EmulatedAPI) HashToG2Bytes(message []frontend.Variable, dst []frontend.Variable) (*gadget.G2Affine, error) {
+	u, err := e.HashToFieldBytes(message, dst, 4)
+	if err != nil {
+		return nil, err
+	}
+	return e.mapToG2(u), nil
+}
+
+func (e *EmulatedAPI) mapToG2(u []*emulated.Element[emulated.BN254Fp]) *gadget.G2Affine {
 	Q0 := e.MapToCurve(&fields_bn254.E2{
 		A0: *u[0],
 		A1: *u[1],
@@ -490,50 +530,90 @@ func (e *EmulatedAPI) HashToG2(message frontend.Variable, dst frontend.Variable)
 		A0: *u[2],
 		A1: *u[3],
 	})
-	return e.ClearCofactor(e.Add(Q0, Q1)), nil
+	return e.ClearCofactor(e.Add(Q0, Q1))
 }
 
 // Union whitepaper: (1), (2) M ◦ H_{mimc^4}
 //
 // https://datatracker.ietf.org/doc/html/rfc9380#name-hash_to_field-implementatio
-// /
-// WARNING: /!\ Tailored for 4 field elements (actually scalar field because of the underlying MiMC hash function).
+//
 // WARNING: this functions uses a 256bit block MiMC (which is in fact only 254bit), use it at your own risk.
-// WARNING: we only support 254bit messages (usually MiMC hash) and domain separation tag (usually MiMC hash).
 func (e *EmulatedAPI) HashToField(message frontend.Variable, dst frontend.Variable) ([]*emulated.Element[emulated.BN254Fp], error) {
 	pseudoRandomBits, err := e.ExpandMsgXmd(message, dst)
 	if err != nil {
 		return nil, err
 	}
-	elements := make([]*emulated.Element[emulated.BN254Fp], 4)
-	for i := 0; i < 4; i++ {
+	return e.hashToField(pseudoRandomBits, 4), nil
+}
+
+// Same as HashToField, over arbitrary byte strings (one byte per variable)
+// and for any number of field elements.
+func (e *EmulatedAPI) HashToFieldBytes(message []frontend.Variable, dst []frontend.Variable, count int) ([]*emulated.Element[emulated.BN254Fp], error) {
+	pseudoRandomBits, err := e.ExpandMsgXmdBytes(message, dst, count*HashToFieldSize)
+	if err != nil {
+		return nil, err
+	}
+	return e.hashToField(pseudoRandomBits, count), nil
+}
+
+func (e *EmulatedAPI) hashToField(pseudoRandomBits []frontend.Variable, count int) []*emulated.Element[emulated.BN254Fp] {
+	elements := make([]*emulated.Element[emulated.BN254Fp], count)
+	for i := 0; i < count; i++ {
 		// The modulus is 31<m<32 bytes, we split the random bytes in two chunks [0..17] and [17..48] then recombine them.
 		// We split at 17 and not 31 because of endianness.
-		elemBits := pseudoRandomBits[i*48*8 : (i+1)*48*8]
+		elemBits := pseudoRandomBits[i*HashToFieldSize*8 : (i+1)*HashToFieldSize*8]
 		splitPoint := int64(17)
 		l := e.field.FromBits(elemBits[:splitPoint*8]...)
 		r := e.field.FromBits(elemBits[splitPoint*8:]...)
 		c := emulated.ValueOf[emulated.BN254Fp](new(big.Int).Lsh(big.NewInt(1), uint(splitPoint*8)))
 		elements[i] = e.field.Add(l, e.field.Mul(r, &c))
 	}
-	return elements, nil
+	return elements
 }
 
-// /!\ IMPORTANT /!\ : This is not a general implementation as the input/output length are fixed.
-// It is a tailor-made for BN254G2_XMD:MiMC-256_SVDW hash_to_curve implementation.
+// It is a tailor-made for BN254G2_XMD:MiMC-256_SVDW hash_to_curve implementation,
+// producing the 192 bytes required by HashToField, see ExpandMsgXmdBytes for
+// the general version.
 //
 // WARNING: this functions uses a 256bit block MiMC (effectively 254bit with modulus happening), use it at your own risk.
-// WARNING: we only support ~254bit messages and domain separation tag (both bn254 F_r elements).
+// WARNING: the message and domain separation tag are bn254 F_r elements, written as 32 little endian bytes.
 //
 // https://datatracker.ietf.org/doc/html/rfc9380#name-expand_message_xmd
 // https://datatracker.ietf.org/doc/html/rfc9380#name-utility-functions (I2OSP/O2ISP)
 // https://eprint.iacr.org/2016/492.pdf
 func (e *EmulatedAPI) ExpandMsgXmd(message frontend.Variable, dst frontend.Variable) ([]frontend.Variable, error) {
+	return e.expandMsgXmd(e.api.ToBinary(message, 256), e.api.ToBinary(dst, 256), 4*HashToFieldSize)
+}
+
+// expand_message_xmd over arbitrary byte strings (one byte per variable, the
+// lengths being fixed at compilation). The result is returned as bits, each
+// byte being little endian.
+func (e *EmulatedAPI) ExpandMsgXmdBytes(message []frontend.Variable, dst []frontend.Variable, lenInBytes int) ([]frontend.Variable, error) {
+	toBits := func(bytes []frontend.Variable) []frontend.Variable {
+		bits := make([]frontend.Variable, 0, len(bytes)*8)
+		for _, b := range bytes {
+			bits = append(bits, e.api.ToBinary(b, 8)...)
+		}
+		return bits
+	}
+	return e.expandMsgXmd(toBits(message), toBits(dst), lenInBytes)
+}
+
+func (e *EmulatedAPI) expandMsgXmd(message []frontend.Variable, dst []frontend.Variable, lenInBytes int) ([]frontend.Variable, error) {
 	h, err := mimc.NewMiMC(e.api)
 	if err != nil {
 		return nil, err
 	}
 
+	// ceil(len_in_bytes / b_in_bytes)
+	ell := (lenInBytes + MiMCBlockSize/8 - 1) / (MiMCBlockSize / 8)
+	if ell > 255 || lenInBytes > 65535 {
+		return nil, fmt.Errorf("invalid lenInBytes: %d", lenInBytes)
+	}
+	if len(dst) > 255*8 {
+		return nil, fmt.Errorf("invalid domain size (>255 bytes): %d", len(dst)/8)
+	}
+
 	block := []frontend.Variable{}
 	write := func(b ...frontend.Variable) {
 		block = append(block, b...)
@@ -563,25 +643,21 @@ func (e *EmulatedAPI) ExpandMsgXmd(message frontend.Variable, dst frontend.Varia
 		write(e.api.ToBinary(x, 8)...)
 	}
 
-	write_message := func() {
-		write(e.api.ToBinary(message, 256)...)
-	}
-
 	// Z_pad = I2OSP(0, r_in_bytes)
 	write_Z_pad := func() {
-		repeat(0, 256)
+		repeat(0, MiMCBlockSize)
 	}
 
 	// l_i_b_str = I2OSP(len_in_bytes, 2)
 	write_l_i_b_str := func() {
-		writeU8(192 >> 8)
-		writeU8(192)
+		writeU8(lenInBytes >> 8)
+		writeU8(lenInBytes & 0xFF)
 	}
 
 	// DST_prime =  DST ∥ I2OSP(len(DST), 1)
 	write_DST_prime := func() {
-		write(e.api.ToBinary(dst, 256)...)
-		writeU8(32)
+		write(dst...)
+		writeU8(len(dst) / 8)
 	}
 
 	// Z_pad = I2OSP(0, r_in_bytes)
@@ -589,7 +665,7 @@ func (e *EmulatedAPI) ExpandMsgXmd(message frontend.Variable, dst frontend.Varia
 	// DST_prime =  DST ∥ I2OSP(len(DST), 1)
 	// b₀ = H(Z_pad ∥ msg ∥ l_i_b_str ∥ I2OSP(0, 1) ∥ DST_prime)
 	write_Z_pad()
-	write_message()
+	write(message...)
 	write_l_i_b_str()
 	writeU8(0)
 	write_DST_prime()
@@ -601,26 +677,23 @@ func (e *EmulatedAPI) ExpandMsgXmd(message frontend.Variable, dst frontend.Varia
 	write_DST_prime()
 	b1 := sum()
 
-	res := make([]frontend.Variable, 192*8)
-	for i := 0; i < len(res); i++ {
-		res[i] = 0
-	}
-	copy(res, b1)
+	res := make([]frontend.Variable, 0, ell*MiMCBlockSize)
+	res = append(res, b1...)
 
-	for i := 1; i <= 5; i++ {
-		strxor := make([]frontend.Variable, 256)
-		for j := 0; j < 256; j++ {
+	for i := 2; i <= ell; i++ {
+		strxor := make([]frontend.Variable, MiMCBlockSize)
+		for j := 0; j < MiMCBlockSize; j++ {
 			strxor[j] = e.api.Xor(b0[j], b1[j])
 		}
 
 		// b_i = H(strxor(b₀, b_(i - 1)) ∥ I2OSP(i, 1) ∥ DST_prime)
 		write(strxor...)
-		writeU8(i + 1)
+		writeU8(i)
 		write_DST_prime()
 		b1 = sum()
 
-		copy(res[i*256:(i+1)*256], b1)
+		res = append(res, b1...)
 	}
 
-	return res, nil
+	return res[:lenInBytes*8], nil
 }
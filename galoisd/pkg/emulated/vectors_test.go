@@ -0,0 +1,650 @@
+package g2
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	cometbft_bn254 "github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bn254"
+	gadget "github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/assert"
+)
+
+type svdwBranch int
+
+const (
+	branchX1 svdwBranch = iota + 1
+	branchX2
+	branchX3
+)
+
+// Native counterpart of the MapToCurve branch selection, returns the branch
+// taken by u and whether u hits the exceptional case of inv0.
+func svdwBranchOf(u *curve.E2) (svdwBranch, bool) {
+	var tv1, tv2, tv3, tv4, x1, x2, gx1, gx2, one curve.E2
+	one.SetOne()
+	tv1.Square(u)
+	tv1.Mul(&tv1, &svdwC1)
+	tv2.Add(&one, &tv1)
+	tv1.Sub(&one, &tv1)
+	tv3.Mul(&tv1, &tv2)
+	exceptional := tv3.IsZero()
+	tv3.Inverse(&tv3)
+	tv4.Mul(u, &tv1)
+	tv4.Mul(&tv4, &tv3)
+	tv4.Mul(&tv4, &svdwC3)
+	x1.Sub(&svdwC2, &tv4)
+	gx1.Square(&x1).Mul(&gx1, &x1).Add(&gx1, &bTwistCurveCoeff)
+	if gx1.Legendre() >= 0 {
+		return branchX1, exceptional
+	}
+	x2.Add(&svdwC2, &tv4)
+	gx2.Square(&x2).Mul(&gx2, &x2).Add(&gx2, &bTwistCurveCoeff)
+	if gx2.Legendre() >= 0 {
+		return branchX2, exceptional
+	}
+	return branchX3, exceptional
+}
+
+// Test vectors for BN254G2_XMD:MiMC-256_SVDW in the style of RFC 9380
+// appendix J and K, shared with the cometbft bn254 implementation. Field
+// elements are big endian.
+
+type g2Vector struct {
+	x0, x1, y0, y1 string
+}
+
+type expandMsgVector struct {
+	msg          string
+	lenInBytes   int
+	uniformBytes string
+}
+
+type hashToCurveVector struct {
+	msg    string
+	u      [4]string
+	q0, q1 g2Vector
+	p      g2Vector
+}
+
+// Vote signatures: the message is reduced to a scalar field element (the
+// image) before being hashed with the CometBLS domain separation tag.
+type signatureVector struct {
+	msg   string
+	image string
+	p     g2Vector
+}
+
+type mapToCurveVector struct {
+	exceptional bool
+	branch      svdwBranch
+	u           [2]string
+	q           g2Vector
+}
+
+const (
+	expandMsgDST   = "QUUX-V01-CS02-with-expander-MIMC256"
+	hashToCurveDST = "QUUX-V01-CS02-with-BN254G2_XMD:MiMC-256_SVDW_RO_"
+)
+
+var expandMsgVectors = []expandMsgVector{
+	{
+		msg:          "",
+		lenInBytes:   0x20,
+		uniformBytes: "be75e1d84b8813e1db6baa73f09458bbc566ecf264352ca8934763cb8bb34f2f",
+	},
+	{
+		msg:          "abc",
+		lenInBytes:   0x20,
+		uniformBytes: "5c580015f333e951f35604e1349c5f31a9fc5975624d9238cfeae81b264e8916",
+	},
+	{
+		msg:          "abcdef0123456789",
+		lenInBytes:   0x20,
+		uniformBytes: "44b51e55f792c2ea783aa1c4a3287c0fe3d199eb6b9c597fc032aa10e3823a26",
+	},
+	{
+		msg:          "q128_" + strings.Repeat("q", 128),
+		lenInBytes:   0x20,
+		uniformBytes: "79165ba18af3ca9c730e98c850e291022c58d2aab922af48fe3ff63487de950d",
+	},
+	{
+		msg:          "a512_" + strings.Repeat("a", 512),
+		lenInBytes:   0x20,
+		uniformBytes: "593181dfefd92d8674ad792b79d60a2a088a361e3f09323c2dd75fe15100de03",
+	},
+	{
+		msg:          "",
+		lenInBytes:   0x80,
+		uniformBytes: "63004f5c158b99740d7513161468de5c248391483557561fcfe70459e2ac78203f5cde7b9932256d366fb24cfd07b0a06419491e52ea813c02fd0905d0db74082f4f4e25349fa49527dc3ee6462c54c156f39b57471a826a632e1ab1647a972498e741604eae83fa697ef6e2e43c6bad3ccde76ac418c98d563ed458703ee922",
+	},
+	{
+		msg:          "abc",
+		lenInBytes:   0x80,
+		uniformBytes: "e15ff9baf873155db3d0817964d09100e72f786a2e90411b64c302c7a0d9d6095b3c92d3c7ed7428d7fa1fd5f2685b63cbcaf63b001789509c498772f7a4d60618e1240939f2a9014e165e18b26d4b26e45ea553e2294117e6e0eb36f3ae2130a737dac262baa7b25a6d199bf51089efd2573b5ef1028922a0a51a9e6314671d",
+	},
+	{
+		msg:          "abcdef0123456789",
+		lenInBytes:   0x80,
+		uniformBytes: "3bc7c6c0de6428b04092126290c3720872cef48fd4a1fb3c639fde60aa93e20db482727b6a64d92bbc8bceaa14f254ea3cc30a37a524d71e73b899f0e520192ed7aae65af66067b821885fb6f2587e5d0d374c436b9c4857ad8a751f0a063016607586d0b2bb1d91c39dc59489f2e70e021a1fff5b9c674efc89c885d724762b",
+	},
+	{
+		msg:          "q128_" + strings.Repeat("q", 128),
+		lenInBytes:   0x80,
+		uniformBytes: "ad1b24d497b5f3168910715a2d45456afe75b3f2d88af244634ff1a8f0a0ce09f4a0e194c65b221e856d631ca5672e82f488f72bb224ccd184b1c1dacd1ac20be91611ffece804154cac883b39f6f3fee52684843f5a9a69f94fd7b573a3701a22e4506660efbb6c73775129c751cdfea0ccaf594356a941be18114a5a44411f",
+	},
+	{
+		msg:          "a512_" + strings.Repeat("a", 512),
+		lenInBytes:   0x80,
+		uniformBytes: "7ef9273604e445e5bc5013169905e53f560c6e3cff40d18cdf336d705e264317f2c3e146b33e9084f36978b56757d921ee289fbd0d2e77faf9dbb338e3dc181ed28391bee666aad7312c1d98d2500843a18e9f9b29ceea9dfef43ee83961580c2a9ec5d0209ca95f68e727db7383d122fad84442ef967a3df207d0cfeb886d20",
+	},
+}
+
+var hashToCurveVectors = []hashToCurveVector{
+	{
+		msg: "",
+		u: [4]string{
+			"0x1ee8144dc85dd7bac12681a6f305bd66b03e438b67dd7dd5f065d37546366cab",
+			"0x2699281f545f8995a8ca2d0b5f1c449aae8e81fc0099ac77f6392f9a2c9d8f37",
+			"0x0a60618d09ce8d024ecfa0692ab84347005dc21e4bdeb8a261653ab32f39d5f3",
+			"0x1320c279f94466911f2f727823b63f523b10e201a80fe4cb15348052b8241699",
+		},
+		q0: g2Vector{
+			x0: "0x26fb250197f5841335164722bad2fe202ca7ef3f1a90fda5a7b12fd7a4e9f42b",
+			x1: "0x0b8b48e43b2196d57b5e702ea58d4e53334d2a7c373398f449ade1e58bed5338",
+			y0: "0x28dc83980cf4b05ca1abbd6a760576b0a7c14e8793e1dac582b0ea9f2852eedb",
+			y1: "0x15d9045f7c6840a2bdf9d364a74c60280e769b6296b0933aa11921d484ea264d",
+		},
+		q1: g2Vector{
+			x0: "0x2ba7fd6f8f989f9cb05f2670e23ac15f03c1529b07e47a04be64430caa8ecd75",
+			x1: "0x0a23fee845500a418c547b30c37e9f4f820c2801e819dec426a2efc988b2b605",
+			y0: "0x20e8ad5682383c0788b67c268f38acc6dcc64a33c2cf1d2b60edc457d18ab439",
+			y1: "0x153ae9c7cb8d198c6b18b1a774f66e1350d2f345ffad1429305d6b26fe0f5fbd",
+		},
+		p: g2Vector{
+			x0: "0x11b59bd01e65acad0137f025ed1348193a16afe9be9b372cb7876c56292ca584",
+			x1: "0x165882fb035a105e4beae6e30922b1040956b756865142c34fbc1fa9c4f0b8fc",
+			y0: "0x23ceee0be6c79da5668102a5a069cf3f55e0b65598b7648a6f37404b84c03d7a",
+			y1: "0x187617e20c78544e15e76c64498d4bffa147f05999363871d47d6e0199da318f",
+		},
+	},
+	{
+		msg: "abc",
+		u: [4]string{
+			"0x088423dae610ed4348b1ddf092e7af60117876c62738b57306ef1d0130932f5b",
+			"0x19755e1e85973526b59a2bb800b4d6137ed669b9c1181833d12bfb8be7b3137b",
+			"0x0a045a9ae974051c9a9154f0133c6db15b559d5dd237aea8862d6d7ff414b3cd",
+			"0x08e9e2f168cfca7f2f91df0a98b6cd9c10d3c269cf3afcbd9863148937867d57",
+		},
+		q0: g2Vector{
+			x0: "0x11e53c16f064686b5b645b3688a12daf0e09be77aa817fdac680593df098101c",
+			x1: "0x008e9c9c84ce2d846b70cd1e5066a765da9176ab50b9614913fd4c71f29a3472",
+			y0: "0x1a7d70a35d20048c7290516c13ce254d5ab2243ab71a8d45f5b178924a7f05a9",
+			y1: "0x01b7f03ca835b2ffbd085a0b7744407b15a06e49bf03d59e4492d264f08e4db9",
+		},
+		q1: g2Vector{
+			x0: "0x040c835623100936d79bb7823a8f1c513b9ea30b737d82d60ce04d66d608b287",
+			x1: "0x1980431866d90ad29dfb0143ae1b3d2635a390737877b3f564fc682f19cad6bd",
+			y0: "0x28ddd000c4b8d540e70d035a55ee9f748b0b2c0ba31a4e864635b3b5f5124aaf",
+			y1: "0x0b3c73083d8cf9cf53f5580269846509cbd384bfd78a553ce891ea392d55dafc",
+		},
+		p: g2Vector{
+			x0: "0x07ee3a15aef9714ba1c98eb3a365c70d70c865fb64dc3e7558b90b0865d028b2",
+			x1: "0x07ce1ed1be0662c2f7c89a355a54c2514d204b1bc4f22ee722fb0f1274d78e21",
+			y0: "0x20e91fc776da0b5779f71869e4f1dc2c06c056579c59c4572885f875441d55bf",
+			y1: "0x1eb4832a768c5bb2cec3669a610c561c95d526a8adb9609ba337ea97114efca0",
+		},
+	},
+	{
+		msg: "abcdef0123456789",
+		u: [4]string{
+			"0x104f7bb43615667d059c0307aa45685cefd40d30f1da3830fd503d0638af73e3",
+			"0x18a1a9ddf974579b13f15c950ccd347cb37a3a672358406c42cc690db84599f3",
+			"0x2eb48f085bafb70d7212b06ad08cd2bdd74623b6ae66ad8518914f2a56a51235",
+			"0x255ac258c70471fcc737da9a655136295a14a23f3569bf4ca7e4d56b6fae250b",
+		},
+		q0: g2Vector{
+			x0: "0x25f50f6de5a1954ed56b3d69258d43e0afbb86c10b27eda27922c916f73c8c62",
+			x1: "0x1e18e51a99fcb9057b7048916547af738d9736ed593e8148fd70d7440aea3ef1",
+			y0: "0x1833734e0d1329aa2a9a5f915e63510e6bcc727398ce46923b4e995781390893",
+			y1: "0x1415d24fd6cb02ba34b45c4371982dd3ea20fb237905b43dff3c056e4a8744b0",
+		},
+		q1: g2Vector{
+			x0: "0x2a339fe934a48b2f1c0ba7884205f0d5822e5be367e60b6909a31f6eb765b16a",
+			x1: "0x18f8668a59e9b720721579f92919033b610ef50fd152a8c3aa399f8898287c07",
+			y0: "0x1af7367a40fe220c15cfe61e8fd9fbb619212c629b9d820427393d0f60958e39",
+			y1: "0x03cffd081ea0b18bfd9a6c4f670f20f21cca31a27c73c89621fc2edce4febddd",
+		},
+		p: g2Vector{
+			x0: "0x2df689a8963a9e2171c363f5dee40ff3af5ba880cec08f5d7cc5c5f981ba8c2d",
+			x1: "0x0811d695a7f527d47e4ac03140904bc56ead8a20b54dc99f9ae14258bb80961d",
+			y0: "0x1e3000faa1045e4c6f5539c01d57d6e534f90c0ee9c3dc68b55ca5e143d5e845",
+			y1: "0x2bb9d6ee6b98eb452f4cd180b407d88aafaaf814a5e633372fc664829d683e93",
+		},
+	},
+	{
+		msg: "q128_" + strings.Repeat("q", 128),
+		u: [4]string{
+			"0x00c9b66f89ae9794727f10274551107c200e98a629b1e69ab69810576e3c37f7",
+			"0x0614b3a5e107da2b613f8e2fc06dd03425f269d951a03894caf32f73136cf2a5",
+			"0x0eba088268d77f1e70c4b3a129843d128adfda529f905845aaa09fe1089afb4e",
+			"0x0404c313e8301355a2d6a85bb08ae6a032f2108e29481c63a2bcf8c5632c7ad8",
+		},
+		q0: g2Vector{
+			x0: "0x298896cdaf4a1892364fad36b5aeac866720fe29a3f15e70b19ac3869a0f5fbd",
+			x1: "0x172a117460ad839007d72a43bb66a42a98e4eb983b89291545e69fe4e28bf9d4",
+			y0: "0x00727487672165493d6c20eddab7b8e7d9f41d0cf035f5ee36d673581efa54af",
+			y1: "0x198bb3f9474a31b149960ceaf3b79dc97b232e24d3f46e253cbf2c70bc3229cd",
+		},
+		q1: g2Vector{
+			x0: "0x0e2aef0df066566784b8c56be022782ea9e26fc2350b5a8d3b34ff5ef432346f",
+			x1: "0x10ba101d6940a4e7bb6578698970f69665a163a387ec86fb635b6d8d6447a3f8",
+			y0: "0x18134319688e551db4846f746b7194b2caf23ad08b3ffa0eb62fc1a7b09deb10",
+			y1: "0x24c5375663431fa461ea0e3e1c1406cf3f3ce580fdb45a0dd0950ed36e91f52c",
+		},
+		p: g2Vector{
+			x0: "0x115eb38fe89190fb612cb7d768633cf28a8aa57ce749bb226973210e9ff2a710",
+			x1: "0x0fde0eeff5033d14d266240ab9f7d30302f8a42140a35cc877dc02358dc8830a",
+			y0: "0x0cefa2144c43a3091552af7fb673d2f63a33bfa4746429ac7b8e9543e31a3c58",
+			y1: "0x28decbc517f1d384a358cfd0d604286b0b33984a7b2ae1d1553c36d2d6aa9006",
+		},
+	},
+	{
+		msg: "a512_" + strings.Repeat("a", 512),
+		u: [4]string{
+			"0x0f9ed16fc7f6300f51e97977aace0173bd4c5835c4167bc9020fe51673f982d2",
+			"0x0af1e67683d6f4e9ae5cfc9f7de39bc46c2b23cd6e0ce5e673baca5592437c32",
+			"0x16b01da19e1f4161d2909f1b513c7b901db21bfeff7c945ea01b743a7bcde6a6",
+			"0x208915f4be1c5da1305d8a9eefee10fe193d78e4db2ec8a24b09006d62e1b885",
+		},
+		q0: g2Vector{
+			x0: "0x0ac5f94d669ee8cbf2182965fd61439fdb40c61f76ab1ee0977ab5d7db81bb93",
+			x1: "0x2c07b8957c4a37e5f746469f9c43d1b94140f0c1e59c77abd7a986e6b8ec8175",
+			y0: "0x26cf0ce3c210a4cf2aff24d7dda0fe64529a6b2f2f79e3945e86e550e714a81a",
+			y1: "0x066cf7f491ba681070baf2762ad60319b4682f25a44cc89d940498977d030622",
+		},
+		q1: g2Vector{
+			x0: "0x2c7061dfd834695abec91926aa50d18f6f9b8dd5a3637f5a97f59ff15230f1f6",
+			x1: "0x00b18460468cf91620112eb15dd945d795485f8d1854a764452260741384ef99",
+			y0: "0x0a62a052998b6f9ca710c1e685bb94f3e7aa6c1ea0ffb511fe1c1803f7619e2c",
+			y1: "0x28c417a881ac28d2c24712fa4561d38b0c862f918293245361f34b0a58c7f2d5",
+		},
+		p: g2Vector{
+			x0: "0x1a5780d356a3b96e8c0c18eab0cbdcf61474704f531a96db0161f694ad2aa20c",
+			x1: "0x1e7bb879ad067643129f0cd4c0f64e095525e92d80567aec7281db2f92054b8f",
+			y0: "0x17c32959d22b74fed4a04a8c7efab89739e6470c3c9b7a7f1f4cda488ac549c0",
+			y1: "0x2b598ef87e277cb008ffffaff54d87788208c0e80e95e0a8c3665753a44979c5",
+		},
+	},
+}
+
+var signatureVectors = []signatureVector{
+	{
+		msg:   "",
+		image: "0x29d64e657c6b0c10b8252715207a9162660bfd308954191e4774bde639f35730",
+		p: g2Vector{
+			x0: "0x23feac7d16bac65c774512e5956e734f916dc8381accdfcc47e97feea6d2bf06",
+			x1: "0x25739fd261075920fc63db4d00894092c430a85aa7988749665bb993b87419c8",
+			y0: "0x066975d626235d9431a6631b96b61ef54291d31fc41d4ff1c5659963e1ea0c63",
+			y1: "0x24e920420b47086f41619efdbe26b96c79a2dab8df99b5ff989b7e190e29468f",
+		},
+	},
+	{
+		msg:   "abc",
+		image: "0x04bf5586e448dbe461ce33d49457f28e89d088a3e5916180266245f47fcd90e2",
+		p: g2Vector{
+			x0: "0x0bae769bee779ec30a919478a5ab4060e5229e46e71f9c9f147c0be5afc8b700",
+			x1: "0x2d1dd10eff8450290491e9229dfc8a4e1d322eb90d8390d614f8ea090686956b",
+			y0: "0x0617bc4618a1089e4f464f19900016ff84929f9cbd79b393b63061e1a63bd640",
+			y1: "0x140069450dbe56dfac92bdb7a2ee750f513c65b5341e9afe3c4c3897d0ff3c1a",
+		},
+	},
+	{
+		msg:   "\x01\x02\x03",
+		image: "0x1923823d32c31611dd04d14644bbce9810f3a0bac2f4bb0ccb8db7c2aea33037",
+		p: g2Vector{
+			x0: "0x2e9aa14612540da367565c8ed7e699d249e0b2b6b2d05ae7af2a420c6449ed2d",
+			x1: "0x26b6944e488529db53f96c6a8984b4c72a7c7d226aa1709474b88afba115288f",
+			y0: "0x22f9a46f5cc9f572fe6fc88323956b5e6224c243a85cd7de76a898c7c2bdda51",
+			y1: "0x1389d07d1a24623f9fcfad17610df381bf6960d7888190519adef49aac026da9",
+		},
+	},
+}
+
+// Each SvdW branch, the exceptional cases of inv0 (u² c1 = ±1) and zero
+var mapToCurveVectors = []mapToCurveVector{
+	{
+		branch: branchX2,
+		u:      [2]string{"0x08c455a4e976b393bedc700d9309cbf472f22c0aa018166a1d05bc267e434ece", "0x04a1913f16105675455e8706ffe1b3de409630c2f1fcd5422f878ffa5a68301f"},
+		q: g2Vector{
+			x0: "0x02947c68ec5f81a67ff0cd8ed5a0315efb5978946e4cd312c8947267cc115cd1",
+			x1: "0x164389fbfb476bba8e22fe922f4866eb788d08fd2c00ff6f6274be951bd8f04a",
+			y0: "0x034be8596cd0380aca95acaddaded5a1d472ef3370e96bd4a75d8790d5b6651e",
+			y1: "0x01d71e93b9105ca146fdf599ebe7c541bd1e0ac3a57bd7024ff77e9836f5bed8",
+		},
+	},
+	{
+		branch: branchX1,
+		u:      [2]string{"0x1acfc9b3bda09860d9b622dd7c514ec6feaf3c6b6437f09a46e4da85e5711078", "0x28002fe0d562ba4a7cd4bc14d0e8f486004ada6e2c0140b4211487451a15da28"},
+		q: g2Vector{
+			x0: "0x2f473f3ddfdba6f72298cb8317bc5379eb7d08a0ef69cb8ed7154217b74f6fb0",
+			x1: "0x18d40970e92d357e47a0678b44bcd42e8ac9e6c41b449c9a1297d6b0cad35455",
+			y0: "0x1494e41bd5181ba4cd3bfe5c426051b2ab5fcd6b062e4ee06d29a82dd40e5c18",
+			y1: "0x2fc530de58a1c551a49676906d37a0da18741e89c5aead6e5db9d5cf9e2e034b",
+		},
+	},
+	{
+		branch: branchX3,
+		u:      [2]string{"0x237c43df29406f855d76d78fdc9675069268e86e395c6ac970e4371cbba7e145", "0x07f71fa84b0875fcde3513e92499a5ade8c803041cbcc5c1d8d8ab1401cb3a6c"},
+		q: g2Vector{
+			x0: "0x1dd12f589efd41df1c2d14ac089564e99778ebe9ffcfc58eaca3ce20ddbfa546",
+			x1: "0x28cb1bb15213c23659b7a2d1752e6325bbb635a9b25fda2c711ada04983522e2",
+			y0: "0x22b19396cf8f1e65cbbc14b366eaa8d0ee8866af9bdd74ef5fca4efc82161ab1",
+			y1: "0x2562ec40902a0265108dcb47fca2d9481e15a0ef84327c51645885efbb19c5c3",
+		},
+	},
+	{
+		branch: branchX1,
+		u:      [2]string{"0x07dd496037ca58ad203ab0d671eb8c61ac86c4ed876e7cd26b455e11e96cb0fc", "0x1b02ac787315fa3dccb3c103a5ac5095359ef12e26f34ce1405c9e8acb1ed425"},
+		q: g2Vector{
+			x0: "0x1b95eb95b3aa1b781708bde2e5d5960de66e2f479d760c551b2d029faa069807",
+			x1: "0x0b03e16777c41a2763796801ad50e971d656559531e6daf83f0392c9de33f93d",
+			y0: "0x146da6197aeb29a2085f89a82550272ac9142a9438431fca8ed8209808e73942",
+			y1: "0x111ca04e8feff767842255ba18f0671df3b942b16114676681004cbc221faaea",
+		},
+	},
+	{
+		branch: branchX3,
+		u:      [2]string{"0x292d48e0e2e2e0e04128617bb805350896416fd59a7522396db93110da03640c", "0x0ea281f3276f2678167d2a475ea8b0245b69d4a0149689fe55da5fb02d6b251b"},
+		q: g2Vector{
+			x0: "0x14d547a4252119907f29d99c5595e5a3d835519838adda8af32eb93463bbed6a",
+			x1: "0x2bc846c92e0779d8eeb6dfe99be9c77a04ca0344d228a6d15e63220ae37e2fa4",
+			y0: "0x009c8ad0c965e811de5a5a62786b900c9632872683d20a3557a0ca46a982dfce",
+			y1: "0x0f32ef1a1af8d586c41cf0b6110c4e7425dfba0c7e74b050a93c24ff5cc67fbd",
+		},
+	},
+	{
+		branch: branchX2,
+		u:      [2]string{"0x03959665f41febf4a16eb29a051bc73d339a5c327c65333640a5a12b53a7d571", "0x113d4a7cd82446370059988c8ac6ac8acc15a36a0875200c74201c709aef58c9"},
+		q: g2Vector{
+			x0: "0x0b01e44845ab2c4d47818f1bef8d24532870b9ba2d93e9442ea518518539c4bf",
+			x1: "0x1930d354b8e7b0f0791fbd4835267fce75c44219f2d3a67fa7ab76a2438a359b",
+			y0: "0x0e618a4d46d81d3929d254031ceecee6741910f7629b4ce10b4705e42504aceb",
+			y1: "0x28c19825faeef0005dbecb624cd8bcdcce4895bdcd0f596c1125a98e0f3e19a2",
+		},
+	},
+	{
+		exceptional: true,
+		branch:      branchX3,
+		u:           [2]string{"0x0e6be1329360814a7ffcb1c2f50aa503882f748a8759ab759a9c94905bae3d0a", "0x10009fb7bc95062257a7d35f6c8332495802a82e755652d245b0f4cbad3d9aa5"},
+		q: g2Vector{
+			x0: "0x0000000000000000000000000000000000000000000000000000000000000001",
+			x1: "0x0000000000000000000000000000000000000000000000000000000000000000",
+			y0: "0x07fb3d558dafafb6bf6dd326a5fefe0beca3f9ac3bd999a390d504fad34b0b8c",
+			y1: "0x2351dcdda257b62181cbd745dfee16d5fdf4eb185bbcf33c20a0fe6eaa9cb4a3",
+		},
+	},
+	{
+		exceptional: true,
+		branch:      branchX3,
+		u:           [2]string{"0x10009fb7bc95062257a7d35f6c8332495802a82e755652d245b0f4cbad3d9aa5", "0x21f86d404dd11edf385393f38c76b35a0f51f606e1181f17a183f7867ccec03d"},
+		q: g2Vector{
+			x0: "0x0000000000000000000000000000000000000000000000000000000000000001",
+			x1: "0x0000000000000000000000000000000000000000000000000000000000000000",
+			y0: "0x2869111d5381f072f8e2728fdb825a51aadd70e52c9830e9ab4b871c0531f1bb",
+			y1: "0x0d1271953ed9ea0836846e70a1934187998c7f790cb4d7511b7f8da82de048a4",
+		},
+	},
+	{
+		branch: branchX3,
+		u:      [2]string{"0x0000000000000000000000000000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		q: g2Vector{
+			x0: "0x17365bbe63b1d2078632fe0eb2ac5a41b4e6a9c08b98676721010b008d4eaf9a",
+			x1: "0x0f57ffe5fc79e19cd689d7aa4209cad8fe164d7f4694786b388732a995d03755",
+			y0: "0x2bc9fff87a744078fa8071076817d3e91050735ed50fd58cec28ab894ffd3258",
+			y1: "0x1729bddff301ffe7b1e30afc6452c39c91ee831ac9e71bc914d1e4f45f5f66c3",
+		},
+	},
+}
+
+func fpOf(t *testing.T, h string) fp.Element {
+	var x fp.Element
+	_, err := x.SetString(h)
+	assert.NoError(t, err)
+	return x
+}
+
+func g2Of(t *testing.T, v g2Vector) curve.G2Affine {
+	var p curve.G2Affine
+	p.X.A0 = fpOf(t, v.x0)
+	p.X.A1 = fpOf(t, v.x1)
+	p.Y.A0 = fpOf(t, v.y0)
+	p.Y.A1 = fpOf(t, v.y1)
+	assert.True(t, p.IsOnCurve())
+	return p
+}
+
+func bytesToVariables(bz []byte) []frontend.Variable {
+	xs := make([]frontend.Variable, len(bz))
+	for i := 0; i < len(bz); i++ {
+		xs[i] = bz[i]
+	}
+	return xs
+}
+
+type ExpandMsgXmdBytes struct {
+	Message    []frontend.Variable
+	Domain     []frontend.Variable
+	Image      []frontend.Variable
+	lenInBytes int
+}
+
+func (c *ExpandMsgXmdBytes) Define(api frontend.API) error {
+	emulated, err := NewEmulatedAPI(api)
+	if err != nil {
+		return err
+	}
+	image, err := emulated.ExpandMsgXmdBytes(c.Message, c.Domain, c.lenInBytes)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(len(c.Image)*8, len(image))
+	for i := 0; i < len(c.Image); i++ {
+		imageBits := api.ToBinary(c.Image[i], 8)
+		for j := 0; j < 8; j++ {
+			api.AssertIsEqual(image[i*8+j], imageBits[j])
+		}
+	}
+	return nil
+}
+
+func TestExpandMsgXmdVectors(t *testing.T) {
+	t.Parallel()
+	for _, v := range expandMsgVectors {
+		expected, err := hex.DecodeString(v.uniformBytes)
+		assert.NoError(t, err)
+		uniformBytes, err := cometbft_bn254.ExpandMsgXmdMiMC([]byte(v.msg), []byte(expandMsgDST), v.lenInBytes)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, uniformBytes)
+		err = test.IsSolved(
+			&ExpandMsgXmdBytes{
+				Message:    make([]frontend.Variable, len(v.msg)),
+				Domain:     make([]frontend.Variable, len(expandMsgDST)),
+				Image:      make([]frontend.Variable, v.lenInBytes),
+				lenInBytes: v.lenInBytes,
+			},
+			&ExpandMsgXmdBytes{
+				Message: bytesToVariables([]byte(v.msg)),
+				Domain:  bytesToVariables([]byte(expandMsgDST)),
+				Image:   bytesToVariables(expected),
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+	}
+}
+
+type HashToCurveBytes struct {
+	Message []frontend.Variable
+	Domain  []frontend.Variable
+	U       [4]emulated.Element[emulated.BN254Fp]
+	Q0      gadget.G2Affine
+	Q1      gadget.G2Affine
+	P       gadget.G2Affine
+}
+
+func (c *HashToCurveBytes) Define(api frontend.API) error {
+	e, err := NewEmulatedAPI(api)
+	if err != nil {
+		return err
+	}
+	u, err := e.HashToFieldBytes(c.Message, c.Domain, 4)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < 4; i++ {
+		e.field.AssertIsEqual(&c.U[i], u[i])
+	}
+	e.AssertIsEqual(&c.Q0, e.MapToCurve(&fields_bn254.E2{A0: *u[0], A1: *u[1]}))
+	e.AssertIsEqual(&c.Q1, e.MapToCurve(&fields_bn254.E2{A0: *u[2], A1: *u[3]}))
+	e.AssertIsEqual(&c.P, e.mapToG2(u))
+	return nil
+}
+
+func TestHashToCurveVectors(t *testing.T) {
+	t.Parallel()
+	for _, v := range hashToCurveVectors {
+		u, err := cometbft_bn254.HashToFieldMiMC([]byte(v.msg), []byte(hashToCurveDST))
+		assert.NoError(t, err)
+		var expectedU [4]emulated.Element[emulated.BN254Fp]
+		for i := 0; i < 4; i++ {
+			assert.Equal(t, fpOf(t, v.u[i]), u[i])
+			expectedU[i] = emulated.ValueOf[emulated.BN254Fp](u[i])
+		}
+		q0 := g2Of(t, v.q0)
+		assert.Equal(t, q0, curve.MapToCurve2(&curve.E2{A0: u[0], A1: u[1]}))
+		q1 := g2Of(t, v.q1)
+		assert.Equal(t, q1, curve.MapToCurve2(&curve.E2{A0: u[2], A1: u[3]}))
+		p := g2Of(t, v.p)
+		assert.True(t, p.IsInSubGroup())
+		hashed, err := cometbft_bn254.HashToG2MiMC([]byte(v.msg), []byte(hashToCurveDST))
+		assert.NoError(t, err)
+		assert.Equal(t, p, hashed)
+		err = test.IsSolved(
+			&HashToCurveBytes{
+				Message: make([]frontend.Variable, len(v.msg)),
+				Domain:  make([]frontend.Variable, len(hashToCurveDST)),
+			},
+			&HashToCurveBytes{
+				Message: bytesToVariables([]byte(v.msg)),
+				Domain:  bytesToVariables([]byte(hashToCurveDST)),
+				U:       expectedU,
+				Q0:      gadget.NewG2Affine(q0),
+				Q1:      gadget.NewG2Affine(q1),
+				P:       gadget.NewG2Affine(p),
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+	}
+}
+
+func TestSignatureVectors(t *testing.T) {
+	t.Parallel()
+	for _, v := range signatureVectors {
+		p := g2Of(t, v.p)
+		assert.Equal(t, p, cometbft_bn254.HashToG2([]byte(v.msg)))
+		image, err := hex.DecodeString(strings.TrimPrefix(v.image, "0x"))
+		assert.NoError(t, err)
+		err = test.IsSolved(
+			&HashToG2{},
+			&HashToG2{
+				Preimage: image,
+				Domain:   []byte(cometbft_bn254.CometblsSigDST),
+				Image:    gadget.NewG2Affine(p),
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+	}
+}
+
+func TestMapToCurveVectors(t *testing.T) {
+	t.Parallel()
+	for _, v := range mapToCurveVectors {
+		u := curve.E2{A0: fpOf(t, v.u[0]), A1: fpOf(t, v.u[1])}
+		branch, exceptional := svdwBranchOf(&u)
+		assert.Equal(t, v.branch, branch)
+		assert.Equal(t, v.exceptional, exceptional)
+		q := g2Of(t, v.q)
+		assert.Equal(t, q, curve.MapToCurve2(&u))
+		err := test.IsSolved(
+			&MapToCurve{},
+			&MapToCurve{
+				Preimage: fields_bn254.FromE2(&u),
+				Image:    gadget.NewG2Affine(q),
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+	}
+}
+
+type IsSquare struct {
+	X     fields_bn254.E2
+	Claim frontend.Variable
+}
+
+func (c *IsSquare) Define(api frontend.API) error {
+	e, err := NewEmulatedAPI(api)
+	if err != nil {
+		return err
+	}
+	e.assertIsSquare(&c.X, c.Claim)
+	return nil
+}
+
+// The prover must not be able to pick the SvdW branch by lying on is_square
+func TestIsSquareClaim(t *testing.T) {
+	t.Parallel()
+	var square, nonSquare, zero curve.E2
+	for square.Legendre() != 1 {
+		square.SetRandom()
+	}
+	for nonSquare.Legendre() != -1 {
+		nonSquare.SetRandom()
+	}
+	for _, c := range []struct {
+		x     curve.E2
+		claim int
+		valid bool
+	}{
+		{square, 1, true},
+		{square, 0, false},
+		{nonSquare, 0, true},
+		{nonSquare, 1, false},
+		{zero, 1, true},
+		{zero, 0, false},
+	} {
+		err := test.IsSolved(
+			&IsSquare{},
+			&IsSquare{
+				X:     fields_bn254.FromE2(&c.x),
+				Claim: c.claim,
+			},
+			ecc.BN254.ScalarField(),
+		)
+		if c.valid {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
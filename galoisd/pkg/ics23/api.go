@@ -0,0 +1,267 @@
+package ics23
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/cmp"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Only the SHA-256 based specs with VAR_PROTO length prefixing, no key
+// prehashing and SHA-256 value prehashing are supported (IAVL and Tendermint):
+//
+//	leaf  = sha256(prefix || varint(len(key)) || key || varint(32) || sha256(value))
+//	inner = sha256(prefix || child || suffix)
+type ProofSpec struct {
+	LeafPrefix      byte
+	MinPrefixLength int
+	MaxPrefixLength int
+	ChildSize       int
+}
+
+var (
+	// ICS23 IavlSpec
+	IavlSpec = ProofSpec{
+		LeafPrefix:      0,
+		MinPrefixLength: 4,
+		MaxPrefixLength: 12,
+		ChildSize:       33,
+	}
+	// ICS23 TendermintSpec
+	TendermintSpec = ProofSpec{
+		LeafPrefix:      0,
+		MinPrefixLength: 1,
+		MaxPrefixLength: 1,
+		ChildSize:       32,
+	}
+)
+
+// Maximum length of an inner op prefix, including the left sibling (two children only)
+func (s ProofSpec) MaxInnerPrefixSize() int {
+	return s.MaxPrefixLength + s.ChildSize
+}
+
+// Maximum length of an inner op suffix, including the right sibling (two children only)
+func (s ProofSpec) MaxInnerSuffixSize() int {
+	return s.ChildSize
+}
+
+// Bytes are stored one per variable, the slices are allocated to their
+// maximum size and the actual length is provided as a separate variable.
+type LeafOp struct {
+	Prefix    []frontend.Variable
+	PrefixLen frontend.Variable
+}
+
+type InnerOp struct {
+	Prefix    []frontend.Variable
+	PrefixLen frontend.Variable
+	Suffix    []frontend.Variable
+	SuffixLen frontend.Variable
+}
+
+type ExistenceProof struct {
+	Key      []frontend.Variable
+	KeyLen   frontend.Variable
+	Value    []frontend.Variable
+	ValueLen frontend.Variable
+	Leaf     LeafOp
+	Path     []InnerOp
+	PathLen  frontend.Variable
+}
+
+type ICS23API struct {
+	api         frontend.API
+	spec        ProofSpec
+	binaryField *uints.BinaryField[uints.U32]
+}
+
+func NewICS23API(api frontend.API, spec ProofSpec) (*ICS23API, error) {
+	binaryField, err := uints.New[uints.U32](api)
+	if err != nil {
+		return nil, err
+	}
+	return &ICS23API{
+		api:         api,
+		spec:        spec,
+		binaryField: binaryField,
+	}, nil
+}
+
+type segment struct {
+	bytes  []uints.U8
+	length frontend.Variable
+}
+
+func (i *ICS23API) bytesOf(xs []frontend.Variable) []uints.U8 {
+	bytes := make([]uints.U8, len(xs))
+	for j := 0; j < len(xs); j++ {
+		bytes[j] = i.binaryField.ByteValueOf(xs[j])
+	}
+	return bytes
+}
+
+// VAR_PROTO length prefix, at most two bytes
+func (i *ICS23API) varint(x frontend.Variable, capacity int) segment {
+	if capacity >= 1<<14 {
+		panic(fmt.Sprintf("capacity too large for a two bytes varint: %d", capacity))
+	}
+	bits := i.api.ToBinary(x, 14)
+	low := i.api.FromBinary(bits[:7]...)
+	high := i.api.FromBinary(bits[7:]...)
+	isLong := i.api.Sub(1, i.api.IsZero(high))
+	return segment{
+		bytes: []uints.U8{
+			{Val: i.api.Add(low, i.api.Mul(isLong, 0x80))},
+			{Val: high},
+		},
+		length: i.api.Add(1, isLong),
+	}
+}
+
+// Concatenate the first length bytes of each segment, the content of the
+// result after the returned length is unspecified.
+func (i *ICS23API) concat(segments ...segment) ([]uints.U8, frontend.Variable) {
+	table := logderivlookup.New(i.api)
+	offsets := make([]int, len(segments))
+	starts := make([]frontend.Variable, len(segments)+1)
+	starts[0] = 0
+	capacity := 0
+	for k, s := range segments {
+		i.api.AssertIsLessOrEqual(s.length, len(s.bytes))
+		for _, b := range s.bytes {
+			table.Insert(b.Val)
+		}
+		offsets[k] = capacity
+		capacity += len(s.bytes)
+		starts[k+1] = i.api.Add(starts[k], s.length)
+	}
+	comparator := cmp.NewBoundedComparator(i.api, big.NewInt(int64(capacity+1)), false)
+	indices := make([]frontend.Variable, capacity)
+	for j := 0; j < capacity; j++ {
+		// Whether the j-th byte lies after the start of each segment
+		after := make([]frontend.Variable, len(starts))
+		after[0] = 1
+		for k := 1; k < len(starts); k++ {
+			after[k] = comparator.IsLessEq(starts[k], j)
+		}
+		index := frontend.Variable(0)
+		for k := 0; k < len(segments); k++ {
+			inSegment := i.api.Sub(after[k], after[k+1])
+			index = i.api.Add(index, i.api.Mul(inSegment, i.api.Sub(offsets[k]+j, starts[k])))
+		}
+		indices[j] = index
+	}
+	values := table.Lookup(indices...)
+	result := make([]uints.U8, capacity)
+	for j := 0; j < capacity; j++ {
+		result[j] = uints.U8{Val: values[j]}
+	}
+	return result, starts[len(segments)]
+}
+
+// SHA-256 of the first length bytes of data
+func (i *ICS23API) sum(data []uints.U8, length frontend.Variable) ([]uints.U8, error) {
+	h, err := sha2.New(i.api)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(data)
+	return h.FixedLengthSum(length), nil
+}
+
+// SHA-256 of the first length bytes of data, each variable being a byte
+func (i *ICS23API) Sum(data []frontend.Variable, length frontend.Variable) ([]uints.U8, error) {
+	i.api.AssertIsLessOrEqual(length, len(data))
+	return i.sum(i.bytesOf(data), length)
+}
+
+// ICS23 LeafOp.Apply
+func (i *ICS23API) LeafHash(leaf *LeafOp, key []uints.U8, keyLen frontend.Variable, value []uints.U8, valueLen frontend.Variable) ([]uints.U8, error) {
+	prefix := i.bytesOf(leaf.Prefix)
+	// The prefix must start with the spec leaf prefix
+	i.api.AssertIsLessOrEqual(i.api.Sub(leaf.PrefixLen, 1), len(prefix)-1)
+	i.binaryField.ByteAssertEq(prefix[0], uints.NewU8(i.spec.LeafPrefix))
+	// Neither the key nor the value can be empty
+	i.api.AssertIsLessOrEqual(i.api.Sub(keyLen, 1), len(key)-1)
+	i.api.AssertIsLessOrEqual(i.api.Sub(valueLen, 1), len(value)-1)
+	valueHash, err := i.sum(value, valueLen)
+	if err != nil {
+		return nil, err
+	}
+	preimage, length := i.concat(
+		segment{bytes: prefix, length: leaf.PrefixLen},
+		i.varint(keyLen, len(key)),
+		segment{bytes: key, length: keyLen},
+		segment{bytes: append([]uints.U8{uints.NewU8(uint8(len(valueHash)))}, valueHash...), length: 1 + len(valueHash)},
+	)
+	return i.sum(preimage, length)
+}
+
+// ICS23 InnerOp.Apply, along with the InnerOp.CheckAgainstSpec constraints
+// that apply to a two children inner spec.
+func (i *ICS23API) InnerHash(inner *InnerOp, child []uints.U8) ([]uints.U8, error) {
+	prefix := i.bytesOf(inner.Prefix)
+	suffix := i.bytesOf(inner.Suffix)
+	// MinPrefixLength <= len(prefix) <= MaxPrefixLength + ChildSize
+	i.api.AssertIsLessOrEqual(i.api.Sub(inner.PrefixLen, i.spec.MinPrefixLength), i.spec.MaxInnerPrefixSize()-i.spec.MinPrefixLength)
+	// The prefix can't start with the leaf prefix
+	i.api.AssertIsEqual(i.api.IsZero(i.api.Sub(prefix[0].Val, i.spec.LeafPrefix)), 0)
+	// len(suffix) % ChildSize == 0
+	i.api.AssertIsEqual(i.api.Mul(inner.SuffixLen, i.api.Sub(inner.SuffixLen, i.spec.ChildSize)), 0)
+	preimage, length := i.concat(
+		segment{bytes: prefix, length: inner.PrefixLen},
+		segment{bytes: child, length: len(child)},
+		segment{bytes: suffix, length: inner.SuffixLen},
+	)
+	return i.sum(preimage, length)
+}
+
+// ICS23 ExistenceProof.Calculate
+//
+// Only the first PathLen inner ops are applied, the remaining ones are
+// ignored but must still be well formed.
+func (i *ICS23API) RootHash(proof *ExistenceProof) ([]uints.U8, error) {
+	i.api.AssertIsLessOrEqual(proof.PathLen, len(proof.Path))
+	root, err := i.LeafHash(
+		&proof.Leaf,
+		i.bytesOf(proof.Key),
+		proof.KeyLen,
+		i.bytesOf(proof.Value),
+		proof.ValueLen,
+	)
+	if err != nil {
+		return nil, err
+	}
+	comparator := cmp.NewBoundedComparator(i.api, big.NewInt(int64(len(proof.Path)+1)), false)
+	for k := 0; k < len(proof.Path); k++ {
+		node, err := i.InnerHash(&proof.Path[k], root)
+		if err != nil {
+			return nil, err
+		}
+		enabled := comparator.IsLess(k, proof.PathLen)
+		for j := 0; j < len(root); j++ {
+			root[j] = uints.U8{Val: i.api.Select(enabled, node[j].Val, root[j].Val)}
+		}
+	}
+	return root, nil
+}
+
+// ICS23 VerifyMembership for a single existence proof
+func (i *ICS23API) VerifyMembership(proof *ExistenceProof, expectedRoot []uints.U8) error {
+	root, err := i.RootHash(proof)
+	if err != nil {
+		return err
+	}
+	if len(expectedRoot) != len(root) {
+		return fmt.Errorf("invalid root size: %d", len(expectedRoot))
+	}
+	for j := 0; j < len(root); j++ {
+		i.binaryField.ByteAssertEq(root[j], expectedRoot[j])
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package ics23
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	ics23proto "github.com/cosmos/ics23/go"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	MaxDepth = 8
+)
+
+var IavlSize = ProofSize{
+	KeySize:        160,
+	ValueSize:      64,
+	LeafPrefixSize: 12,
+	Depth:          MaxDepth,
+}
+
+var TendermintSize = ProofSize{
+	KeySize:        16,
+	ValueSize:      32,
+	LeafPrefixSize: 1,
+	Depth:          MaxDepth,
+}
+
+type Membership struct {
+	spec  ProofSpec
+	Proof ExistenceProof
+	Root  [32]frontend.Variable
+}
+
+func (c *Membership) Define(api frontend.API) error {
+	ics23API, err := NewICS23API(api, c.spec)
+	if err != nil {
+		return err
+	}
+	root := make([]uints.U8, len(c.Root))
+	for i := 0; i < len(root); i++ {
+		root[i] = uints.U8{Val: c.Root[i]}
+	}
+	return ics23API.VerifyMembership(&c.Proof, root)
+}
+
+func readBytes(r *rand.Rand, size int) []byte {
+	bz := make([]byte, size)
+	r.Read(bz)
+	return bz
+}
+
+func getIavlProof(r *rand.Rand) *ics23proto.ExistenceProof {
+	version := r.Int63n(1 << 40)
+	leafPrefix := binary.AppendVarint(nil, 0)
+	leafPrefix = binary.AppendVarint(leafPrefix, 1)
+	leafPrefix = binary.AppendVarint(leafPrefix, version)
+	path := make([]*ics23proto.InnerOp, r.Intn(MaxDepth+1))
+	for i := 0; i < len(path); i++ {
+		prefix := binary.AppendVarint(nil, int64(i+1))
+		prefix = binary.AppendVarint(prefix, int64(1<<(i+1)))
+		prefix = binary.AppendVarint(prefix, version)
+		prefix = append(prefix, 32)
+		var suffix []byte
+		if r.Intn(2) == 0 {
+			suffix = append([]byte{32}, readBytes(r, 32)...)
+		} else {
+			prefix = append(append(prefix, readBytes(r, 32)...), 32)
+		}
+		path[i] = &ics23proto.InnerOp{
+			Hash:   ics23proto.HashOp_SHA256,
+			Prefix: prefix,
+			Suffix: suffix,
+		}
+	}
+	return &ics23proto.ExistenceProof{
+		Key:   readBytes(r, 1+r.Intn(IavlSize.KeySize)),
+		Value: readBytes(r, 1+r.Intn(IavlSize.ValueSize)),
+		Leaf: &ics23proto.LeafOp{
+			Hash:         ics23proto.HashOp_SHA256,
+			PrehashKey:   ics23proto.HashOp_NO_HASH,
+			PrehashValue: ics23proto.HashOp_SHA256,
+			Length:       ics23proto.LengthOp_VAR_PROTO,
+			Prefix:       leafPrefix,
+		},
+		Path: path,
+	}
+}
+
+func getTendermintProof(r *rand.Rand) *ics23proto.ExistenceProof {
+	path := make([]*ics23proto.InnerOp, r.Intn(MaxDepth+1))
+	for i := 0; i < len(path); i++ {
+		prefix := []byte{1}
+		var suffix []byte
+		if r.Intn(2) == 0 {
+			suffix = readBytes(r, 32)
+		} else {
+			prefix = append(prefix, readBytes(r, 32)...)
+		}
+		path[i] = &ics23proto.InnerOp{
+			Hash:   ics23proto.HashOp_SHA256,
+			Prefix: prefix,
+			Suffix: suffix,
+		}
+	}
+	return &ics23proto.ExistenceProof{
+		Key:   readBytes(r, 1+r.Intn(TendermintSize.KeySize)),
+		Value: readBytes(r, 32),
+		Leaf: &ics23proto.LeafOp{
+			Hash:         ics23proto.HashOp_SHA256,
+			PrehashKey:   ics23proto.HashOp_NO_HASH,
+			PrehashValue: ics23proto.HashOp_SHA256,
+			Length:       ics23proto.LengthOp_VAR_PROTO,
+			Prefix:       []byte{0},
+		},
+		Path: path,
+	}
+}
+
+func verifyMembership(t *testing.T, spec ProofSpec, size ProofSize, proof *ics23proto.ExistenceProof, root []byte) error {
+	assignedProof, err := NewExistenceProof(spec, size, proof)
+	assert.NoError(t, err)
+	var assignedRoot [32]frontend.Variable
+	for i := 0; i < len(assignedRoot); i++ {
+		assignedRoot[i] = root[i]
+	}
+	return test.IsSolved(
+		&Membership{
+			spec:  spec,
+			Proof: size.Allocate(spec),
+		},
+		&Membership{
+			spec:  spec,
+			Proof: assignedProof,
+			Root:  assignedRoot,
+		},
+		ecc.BN254.ScalarField(),
+	)
+}
+
+func calculate(t *testing.T, spec *ics23proto.ProofSpec, proof *ics23proto.ExistenceProof) []byte {
+	root, err := proof.Calculate()
+	assert.NoError(t, err)
+	assert.True(t, ics23proto.VerifyMembership(
+		spec,
+		root,
+		&ics23proto.CommitmentProof{
+			Proof: &ics23proto.CommitmentProof_Exist{
+				Exist: proof,
+			},
+		},
+		proof.Key,
+		proof.Value,
+	))
+	return root
+}
+
+func FuzzIavlMembership(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+		proof := getIavlProof(r)
+		root := calculate(t, ics23proto.IavlSpec, proof)
+		err := verifyMembership(t, IavlSpec, IavlSize, proof, root)
+		assert.NoError(t, err)
+	})
+}
+
+func FuzzTendermintMembership(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+		proof := getTendermintProof(r)
+		root := calculate(t, ics23proto.TendermintSpec, proof)
+		err := verifyMembership(t, TendermintSpec, TendermintSize, proof, root)
+		assert.NoError(t, err)
+	})
+}
+
+func TestWrongValue(t *testing.T) {
+	r := rand.New(rand.NewSource(0xCAFEBABE))
+	proof := getIavlProof(r)
+	root := calculate(t, ics23proto.IavlSpec, proof)
+	proof.Value[0] ^= 1
+	err := verifyMembership(t, IavlSpec, IavlSize, proof, root)
+	assert.Error(t, err)
+}
+
+func TestWrongKey(t *testing.T) {
+	r := rand.New(rand.NewSource(0xCAFEBABE))
+	proof := getIavlProof(r)
+	root := calculate(t, ics23proto.IavlSpec, proof)
+	proof.Key = append(proof.Key, 0)
+	err := verifyMembership(t, IavlSpec, IavlSize, proof, root)
+	assert.Error(t, err)
+}
+
+func TestInnerPrefixCantBeLeafPrefix(t *testing.T) {
+	r := rand.New(rand.NewSource(0xDEADBEEF))
+	proof := getTendermintProof(r)
+	proof.Path = append(proof.Path, &ics23proto.InnerOp{
+		Hash:   ics23proto.HashOp_SHA256,
+		Prefix: []byte{0},
+		Suffix: readBytes(r, 32),
+	})
+	root, err := proof.Calculate()
+	assert.NoError(t, err)
+	err = verifyMembership(t, TendermintSpec, TendermintSize, proof, root)
+	assert.Error(t, err)
+}
+
+func TestInnerSuffixMalformed(t *testing.T) {
+	r := rand.New(rand.NewSource(0xDEADBEEF))
+	proof := getTendermintProof(r)
+	proof.Path = append(proof.Path, &ics23proto.InnerOp{
+		Hash:   ics23proto.HashOp_SHA256,
+		Prefix: []byte{1},
+		Suffix: readBytes(r, 16),
+	})
+	root, err := proof.Calculate()
+	assert.NoError(t, err)
+	err = verifyMembership(t, TendermintSpec, TendermintSize, proof, root)
+	assert.Error(t, err)
+}
+
+func TestLeafPrefixMismatch(t *testing.T) {
+	r := rand.New(rand.NewSource(0xDEADBEEF))
+	proof := getTendermintProof(r)
+	proof.Leaf.Prefix = []byte{1}
+	root, err := proof.Calculate()
+	assert.NoError(t, err)
+	err = verifyMembership(t, TendermintSpec, TendermintSize, proof, root)
+	assert.Error(t, err)
+}
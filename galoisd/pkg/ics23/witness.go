@@ -0,0 +1,132 @@
+package ics23
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	ics23proto "github.com/cosmos/ics23/go"
+)
+
+// Maximum sizes of an existence proof, fixed at circuit compilation
+type ProofSize struct {
+	KeySize        int
+	ValueSize      int
+	LeafPrefixSize int
+	Depth          int
+}
+
+func zeroes(size int) []frontend.Variable {
+	xs := make([]frontend.Variable, size)
+	for i := 0; i < size; i++ {
+		xs[i] = 0
+	}
+	return xs
+}
+
+func bytesToVariables(bz []byte, size int) ([]frontend.Variable, error) {
+	if len(bz) > size {
+		return nil, fmt.Errorf("too many bytes: %d > %d", len(bz), size)
+	}
+	xs := zeroes(size)
+	for i := 0; i < len(bz); i++ {
+		xs[i] = bz[i]
+	}
+	return xs, nil
+}
+
+// Well formed inner op used to fill the unused path
+func paddingInnerOp(spec ProofSpec) InnerOp {
+	prefix := zeroes(spec.MaxInnerPrefixSize())
+	prefix[0] = ^spec.LeafPrefix
+	return InnerOp{
+		Prefix:    prefix,
+		PrefixLen: spec.MinPrefixLength,
+		Suffix:    zeroes(spec.MaxInnerSuffixSize()),
+		SuffixLen: 0,
+	}
+}
+
+// Allocate an existence proof, as required to compile a circuit
+func (s ProofSize) Allocate(spec ProofSpec) ExistenceProof {
+	path := make([]InnerOp, s.Depth)
+	for i := 0; i < s.Depth; i++ {
+		path[i] = InnerOp{
+			Prefix: make([]frontend.Variable, spec.MaxInnerPrefixSize()),
+			Suffix: make([]frontend.Variable, spec.MaxInnerSuffixSize()),
+		}
+	}
+	return ExistenceProof{
+		Key:   make([]frontend.Variable, s.KeySize),
+		Value: make([]frontend.Variable, s.ValueSize),
+		Leaf: LeafOp{
+			Prefix: make([]frontend.Variable, s.LeafPrefixSize),
+		},
+		Path: path,
+	}
+}
+
+// Assign an ICS23 existence proof to the circuit representation
+func NewExistenceProof(spec ProofSpec, size ProofSize, proof *ics23proto.ExistenceProof) (ExistenceProof, error) {
+	leaf := proof.GetLeaf()
+	if leaf == nil {
+		return ExistenceProof{}, fmt.Errorf("missing leaf op")
+	}
+	if leaf.Hash != ics23proto.HashOp_SHA256 ||
+		leaf.PrehashKey != ics23proto.HashOp_NO_HASH ||
+		leaf.PrehashValue != ics23proto.HashOp_SHA256 ||
+		leaf.Length != ics23proto.LengthOp_VAR_PROTO {
+		return ExistenceProof{}, fmt.Errorf("unsupported leaf op: %v", leaf)
+	}
+	if len(proof.Path) > size.Depth {
+		return ExistenceProof{}, fmt.Errorf("path too long: %d > %d", len(proof.Path), size.Depth)
+	}
+	key, err := bytesToVariables(proof.Key, size.KeySize)
+	if err != nil {
+		return ExistenceProof{}, fmt.Errorf("key: %w", err)
+	}
+	value, err := bytesToVariables(proof.Value, size.ValueSize)
+	if err != nil {
+		return ExistenceProof{}, fmt.Errorf("value: %w", err)
+	}
+	leafPrefix, err := bytesToVariables(leaf.Prefix, size.LeafPrefixSize)
+	if err != nil {
+		return ExistenceProof{}, fmt.Errorf("leaf prefix: %w", err)
+	}
+	path := make([]InnerOp, size.Depth)
+	for i := 0; i < size.Depth; i++ {
+		if i >= len(proof.Path) {
+			path[i] = paddingInnerOp(spec)
+			continue
+		}
+		inner := proof.Path[i]
+		if inner.Hash != ics23proto.HashOp_SHA256 {
+			return ExistenceProof{}, fmt.Errorf("unsupported inner op hash: %v", inner.Hash)
+		}
+		prefix, err := bytesToVariables(inner.Prefix, spec.MaxInnerPrefixSize())
+		if err != nil {
+			return ExistenceProof{}, fmt.Errorf("inner prefix %d: %w", i, err)
+		}
+		suffix, err := bytesToVariables(inner.Suffix, spec.MaxInnerSuffixSize())
+		if err != nil {
+			return ExistenceProof{}, fmt.Errorf("inner suffix %d: %w", i, err)
+		}
+		path[i] = InnerOp{
+			Prefix:    prefix,
+			PrefixLen: len(inner.Prefix),
+			Suffix:    suffix,
+			SuffixLen: len(inner.Suffix),
+		}
+	}
+	return ExistenceProof{
+		Key:      key,
+		KeyLen:   len(proof.Key),
+		Value:    value,
+		ValueLen: len(proof.Value),
+		Leaf: LeafOp{
+			Prefix:    leafPrefix,
+			PrefixLen: len(leaf.Prefix),
+		},
+		Path:    path,
+		PathLen: len(proof.Path),
+	}, nil
+}
@@ -8,13 +8,17 @@ import (
 	"github.com/consensys/gnark/frontend"
 	gadget "github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
-	"github.com/consensys/gnark/std/hash/mimc"
 	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/rangecheck"
 )
 
 // Max number of validators the light client can handle
 const MaxVal = 128
 
+// CometBFT caps the total voting power to MaxInt64/8 = 2^60 - 1, hence both
+// individual and accumulated voting powers fit in 60 bits
+const PowerBitSize = 60
+
 type Validator struct {
 	HashableX    frontend.Variable
 	HashableXMSB frontend.Variable
@@ -34,10 +38,18 @@ type TendermintLightClientInput struct {
 type TendermintLightClientAPI struct {
 	api   frontend.API
 	input *TendermintLightClientInput
+	hash  merkle.Hash
 }
 
 func NewTendermintLightClientAPI(api frontend.API, input *TendermintLightClientInput) *TendermintLightClientAPI {
-	return &TendermintLightClientAPI{api: api, input: input}
+	return &TendermintLightClientAPI{api: api, input: input, hash: merkle.MiMC}
+}
+
+// Use the given hash for the validator leaves and the validators merkle tree
+// instead of MiMC.
+func (lc *TendermintLightClientAPI) WithHash(hash merkle.Hash) *TendermintLightClientAPI {
+	lc.hash = hash
+	return lc
 }
 
 // Given a variable of size N and limbs of size M, split the variable in N/M limbs.
@@ -85,9 +97,9 @@ func (lc *TendermintLightClientAPI) Verify(message *gadget.G2Affine, expectedVal
 		bitmapMask := lc.input.NbOfVal
 		for i, signed := range bitmap {
 			validator := lc.input.Validators[i]
-			h, err := mimc.NewMiMC(lc.api)
+			h, err := lc.hash.New(lc.api)
 			if err != nil {
-				return fmt.Errorf("new mimc: %w", err)
+				return fmt.Errorf("new hash: %w", err)
 			}
 			// Union whitepaper: (11) H_pre
 			//
@@ -124,9 +136,13 @@ func (lc *TendermintLightClientAPI) Verify(message *gadget.G2Affine, expectedVal
 	totalVotingPower := frontend.Variable(0)
 	currentVotingPower := frontend.Variable(0)
 
+	// Voting powers are summed as field elements, the range checks prevent
+	// the prover from wrapping the sums around the scalar field modulus
+	rangeChecker := rangecheck.New(lc.api)
+
 	leafHashes := make([]frontend.Variable, MaxVal)
 
-	merkle := merkle.NewMerkleTreeAPI(lc.api)
+	merkle := merkle.NewMerkleTreeAPIWithHash(lc.api, lc.hash)
 
 	bls, err := bls.NewBlsAPI(lc.api)
 	if err != nil {
@@ -137,6 +153,7 @@ func (lc *TendermintLightClientAPI) Verify(message *gadget.G2Affine, expectedVal
 		func(aggregate func(selector frontend.Variable, publicKey *sw_emulated.AffinePoint[emulated.BN254Fp])) error {
 			if err := forEachVal(func(i int, signed frontend.Variable, cannotSign frontend.Variable, publicKey *gadget.G1Affine, power frontend.Variable, leaf frontend.Variable) error {
 				actuallySigned := lc.api.Select(cannotSign, 0, signed)
+				rangeChecker.Check(power, PowerBitSize)
 				// totalVotingPower = totalVotingPower + power
 				totalVotingPower = lc.api.Add(totalVotingPower, lc.api.Select(cannotSign, 0, power))
 				// currentVotingPower = currentVotingPower + if signed then power else 0
@@ -157,7 +174,13 @@ func (lc *TendermintLightClientAPI) Verify(message *gadget.G2Affine, expectedVal
 	// Ensure that we actually aggregated the correct number of signatures
 	lc.api.AssertIsEqual(nbOfKeys, lc.input.NbOfSignature)
 
+	// Ensure that the sums are bounded, the current voting power being a
+	// subset of the total voting power
+	rangeChecker.Check(totalVotingPower, PowerBitSize)
+	rangeChecker.Check(currentVotingPower, PowerBitSize)
+
 	// Ensure that the current sum of voting power exceed the expected threshold
+	// Both sides can't wrap as long as the ratio terms are small constants
 	votingPowerNeeded := lc.api.Mul(totalVotingPower, powerNumerator)
 	currentVotingPowerScaled := lc.api.Mul(currentVotingPower, powerDenominator)
 	lc.api.AssertIsLessOrEqual(votingPowerNeeded, currentVotingPowerScaled)
@@ -1,12 +1,19 @@
 package lightclient
 
 import (
+	"crypto/rand"
 	"fmt"
+	"galois/pkg/lightclient/native"
+	"math/big"
 	"testing"
 
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
 	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
 	"github.com/consensys/gnark/frontend"
+	gadget "github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/test"
 	"github.com/stretchr/testify/assert"
 )
@@ -40,3 +47,146 @@ func TestUnpackRepackISO(t *testing.T) {
 		})
 	}
 }
+
+type Quorum struct {
+	Input   TendermintLightClientInput
+	Message gadget.G2Affine
+	ValRoot frontend.Variable
+}
+
+func (c *Quorum) Define(api frontend.API) error {
+	lc := NewTendermintLightClientAPI(api, &c.Input)
+	return lc.Verify(&c.Message, c.ValRoot, 1, 3)
+}
+
+// Build a validator set with arbitrary (possibly out of range) powers, where
+// the validators at the given indices signed a random message. Unlike the
+// CometBLS encoding, the powers are hashed as field elements so that the
+// validators root commits to the overflowing powers.
+func quorumAssignment(t *testing.T, powers []*big.Int, signers []int) *Quorum {
+	var message [32]byte
+	_, err := rand.Read(message[:])
+	assert.NoError(t, err)
+
+	assignment := &Quorum{
+		Message: gadget.NewG2Affine(cometbn254.HashToG2(message[:])),
+	}
+	for i := 0; i < MaxVal; i++ {
+		assignment.Input.Validators[i] = Validator{
+			HashableX:    0,
+			HashableXMSB: 0,
+			HashableY:    0,
+			HashableYMSB: 0,
+			Power:        0,
+		}
+	}
+
+	privKeys := make([]cometbn254.PrivKey, len(powers))
+	leafHashes := make([][]byte, len(powers))
+	for i, power := range powers {
+		privKeys[i] = cometbn254.GenPrivKey()
+		var publicKey curve.G1Affine
+		_, err := publicKey.SetBytes(privKeys[i].PubKey().Bytes())
+		assert.NoError(t, err)
+		leaf, err := cometbn254.NewMerkleLeaf(publicKey, 0)
+		assert.NoError(t, err)
+		assignment.Input.Validators[i] = Validator{
+			HashableX:    leaf.ShiftedX,
+			HashableXMSB: leaf.MsbX,
+			HashableY:    leaf.ShiftedY,
+			HashableYMSB: leaf.MsbY,
+			Power:        power,
+		}
+		var msbX, msbY, powerElement fr.Element
+		msbX.SetUint64(uint64(leaf.MsbX))
+		msbY.SetUint64(uint64(leaf.MsbY))
+		powerElement.SetBigInt(power)
+		h := mimc.NewMiMC()
+		for _, x := range []fr.Element{leaf.ShiftedX, leaf.ShiftedY, msbX, msbY, powerElement} {
+			b := x.Bytes()
+			h.Write(b[:])
+		}
+		leafHashes[i] = native.LeafHash(h.Sum(nil))
+	}
+
+	var bitmap big.Int
+	var aggregatedSignature curve.G2Affine
+	for _, i := range signers {
+		signature, err := privKeys[i].Sign(message[:])
+		assert.NoError(t, err)
+		var decompressedSignature curve.G2Affine
+		_, err = decompressedSignature.SetBytes(signature)
+		assert.NoError(t, err)
+		aggregatedSignature.Add(&aggregatedSignature, &decompressedSignature)
+		bitmap.SetBit(&bitmap, i, 1)
+	}
+
+	assignment.Input.Sig = gadget.NewG2Affine(aggregatedSignature)
+	assignment.Input.NbOfVal = len(powers)
+	assignment.Input.NbOfSignature = len(signers)
+	assignment.Input.Bitmap = bitmap
+	assignment.ValRoot = native.RootHash(leafHashes)
+	return assignment
+}
+
+func TestQuorumPowerRange(t *testing.T) {
+	t.Parallel()
+
+	maxPower := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), PowerBitSize), big.NewInt(1))
+	// p - 300, which cancels out the honest voting power of the other validators
+	wrappingPower := new(big.Int).Sub(fr.Modulus(), big.NewInt(300))
+
+	cases := []struct {
+		name    string
+		powers  []*big.Int
+		signers []int
+		valid   bool
+	}{
+		{
+			name:    "in range",
+			powers:  []*big.Int{big.NewInt(100), big.NewInt(100), big.NewInt(100), big.NewInt(100)},
+			signers: []int{0, 1},
+			valid:   true,
+		},
+		{
+			name:    "max power",
+			powers:  []*big.Int{maxPower},
+			signers: []int{0},
+			valid:   true,
+		},
+		{
+			name:    "power wraps around the modulus",
+			powers:  []*big.Int{big.NewInt(100), wrappingPower, big.NewInt(100), big.NewInt(100)},
+			signers: []int{0},
+			valid:   false,
+		},
+		{
+			name:    "power exceeds max",
+			powers:  []*big.Int{new(big.Int).Add(maxPower, big.NewInt(1))},
+			signers: []int{0},
+			valid:   false,
+		},
+		{
+			name:    "total power exceeds max",
+			powers:  []*big.Int{maxPower, maxPower, maxPower, maxPower},
+			signers: []int{0, 1, 2, 3},
+			valid:   false,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.IsSolved(
+				&Quorum{},
+				quorumAssignment(t, c.powers, c.signers),
+				ecc.BN254.ScalarField(),
+			)
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -12,7 +12,6 @@ import (
 
 	types "github.com/cometbft/cometbft/api/cometbft/types/v1"
 	gadget "github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
-	"github.com/consensys/gnark/std/hash/mimc"
 )
 
 type UnconsHash struct {
@@ -62,6 +61,7 @@ type BlockHeaderAPI struct {
 	header      BlockHeader
 	api         frontend.API
 	binaryField *uints.BinaryField[uints.U32]
+	hash        merkle.Hash
 }
 
 func NewBlockHeaderAPI(api frontend.API, header BlockHeader, vote BlockVote) (*BlockHeaderAPI, error) {
@@ -74,9 +74,17 @@ func NewBlockHeaderAPI(api frontend.API, header BlockHeader, vote BlockVote) (*B
 		vote:        vote,
 		header:      header,
 		binaryField: binaryField,
+		hash:        merkle.MiMC,
 	}, nil
 }
 
+// Use the given hash for the block hash and the vote sign bytes instead of
+// MiMC.
+func (b *BlockHeaderAPI) WithHash(hash merkle.Hash) *BlockHeaderAPI {
+	b.hash = hash
+	return b
+}
+
 func (b *BlockHeaderAPI) unpack(x frontend.Variable) []uints.U8 {
 	split := Unpack(b.api, x, 256, 8)
 	slices.Reverse(split)
@@ -117,6 +125,11 @@ func (b *BlockHeaderAPI) unpackHash(x *UnconsHash) []uints.U8 {
 	return hash
 }
 
+// The header AppHash, as 32 bytes
+func (b *BlockHeaderAPI) AppHash() []uints.U8 {
+	return b.unpackHash(&b.header.AppHash)
+}
+
 func (b *BlockHeaderAPI) VerifyInputs(expectedHash frontend.Variable, trustedValRoot frontend.Variable) error {
 	expectedHashBytes := b.unpack(expectedHash)
 	hash, err := b.InputsHash(trustedValRoot)
@@ -149,7 +162,7 @@ func (b *BlockHeaderAPI) InputsHash(trustedValRoot frontend.Variable) ([]uints.U
 }
 
 func (b *BlockHeaderAPI) BlockHash() frontend.Variable {
-	m := merkle.NewMerkleTreeAPI(b.api)
+	m := merkle.NewMerkleTreeAPIWithHash(b.api, b.hash)
 	uncons := func(x *UnconsHash) frontend.Variable {
 		leaves := []frontend.Variable{
 			x.Head,
@@ -187,9 +200,9 @@ func (b *BlockHeaderAPI) BlockHash() frontend.Variable {
 }
 
 func (b *BlockHeaderAPI) VoteSignBytes() (frontend.Variable, error) {
-	h, err := mimc.NewMiMC(b.api)
+	h, err := b.hash.New(b.api)
 	if err != nil {
-		return nil, fmt.Errorf("new mimc: %w", err)
+		return nil, fmt.Errorf("new hash: %w", err)
 	}
 	// Vote structure
 	h.Write(int64(types.PrecommitType))
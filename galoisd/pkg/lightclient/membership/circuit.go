@@ -0,0 +1,135 @@
+package membership
+
+import (
+	"crypto/sha256"
+	"galois/pkg/ics23"
+	"galois/pkg/lightclient"
+	"galois/pkg/lightclient/nonadjacent"
+	"slices"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+const (
+	MaxKeySize   = 128
+	MaxValueSize = 64
+	// varint(height) || varint(size) || varint(version)
+	MaxLeafPrefixSize = 12
+	MaxDepth          = 32
+
+	MaxStoreKeySize = 32
+	MaxStoreDepth   = 8
+)
+
+var (
+	// IAVL proof of the key/value in the module store
+	ProofSize = ics23.ProofSize{
+		KeySize:        MaxKeySize,
+		ValueSize:      MaxValueSize,
+		LeafPrefixSize: MaxLeafPrefixSize,
+		Depth:          MaxDepth,
+	}
+	// Tendermint proof of the module store root in the AppHash
+	StoreProofSize = ics23.ProofSize{
+		KeySize:        MaxStoreKeySize,
+		ValueSize:      sha256.Size,
+		LeafPrefixSize: 1,
+		Depth:          MaxStoreDepth,
+	}
+)
+
+// Proves that the header is finalized (see nonadjacent.Circuit) and that the
+// key has the given value in the state committed by the header AppHash.
+type Circuit struct {
+	LightClient    nonadjacent.Circuit
+	Proof          ics23.ExistenceProof
+	StoreProof     ics23.ExistenceProof
+	MembershipHash frontend.Variable `gnark:",public"`
+}
+
+// Allocate the proofs, required before compiling the circuit
+func NewCircuit() *Circuit {
+	return &Circuit{
+		Proof:      ProofSize.Allocate(ics23.IavlSpec),
+		StoreProof: StoreProofSize.Allocate(ics23.TendermintSpec),
+	}
+}
+
+func (circuit *Circuit) Define(api frontend.API) error {
+	err := circuit.LightClient.Define(api)
+	if err != nil {
+		return err
+	}
+	bhapi, err := lightclient.NewBlockHeaderAPI(api, circuit.LightClient.Header, circuit.LightClient.Vote)
+	if err != nil {
+		return err
+	}
+	return VerifyMembership(api, bhapi.AppHash(), &circuit.Proof, &circuit.StoreProof, circuit.MembershipHash)
+}
+
+// Verify the chained ICS23 proofs (IAVL then Tendermint) against the AppHash
+// and bind the store key, key and value to the membership hash.
+func VerifyMembership(api frontend.API, appHash []uints.U8, proof *ics23.ExistenceProof, storeProof *ics23.ExistenceProof, expectedHash frontend.Variable) error {
+	iavl, err := ics23.NewICS23API(api, ics23.IavlSpec)
+	if err != nil {
+		return err
+	}
+	store, err := ics23.NewICS23API(api, ics23.TendermintSpec)
+	if err != nil {
+		return err
+	}
+	storeRoot, err := iavl.RootHash(proof)
+	if err != nil {
+		return err
+	}
+	// The value of the store proof is the root of the module store
+	api.AssertIsEqual(storeProof.ValueLen, len(storeRoot))
+	for i := 0; i < len(storeRoot); i++ {
+		api.AssertIsEqual(storeProof.Value[i], storeRoot[i].Val)
+	}
+	err = store.VerifyMembership(storeProof, appHash)
+	if err != nil {
+		return err
+	}
+	storeKeyHash, err := store.Sum(storeProof.Key, storeProof.KeyLen)
+	if err != nil {
+		return err
+	}
+	keyHash, err := iavl.Sum(proof.Key, proof.KeyLen)
+	if err != nil {
+		return err
+	}
+	valueHash, err := iavl.Sum(proof.Value, proof.ValueLen)
+	if err != nil {
+		return err
+	}
+	h, err := sha2.New(api)
+	if err != nil {
+		return err
+	}
+	h.Write(storeKeyHash)
+	h.Write(keyHash)
+	h.Write(valueHash)
+	hash := h.Sum()
+	expectedHashBytes := lightclient.Unpack(api, expectedHash, 256, 8)
+	slices.Reverse(expectedHashBytes)
+	// Truncate most significant byte of the sha256 hash to fit in a single public input
+	for i := 1; i < len(hash); i++ {
+		api.AssertIsEqual(expectedHashBytes[i], hash[i].Val)
+	}
+	return nil
+}
+
+// sha256(sha256(storeKey) || sha256(key) || sha256(value)) with the most
+// significant byte truncated, as expected for the public input.
+func MembershipHash(storeKey []byte, key []byte, value []byte) []byte {
+	buff := []byte{}
+	for _, b := range [][]byte{storeKey, key, value} {
+		hash := sha256.Sum256(b)
+		buff = append(buff, hash[:]...)
+	}
+	hash := sha256.Sum256(buff)
+	return hash[1:]
+}
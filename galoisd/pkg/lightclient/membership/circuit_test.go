@@ -0,0 +1,212 @@
+package membership
+
+import (
+	"encoding/binary"
+	"galois/pkg/ics23"
+	"galois/pkg/lightclient"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+	ics23proto "github.com/cosmos/ics23/go"
+	"github.com/stretchr/testify/assert"
+)
+
+type Membership struct {
+	Vote           lightclient.BlockVote
+	Header         lightclient.BlockHeader
+	Proof          ics23.ExistenceProof
+	StoreProof     ics23.ExistenceProof
+	MembershipHash frontend.Variable `gnark:",public"`
+}
+
+func (c *Membership) Define(api frontend.API) error {
+	bhapi, err := lightclient.NewBlockHeaderAPI(api, c.Header, c.Vote)
+	if err != nil {
+		return err
+	}
+	return VerifyMembership(api, bhapi.AppHash(), &c.Proof, &c.StoreProof, c.MembershipHash)
+}
+
+func readBytes(r *rand.Rand, size int) []byte {
+	bz := make([]byte, size)
+	r.Read(bz)
+	return bz
+}
+
+func leafOp(prefix []byte) *ics23proto.LeafOp {
+	return &ics23proto.LeafOp{
+		Hash:         ics23proto.HashOp_SHA256,
+		PrehashKey:   ics23proto.HashOp_NO_HASH,
+		PrehashValue: ics23proto.HashOp_SHA256,
+		Length:       ics23proto.LengthOp_VAR_PROTO,
+		Prefix:       prefix,
+	}
+}
+
+func getProofs(r *rand.Rand, key []byte, value []byte) (*ics23proto.ExistenceProof, *ics23proto.ExistenceProof, []byte, error) {
+	version := r.Int63n(1 << 40)
+	leafPrefix := binary.AppendVarint(nil, 0)
+	leafPrefix = binary.AppendVarint(leafPrefix, 1)
+	leafPrefix = binary.AppendVarint(leafPrefix, version)
+	path := make([]*ics23proto.InnerOp, 1+r.Intn(16))
+	for i := 0; i < len(path); i++ {
+		prefix := binary.AppendVarint(nil, int64(i+1))
+		prefix = binary.AppendVarint(prefix, int64(1<<(i+1)))
+		prefix = binary.AppendVarint(prefix, version)
+		prefix = append(prefix, 32)
+		var suffix []byte
+		if r.Intn(2) == 0 {
+			suffix = append([]byte{32}, readBytes(r, 32)...)
+		} else {
+			prefix = append(append(prefix, readBytes(r, 32)...), 32)
+		}
+		path[i] = &ics23proto.InnerOp{Hash: ics23proto.HashOp_SHA256, Prefix: prefix, Suffix: suffix}
+	}
+	proof := &ics23proto.ExistenceProof{
+		Key:   key,
+		Value: value,
+		Leaf:  leafOp(leafPrefix),
+		Path:  path,
+	}
+	storeRoot, err := proof.Calculate()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	storePath := make([]*ics23proto.InnerOp, 1+r.Intn(5))
+	for i := 0; i < len(storePath); i++ {
+		prefix := []byte{1}
+		var suffix []byte
+		if r.Intn(2) == 0 {
+			suffix = readBytes(r, 32)
+		} else {
+			prefix = append(prefix, readBytes(r, 32)...)
+		}
+		storePath[i] = &ics23proto.InnerOp{Hash: ics23proto.HashOp_SHA256, Prefix: prefix, Suffix: suffix}
+	}
+	storeProof := &ics23proto.ExistenceProof{
+		Key:   []byte("ibc"),
+		Value: storeRoot,
+		Leaf:  leafOp([]byte{0}),
+		Path:  storePath,
+	}
+	appHash, err := storeProof.Calculate()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return proof, storeProof, appHash, nil
+}
+
+func verifyMembership(t *testing.T, proof *ics23proto.ExistenceProof, storeProof *ics23proto.ExistenceProof, appHash []byte, membershipHash []byte) error {
+	assignedProof, err := ics23.NewExistenceProof(ics23.IavlSpec, ProofSize, proof)
+	assert.NoError(t, err)
+	assignedStoreProof, err := ics23.NewExistenceProof(ics23.TendermintSpec, StoreProofSize, storeProof)
+	assert.NoError(t, err)
+	circuit := NewCircuit()
+	zero := lightclient.UnconsHash{Head: 0, Tail: 0}
+	return test.IsSolved(
+		&Membership{
+			Proof:      circuit.Proof,
+			StoreProof: circuit.StoreProof,
+		},
+		&Membership{
+			Vote: lightclient.BlockVote{
+				BlockPartSetHeaderTotal: 0,
+				BlockPartSetHeaderHash:  zero,
+				Round:                   0,
+			},
+			Header: lightclient.BlockHeader{
+				VersionBlock:                0,
+				VersionApp:                  0,
+				ChainID:                     0,
+				Height:                      0,
+				TimeSecs:                    0,
+				TimeNanos:                   0,
+				LastBlockHash:               0,
+				LastBlockPartSetHeaderTotal: 0,
+				LastBlockPartSetHeaderHash:  zero,
+				LastCommitHash:              zero,
+				DataHash:                    zero,
+				ValidatorsHash:              0,
+				NextValidatorsHash:          0,
+				ConsensusHash:               zero,
+				AppHash: lightclient.UnconsHash{
+					Head: appHash[0],
+					Tail: appHash[1:],
+				},
+				LastResultsHash: zero,
+				EvidenceHash:    zero,
+				ProposerAddress: zero,
+			},
+			Proof:          assignedProof,
+			StoreProof:     assignedStoreProof,
+			MembershipHash: membershipHash,
+		},
+		ecc.BN254.ScalarField(),
+	)
+}
+
+func FuzzMembership(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+		key := []byte("commitments/ports/transfer/channels/channel-0/sequences/1")
+		value := readBytes(r, 32)
+		proof, storeProof, appHash, err := getProofs(r, key, value)
+		assert.NoError(t, err)
+		assert.True(t, ics23proto.VerifyMembership(
+			ics23proto.IavlSpec,
+			storeProof.Value,
+			&ics23proto.CommitmentProof{Proof: &ics23proto.CommitmentProof_Exist{Exist: proof}},
+			key,
+			value,
+		))
+		assert.True(t, ics23proto.VerifyMembership(
+			ics23proto.TendermintSpec,
+			appHash,
+			&ics23proto.CommitmentProof{Proof: &ics23proto.CommitmentProof_Exist{Exist: storeProof}},
+			storeProof.Key,
+			storeProof.Value,
+		))
+		err = verifyMembership(t, proof, storeProof, appHash, MembershipHash(storeProof.Key, key, value))
+		assert.NoError(t, err)
+	})
+}
+
+func TestWrongMembershipHash(t *testing.T) {
+	r := rand.New(rand.NewSource(0xCAFEBABE))
+	key := []byte("commitments/ports/transfer/channels/channel-0/sequences/1")
+	value := readBytes(r, 32)
+	proof, storeProof, appHash, err := getProofs(r, key, value)
+	assert.NoError(t, err)
+	otherValue := append([]byte{}, value...)
+	otherValue[0] ^= 1
+	err = verifyMembership(t, proof, storeProof, appHash, MembershipHash(storeProof.Key, key, otherValue))
+	assert.Error(t, err)
+}
+
+func TestWrongAppHash(t *testing.T) {
+	r := rand.New(rand.NewSource(0xCAFEBABE))
+	key := []byte("commitments/ports/transfer/channels/channel-0/sequences/1")
+	value := readBytes(r, 32)
+	proof, storeProof, appHash, err := getProofs(r, key, value)
+	assert.NoError(t, err)
+	appHash[31] ^= 1
+	err = verifyMembership(t, proof, storeProof, appHash, MembershipHash(storeProof.Key, key, value))
+	assert.Error(t, err)
+}
+
+func TestStoreRootMismatch(t *testing.T) {
+	r := rand.New(rand.NewSource(0xCAFEBABE))
+	key := []byte("commitments/ports/transfer/channels/channel-0/sequences/1")
+	value := readBytes(r, 32)
+	proof, _, _, err := getProofs(r, key, value)
+	assert.NoError(t, err)
+	// Store proof committing to an unrelated module store root
+	_, storeProof, appHash, err := getProofs(r, key, readBytes(r, 32))
+	assert.NoError(t, err)
+	err = verifyMembership(t, proof, storeProof, appHash, MembershipHash(storeProof.Key, key, value))
+	assert.Error(t, err)
+}
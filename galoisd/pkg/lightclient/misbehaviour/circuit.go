@@ -0,0 +1,59 @@
+package misbehaviour
+
+import (
+	"galois/pkg/lightclient"
+	"galois/pkg/lightclient/nonadjacent"
+
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	"github.com/consensys/gnark/frontend"
+)
+
+// A header signed by the trusted validator set
+type ConflictingHeader struct {
+	TrustedInput nonadjacent.TendermintNonAdjacentLightClientInput
+	Vote         lightclient.BlockVote
+	Header       lightclient.BlockHeader
+}
+
+// Proves that the trusted validator set double-signed, i.e. that two distinct
+// headers at the same height of the same chain were each signed by enough
+// voting power of the trusted set to convince the light client (see
+// nonadjacent.TrustedRatioNum/nonadjacent.TrustedRatioDen).
+type Circuit struct {
+	Header1        ConflictingHeader
+	Header2        ConflictingHeader
+	TrustedValRoot frontend.Variable `gnark:",public"`
+	BlockHash1     frontend.Variable `gnark:",public"`
+	BlockHash2     frontend.Variable `gnark:",public"`
+}
+
+func verifyHeader(api frontend.API, header *ConflictingHeader, trustedValRoot frontend.Variable, expectedBlockHash frontend.Variable) error {
+	bhapi, err := lightclient.NewBlockHeaderAPI(api, header.Header, header.Vote)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(bhapi.BlockHash(), expectedBlockHash)
+	hashedMessage, err := bhapi.HashToCurve([]byte(cometbn254.CometblsSigDST))
+	if err != nil {
+		return err
+	}
+	lc := lightclient.NewTendermintLightClientAPI(api, &lightclient.TendermintLightClientInput{
+		Sig:           header.TrustedInput.Sig,
+		Validators:    header.TrustedInput.Validators,
+		NbOfVal:       header.TrustedInput.NbOfVal,
+		NbOfSignature: header.TrustedInput.NbOfSignature,
+		Bitmap:        header.TrustedInput.Bitmap,
+	})
+	return lc.Verify(hashedMessage, trustedValRoot, nonadjacent.TrustedRatioNum, nonadjacent.TrustedRatioDen)
+}
+
+func (circuit *Circuit) Define(api frontend.API) error {
+	api.AssertIsEqual(circuit.Header1.Header.ChainID, circuit.Header2.Header.ChainID)
+	api.AssertIsEqual(circuit.Header1.Header.Height, circuit.Header2.Header.Height)
+	api.AssertIsDifferent(circuit.BlockHash1, circuit.BlockHash2)
+	err := verifyHeader(api, &circuit.Header1, circuit.TrustedValRoot, circuit.BlockHash1)
+	if err != nil {
+		return err
+	}
+	return verifyHeader(api, &circuit.Header2, circuit.TrustedValRoot, circuit.BlockHash2)
+}
@@ -0,0 +1,368 @@
+package misbehaviour
+
+import (
+	"fmt"
+	"galois/pkg/lightclient"
+	"galois/pkg/lightclient/nonadjacent"
+	"math/big"
+	"math/rand"
+	"time"
+
+	tmtypes "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	version "github.com/cometbft/cometbft/api/cometbft/version/v1"
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	ce "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/types"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+
+	gadget "github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/test"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/stretchr/testify/assert"
+
+	"testing"
+)
+
+func marshalValidators(validators []*tmtypes.SimpleValidator) ([lightclient.MaxVal]lightclient.Validator, []byte, error) {
+	lcValidators := [lightclient.MaxVal]lightclient.Validator{}
+	// Make sure we zero initialize
+	for i := 0; i < lightclient.MaxVal; i++ {
+		lcValidators[i].HashableX = 0
+		lcValidators[i].HashableXMSB = 0
+		lcValidators[i].HashableY = 0
+		lcValidators[i].HashableYMSB = 0
+		lcValidators[i].Power = 0
+	}
+	merkleTree := make([][]byte, len(validators))
+	for i, val := range validators {
+		tmPK, err := ce.PubKeyFromProto(*val.PubKey)
+		if err != nil {
+			return lcValidators, nil, fmt.Errorf("Could not deserialize proto to tendermint public key %s", err)
+		}
+		var public curve.G1Affine
+		_, err = public.SetBytes(tmPK.Bytes())
+		if err != nil {
+			return lcValidators, nil, fmt.Errorf("Could not deserialize bn254 public key %s", err)
+		}
+		leaf, err := cometbn254.NewMerkleLeaf(public, val.VotingPower)
+		if err != nil {
+			return lcValidators, nil, fmt.Errorf("Could not create merkle leaf %s", err)
+		}
+		lcValidators[i].HashableX = leaf.ShiftedX
+		lcValidators[i].HashableY = leaf.ShiftedY
+		lcValidators[i].HashableXMSB = leaf.MsbX
+		lcValidators[i].HashableYMSB = leaf.MsbY
+		lcValidators[i].Power = leaf.VotingPower
+		merkleTree[i], err = leaf.Hash()
+		if err != nil {
+			return lcValidators, nil, fmt.Errorf("Could not create merkle hash %s", err)
+		}
+	}
+	return lcValidators, merkle.MimcHashFromByteSlices(merkleTree), nil
+}
+
+func aggregateSignatures(signatures [][]byte) (curve.G2Affine, error) {
+	var aggregatedSignature curve.G2Affine
+	var decompressedSignature curve.G2Affine
+	for _, signature := range signatures {
+		_, err := decompressedSignature.SetBytes(signature)
+		if err != nil {
+			return curve.G2Affine{}, fmt.Errorf("Could not decompress signature %s", err)
+		}
+		aggregatedSignature.Add(&aggregatedSignature, &decompressedSignature)
+	}
+	return aggregatedSignature, nil
+}
+
+func toValidator(pubKey []byte, power int64) (*tmtypes.SimpleValidator, error) {
+	protoPK, err := ce.PubKeyToProto(cometbn254.PubKey(pubKey))
+	if err != nil {
+		return &tmtypes.SimpleValidator{}, err
+	}
+	return &tmtypes.SimpleValidator{
+		PubKey:      &protoPK,
+		VotingPower: sdk.TokensToConsensusPower(math.NewInt(power), sdk.DefaultPowerReduction),
+	}, nil
+}
+
+func getBlockHeader(r *rand.Rand, chainID string, height int64, validatorsHash []byte) (*lightclient.BlockHeader, *lightclient.BlockVote, *types.Header, *tmtypes.Vote) {
+	trunc := func(b []byte) lightclient.UnconsHash {
+		return lightclient.UnconsHash{
+			Head: b[0],
+			Tail: b[1:],
+		}
+	}
+
+	readHash := func() []byte {
+		var hash [32]byte
+		r.Read(hash[:])
+		return hash[:]
+	}
+
+	partSetHeaderTotal := r.Uint32()
+	partSetHeaderHash := readHash()
+	round := r.Int31()
+	versionBlock := r.Uint64()
+	versionApp := r.Uint64()
+	time := time.Unix(r.Int63(), r.Int63())
+	lastBlockHash := readHash()[:1]
+	lastBlockPartSetHeaderTotal := r.Uint32()
+	lastBlockPartSetHeaderHash := readHash()
+	lastCommitHash := readHash()
+	dataHash := readHash()
+	consensusHash := readHash()
+	appHash := readHash()
+	lastResultsHash := readHash()
+	evidenceHash := readHash()
+	proposerAddress := readHash()
+
+	header := &lightclient.BlockHeader{
+		VersionBlock:                versionBlock,
+		VersionApp:                  versionApp,
+		ChainID:                     []byte(chainID),
+		Height:                      height,
+		TimeSecs:                    time.Unix(),
+		TimeNanos:                   time.Nanosecond(),
+		LastBlockHash:               lastBlockHash,
+		LastBlockPartSetHeaderTotal: lastBlockPartSetHeaderTotal,
+		LastBlockPartSetHeaderHash:  trunc(lastBlockPartSetHeaderHash),
+		LastCommitHash:              trunc(lastCommitHash),
+		DataHash:                    trunc(dataHash),
+		ValidatorsHash:              validatorsHash,
+		NextValidatorsHash:          validatorsHash,
+		ConsensusHash:               trunc(consensusHash),
+		AppHash:                     trunc(appHash),
+		LastResultsHash:             trunc(lastResultsHash),
+		EvidenceHash:                trunc(evidenceHash),
+		ProposerAddress:             trunc(proposerAddress),
+	}
+
+	vote := &lightclient.BlockVote{
+		BlockPartSetHeaderTotal: partSetHeaderTotal,
+		BlockPartSetHeaderHash:  trunc(partSetHeaderHash),
+		Round:                   round,
+	}
+
+	cometblsHeader := &types.Header{
+		Version: version.Consensus{
+			Block: versionBlock,
+			App:   versionApp,
+		},
+		ChainID: chainID,
+		Height:  height,
+		Time:    time,
+		LastBlockID: types.BlockID{
+			Hash: lastBlockHash,
+			PartSetHeader: types.PartSetHeader{
+				Total: lastBlockPartSetHeaderTotal,
+				Hash:  lastBlockPartSetHeaderHash,
+			},
+		},
+		LastCommitHash:     lastCommitHash,
+		DataHash:           dataHash,
+		ValidatorsHash:     validatorsHash,
+		NextValidatorsHash: validatorsHash,
+		ConsensusHash:      consensusHash,
+		AppHash:            appHash,
+		LastResultsHash:    lastResultsHash,
+		EvidenceHash:       evidenceHash,
+		ProposerAddress:    proposerAddress,
+	}
+
+	cometblsVote := &tmtypes.Vote{
+		Type:   tmtypes.PrecommitType,
+		Height: cometblsHeader.Height,
+		Round:  round,
+		BlockID: tmtypes.BlockID{
+			Hash: cometblsHeader.Hash(),
+			PartSetHeader: tmtypes.PartSetHeader{
+				Total: partSetHeaderTotal,
+				Hash:  partSetHeaderHash,
+			},
+		},
+	}
+
+	return header, vote, cometblsHeader, cometblsVote
+}
+
+type validatorSet struct {
+	privKeys   []cometbn254.PrivKey
+	validators []*tmtypes.SimpleValidator
+	input      [lightclient.MaxVal]lightclient.Validator
+	root       []byte
+	totalPower int64
+}
+
+func newValidatorSet(t *testing.T, r *rand.Rand, nbOfValidators uint32) *validatorSet {
+	set := &validatorSet{
+		privKeys:   make([]cometbn254.PrivKey, nbOfValidators),
+		validators: make([]*tmtypes.SimpleValidator, nbOfValidators),
+	}
+	for i := 0; i < len(set.validators); i++ {
+		set.privKeys[i] = cometbn254.GenPrivKey()
+		val, err := toValidator(set.privKeys[i].PubKey().Bytes(), 100000000+r.Int63n(100000000))
+		if err != nil {
+			t.Fatal(err)
+		}
+		set.totalPower += val.VotingPower
+		set.validators[i] = val
+	}
+	var err error
+	set.input, set.root, err = marshalValidators(set.validators)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return set
+}
+
+// Sign a random header at the given height with validators picked at random
+// until strictly more than `minPower` is reached.
+func (set *validatorSet) signHeader(t *testing.T, r *rand.Rand, chainID string, height int64, minPower int64) (ConflictingHeader, []byte) {
+	header, vote, cometblsHeader, cometblsVote := getBlockHeader(r, chainID, height, set.root)
+
+	signedBytes := types.VoteSignBytes(cometblsHeader.ChainID, cometblsVote)
+
+	nbOfValidators := uint32(len(set.validators))
+	var signatures [][]byte
+	var bitmap big.Int
+	votingPower := int64(0)
+	for votingPower <= minPower && len(signatures) < len(set.validators) {
+		i := uint32(r.Int31n(int32(nbOfValidators)))
+		for bitmap.Bit(int(i)) == 1 {
+			i = (i + 1) % nbOfValidators
+		}
+		votingPower += set.validators[i].VotingPower
+		bitmap.SetBit(&bitmap, int(i), 1)
+		sig, err := set.privKeys[i].Sign(signedBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signatures = append(signatures, sig)
+	}
+
+	aggregatedSignature, err := aggregateSignatures(signatures)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ConflictingHeader{
+		TrustedInput: nonadjacent.TendermintNonAdjacentLightClientInput{
+			Sig:           gadget.NewG2Affine(aggregatedSignature),
+			Validators:    set.input,
+			NbOfVal:       nbOfValidators,
+			NbOfSignature: len(signatures),
+			Bitmap:        bitmap,
+		},
+		Vote:   *vote,
+		Header: *header,
+	}, cometblsHeader.Hash()
+}
+
+func (set *validatorSet) trustedPower() int64 {
+	return set.totalPower * nonadjacent.TrustedRatioNum / nonadjacent.TrustedRatioDen
+}
+
+func FuzzMisbehaviour(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+
+		set := newValidatorSet(t, r, 1+r.Uint32()%lightclient.MaxVal)
+
+		chainID := fmt.Sprintf("union-devnet-%d", r.Uint64()%65535)
+		height := r.Int63()
+
+		header1, blockHash1 := set.signHeader(t, r, chainID, height, set.trustedPower())
+		header2, blockHash2 := set.signHeader(t, r, chainID, height, set.trustedPower())
+
+		err := test.IsSolved(
+			&Circuit{},
+			&Circuit{
+				Header1:        header1,
+				Header2:        header2,
+				TrustedValRoot: set.root,
+				BlockHash1:     blockHash1,
+				BlockHash2:     blockHash2,
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+	})
+}
+
+func TestMisbehaviourSameBlock(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(0))
+
+	set := newValidatorSet(t, r, 4)
+
+	header, blockHash := set.signHeader(t, r, "union-devnet-1", 1337, set.trustedPower())
+
+	err := test.IsSolved(
+		&Circuit{},
+		&Circuit{
+			Header1:        header,
+			Header2:        header,
+			TrustedValRoot: set.root,
+			BlockHash1:     blockHash,
+			BlockHash2:     blockHash,
+		},
+		ecc.BN254.ScalarField(),
+	)
+	assert.Error(t, err)
+}
+
+func TestMisbehaviourDifferentHeight(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(0))
+
+	set := newValidatorSet(t, r, 4)
+
+	header1, blockHash1 := set.signHeader(t, r, "union-devnet-1", 1337, set.trustedPower())
+	header2, blockHash2 := set.signHeader(t, r, "union-devnet-1", 1338, set.trustedPower())
+
+	err := test.IsSolved(
+		&Circuit{},
+		&Circuit{
+			Header1:        header1,
+			Header2:        header2,
+			TrustedValRoot: set.root,
+			BlockHash1:     blockHash1,
+			BlockHash2:     blockHash2,
+		},
+		ecc.BN254.ScalarField(),
+	)
+	assert.Error(t, err)
+}
+
+func TestMisbehaviourInsufficientPower(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(0))
+
+	set := newValidatorSet(t, r, 8)
+
+	// A single validator out of eight never holds more than 1/3 of the power
+	// as individual powers are within [100, 200).
+	header1, blockHash1 := set.signHeader(t, r, "union-devnet-1", 1337, 0)
+	header2, blockHash2 := set.signHeader(t, r, "union-devnet-1", 1337, set.trustedPower())
+
+	err := test.IsSolved(
+		&Circuit{},
+		&Circuit{
+			Header1:        header1,
+			Header2:        header2,
+			TrustedValRoot: set.root,
+			BlockHash1:     blockHash1,
+			BlockHash2:     blockHash2,
+		},
+		ecc.BN254.ScalarField(),
+	)
+	assert.Error(t, err)
+}
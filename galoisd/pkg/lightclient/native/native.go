@@ -0,0 +1,347 @@
+// Reference implementation of the light client gadgets, computed outside of
+// the circuit with the exact same encodings.
+package native
+
+import (
+	"fmt"
+	"galois/pkg/merkle"
+	"math/big"
+
+	tmtypes "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	ce "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/types"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+)
+
+const (
+	LeafPrefix  = 0
+	InnerPrefix = 1
+
+	MiMCBlockSize = 256
+)
+
+// Reduce an arbitrary big endian value to a 32 bytes scalar field element
+func element(x []byte) []byte {
+	var e fr.Element
+	e.SetBigInt(new(big.Int).SetBytes(x))
+	b := e.Bytes()
+	return b[:]
+}
+
+// Native gadgets computed with a given hash, see merkle.Hash
+type Hasher struct {
+	hash merkle.Hash
+}
+
+func NewHasher(hash merkle.Hash) *Hasher {
+	return &Hasher{hash: hash}
+}
+
+// The hash CometBLS currently commits with, used by the package level functions
+var MiMC = NewHasher(merkle.MiMC)
+
+func (n *Hasher) sum(xs ...[]byte) []byte {
+	h := n.hash.NewNative()
+	for _, x := range xs {
+		_, err := h.Write(element(x))
+		if err != nil {
+			panic(err)
+		}
+	}
+	return h.Sum(nil)
+}
+
+func i64(x int64) []byte {
+	return big.NewInt(x).Bytes()
+}
+
+func u64(x uint64) []byte {
+	return new(big.Int).SetUint64(x).Bytes()
+}
+
+// Union whitepaper: (11) H_leaf
+func LeafHash(leaf []byte) []byte {
+	return MiMC.LeafHash(leaf)
+}
+
+func (n *Hasher) LeafHash(leaf []byte) []byte {
+	return n.sum([]byte{LeafPrefix}, leaf)
+}
+
+// Union whitepaper: (11) H_inner
+func InnerHash(left []byte, right []byte) []byte {
+	return MiMC.InnerHash(left, right)
+}
+
+func (n *Hasher) InnerHash(left []byte, right []byte) []byte {
+	return n.sum([]byte{InnerPrefix}, left, right)
+}
+
+// Union whitepaper: (11) merkle_root
+//
+// The leaves are expected to be hashed already, see LeafHash.
+func RootHash(leafHashes [][]byte) []byte {
+	return MiMC.RootHash(leafHashes)
+}
+
+func (n *Hasher) RootHash(leafHashes [][]byte) []byte {
+	switch len(leafHashes) {
+	case 0:
+		return n.sum()
+	case 1:
+		return leafHashes[0]
+	default:
+		// Largest power of two strictly smaller than the size
+		k := 1
+		for k*2 < len(leafHashes) {
+			k *= 2
+		}
+		return n.InnerHash(n.RootHash(leafHashes[:k]), n.RootHash(leafHashes[k:]))
+	}
+}
+
+// Union whitepaper: (11) H_pre
+func ValidatorLeaf(publicKey curve.G1Affine, power int64) []byte {
+	return MiMC.ValidatorLeaf(publicKey, power)
+}
+
+func (n *Hasher) ValidatorLeaf(publicKey curve.G1Affine, power int64) []byte {
+	x := publicKey.X.BigInt(new(big.Int))
+	y := publicKey.Y.BigInt(new(big.Int))
+	msbX := x.Bit(253)
+	msbY := y.Bit(253)
+	x.SetBit(x, 253, 0)
+	y.SetBit(y, 253, 0)
+	return n.sum(x.Bytes(), y.Bytes(), []byte{byte(msbX)}, []byte{byte(msbY)}, i64(power))
+}
+
+func (n *Hasher) validatorsLeaves(validators []*tmtypes.SimpleValidator) ([][]byte, error) {
+	leafHashes := make([][]byte, len(validators))
+	for i, val := range validators {
+		tmPK, err := ce.PubKeyFromProto(*val.PubKey)
+		if err != nil {
+			return nil, fmt.Errorf("Could not deserialize proto to tendermint public key %s", err)
+		}
+		if _, ok := tmPK.(cometbn254.PubKey); !ok {
+			return nil, fmt.Errorf("Not a bn254 public key: %s", tmPK.Type())
+		}
+		var public curve.G1Affine
+		_, err = public.SetBytes(tmPK.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("Could not deserialize bn254 public key %s", err)
+		}
+		leafHashes[i] = n.LeafHash(n.ValidatorLeaf(public, val.VotingPower))
+	}
+	return leafHashes, nil
+}
+
+// Merkle root of a validator set, as committed in the ValidatorsHash
+func ValidatorsHash(validators []*tmtypes.SimpleValidator) ([]byte, error) {
+	return MiMC.ValidatorsHash(validators)
+}
+
+func (n *Hasher) ValidatorsHash(validators []*tmtypes.SimpleValidator) ([]byte, error) {
+	leafHashes, err := n.validatorsLeaves(validators)
+	if err != nil {
+		return nil, err
+	}
+	return n.RootHash(leafHashes), nil
+}
+
+// Whether the validators set in the bitmap hold enough voting power,
+// i.e. total * numerator <= signed * denominator. Voting powers exceeding
+// the CometBFT bounds never reach a quorum.
+func Quorum(validators []*tmtypes.SimpleValidator, bitmap *big.Int, powerNumerator int64, powerDenominator int64) bool {
+	total := big.NewInt(0)
+	signed := big.NewInt(0)
+	for i, val := range validators {
+		if val.VotingPower < 0 || val.VotingPower > types.MaxTotalVotingPower {
+			return false
+		}
+		total.Add(total, big.NewInt(val.VotingPower))
+		if bitmap.Bit(i) == 1 {
+			signed.Add(signed, big.NewInt(val.VotingPower))
+		}
+	}
+	if total.Cmp(big.NewInt(types.MaxTotalVotingPower)) > 0 {
+		return false
+	}
+	total.Mul(total, big.NewInt(powerNumerator))
+	signed.Mul(signed, big.NewInt(powerDenominator))
+	return total.Cmp(signed) <= 0
+}
+
+// A 32 bytes hash (not fitting in the scalar field) is split in its most
+// significant byte and the 31 remaining bytes, each committed as a leaf.
+func (n *Hasher) unconsHash(x []byte) []byte {
+	var padded [32]byte
+	new(big.Int).SetBytes(x).FillBytes(padded[:])
+	return n.RootHash([][]byte{
+		n.LeafHash(padded[:1]),
+		n.LeafHash(padded[1:]),
+	})
+}
+
+// MiMC merkle root of the header fields
+func BlockHash(header *types.Header) []byte {
+	return MiMC.BlockHash(header)
+}
+
+func (n *Hasher) BlockHash(header *types.Header) []byte {
+	leaves := [][]byte{
+		u64(header.Version.Block),
+		u64(header.Version.App),
+		[]byte(header.ChainID),
+		i64(header.Height),
+		i64(header.Time.Unix()),
+		i64(int64(header.Time.Nanosecond())),
+		header.LastBlockID.Hash,
+		u64(uint64(header.LastBlockID.PartSetHeader.Total)),
+	}
+	leafHashes := make([][]byte, 0, 18)
+	for _, leaf := range leaves {
+		leafHashes = append(leafHashes, n.LeafHash(leaf))
+	}
+	for _, leaf := range [][]byte{
+		n.unconsHash(header.LastBlockID.PartSetHeader.Hash),
+		n.unconsHash(header.LastCommitHash),
+		n.unconsHash(header.DataHash),
+		header.ValidatorsHash,
+		header.NextValidatorsHash,
+		n.unconsHash(header.ConsensusHash),
+		n.unconsHash(header.AppHash),
+		n.unconsHash(header.LastResultsHash),
+		n.unconsHash(header.EvidenceHash),
+		n.unconsHash(header.ProposerAddress),
+	} {
+		leafHashes = append(leafHashes, n.LeafHash(leaf))
+	}
+	return n.RootHash(leafHashes)
+}
+
+// MiMC of the precommit vote fields
+func VoteSignBytes(header *types.Header, round int32, partSetHeader types.PartSetHeader) []byte {
+	return MiMC.VoteSignBytes(header, round, partSetHeader)
+}
+
+func (n *Hasher) VoteSignBytes(header *types.Header, round int32, partSetHeader types.PartSetHeader) []byte {
+	var padded [32]byte
+	new(big.Int).SetBytes(partSetHeader.Hash).FillBytes(padded[:])
+	return n.sum(
+		i64(int64(tmtypes.PrecommitType)),
+		i64(header.Height),
+		i64(int64(round)),
+		n.BlockHash(header),
+		u64(uint64(partSetHeader.Total)),
+		padded[:1],
+		padded[1:],
+		[]byte(header.ChainID),
+	)
+}
+
+// Little endian bits of a value, truncated or zero extended to size
+func toBits(x *big.Int, size int) []uint {
+	bits := make([]uint, size)
+	for i := 0; i < size; i++ {
+		bits[i] = x.Bit(i)
+	}
+	return bits
+}
+
+func fromBits(bits []uint) *big.Int {
+	x := big.NewInt(0)
+	for i := len(bits) - 1; i >= 0; i-- {
+		x.Lsh(x, 1)
+		x.Or(x, big.NewInt(int64(bits[i])))
+	}
+	return x
+}
+
+// BN254G2_XMD:MiMC-256_SVDW expand_message_xmd, over the little endian bits
+// of a 254 bits message and domain separation tag, 192 bytes long.
+func ExpandMsgXmd(message []byte, dst []byte) []uint {
+	block := []uint{}
+	write := func(bits ...uint) {
+		block = append(block, bits...)
+	}
+	writeU8 := func(x uint8) {
+		write(toBits(big.NewInt(int64(x)), 8)...)
+	}
+	sum := func() []uint {
+		if len(block)%MiMCBlockSize != 0 {
+			write(make([]uint, MiMCBlockSize-len(block)%MiMCBlockSize)...)
+		}
+		h := mimc.NewMiMC()
+		for i := 0; i < len(block); i += MiMCBlockSize {
+			_, err := h.Write(element(fromBits(block[i : i+MiMCBlockSize]).Bytes()))
+			if err != nil {
+				panic(err)
+			}
+		}
+		block = []uint{}
+		return toBits(new(big.Int).SetBytes(h.Sum(nil)), 256)
+	}
+	writeDSTPrime := func() {
+		write(toBits(new(big.Int).SetBytes(dst), 256)...)
+		writeU8(32)
+	}
+
+	// b₀ = H(Z_pad ∥ msg ∥ l_i_b_str ∥ I2OSP(0, 1) ∥ DST_prime)
+	write(make([]uint, 256)...)
+	write(toBits(new(big.Int).SetBytes(message), 256)...)
+	writeU8(192 >> 8)
+	writeU8(192)
+	writeU8(0)
+	writeDSTPrime()
+	b0 := sum()
+
+	// b₁ = H(b₀ ∥ I2OSP(1, 1) ∥ DST_prime)
+	write(b0...)
+	writeU8(1)
+	writeDSTPrime()
+	bi := sum()
+
+	res := make([]uint, 0, 192*8)
+	res = append(res, bi...)
+	for i := 2; i <= 6; i++ {
+		strxor := make([]uint, 256)
+		for j := 0; j < 256; j++ {
+			strxor[j] = b0[j] ^ bi[j]
+		}
+		// b_i = H(strxor(b₀, b_(i - 1)) ∥ I2OSP(i, 1) ∥ DST_prime)
+		write(strxor...)
+		writeU8(uint8(i))
+		writeDSTPrime()
+		bi = sum()
+		res = append(res, bi...)
+	}
+	return res
+}
+
+// Union whitepaper: (1) H_{mimc^4}
+func HashToField(message []byte, dst []byte) [4]fp.Element {
+	pseudoRandomBits := ExpandMsgXmd(message, dst)
+	var elements [4]fp.Element
+	for i := 0; i < 4; i++ {
+		elements[i].SetBigInt(fromBits(pseudoRandomBits[i*48*8 : (i+1)*48*8]))
+	}
+	return elements
+}
+
+// Union whitepaper: (1), (2) M ◦ H_{mimc^4}
+func HashToG2(message []byte, dst []byte) curve.G2Affine {
+	u := HashToField(message, dst)
+	Q0 := curve.MapToCurve2(&curve.E2{A0: u[0], A1: u[1]})
+	Q1 := curve.MapToCurve2(&curve.E2{A0: u[2], A1: u[3]})
+	var q0, q1 curve.G2Jac
+	q0.FromAffine(&Q0)
+	q1.FromAffine(&Q1).AddAssign(&q0)
+	q1.ClearCofactor(&q1)
+	var point curve.G2Affine
+	point.FromJacobian(&q1)
+	return point
+}
@@ -0,0 +1,505 @@
+package native
+
+import (
+	"fmt"
+	g2 "galois/pkg/emulated"
+	"galois/pkg/lightclient"
+	lcmerkle "galois/pkg/merkle"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	tmtypes "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	version "github.com/cometbft/cometbft/api/cometbft/version/v1"
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	ce "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/types"
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+	gadget "github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/unionlabs/union/11-cometbls/inputshash"
+)
+
+func readBytes(r *rand.Rand, size int) []byte {
+	bz := make([]byte, size)
+	r.Read(bz)
+	return bz
+}
+
+func getBlockHeader(r *rand.Rand) (*lightclient.BlockHeader, *lightclient.BlockVote, *types.Header, types.PartSetHeader, int32) {
+	trunc := func(b []byte) lightclient.UnconsHash {
+		return lightclient.UnconsHash{
+			Head: b[0],
+			Tail: b[1:],
+		}
+	}
+
+	partSetHeaderTotal := r.Uint32()
+	partSetHeaderHash := readBytes(r, 32)
+	round := r.Int31()
+	versionBlock := r.Uint64()
+	versionApp := r.Uint64()
+	chainId := fmt.Sprintf("union-devnet-%d", r.Uint64()%65535)
+	height := r.Int63()
+	time := time.Unix(int64(r.Int63n(10000000000)), r.Int63())
+	lastBlockHash := readBytes(r, 1)
+	lastBlockPartSetHeaderTotal := r.Uint32()
+	lastBlockPartSetHeaderHash := readBytes(r, 32)
+	lastCommitHash := readBytes(r, 32)
+	dataHash := readBytes(r, 32)
+	validatorsHash := readBytes(r, 31)
+	nextValidatorsHash := readBytes(r, 31)
+	consensusHash := readBytes(r, 32)
+	appHash := readBytes(r, 32)
+	lastResultsHash := readBytes(r, 32)
+	evidenceHash := readBytes(r, 32)
+	proposerAddress := readBytes(r, 32)
+
+	header := &lightclient.BlockHeader{
+		VersionBlock:                versionBlock,
+		VersionApp:                  versionApp,
+		ChainID:                     []byte(chainId),
+		Height:                      height,
+		TimeSecs:                    time.Unix(),
+		TimeNanos:                   time.Nanosecond(),
+		LastBlockHash:               lastBlockHash,
+		LastBlockPartSetHeaderTotal: lastBlockPartSetHeaderTotal,
+		LastBlockPartSetHeaderHash:  trunc(lastBlockPartSetHeaderHash),
+		LastCommitHash:              trunc(lastCommitHash),
+		DataHash:                    trunc(dataHash),
+		ValidatorsHash:              validatorsHash,
+		NextValidatorsHash:          nextValidatorsHash,
+		ConsensusHash:               trunc(consensusHash),
+		AppHash:                     trunc(appHash),
+		LastResultsHash:             trunc(lastResultsHash),
+		EvidenceHash:                trunc(evidenceHash),
+		ProposerAddress:             trunc(proposerAddress),
+	}
+
+	vote := &lightclient.BlockVote{
+		BlockPartSetHeaderTotal: partSetHeaderTotal,
+		BlockPartSetHeaderHash:  trunc(partSetHeaderHash),
+		Round:                   round,
+	}
+
+	cometblsHeader := &types.Header{
+		Version: version.Consensus{
+			Block: versionBlock,
+			App:   versionApp,
+		},
+		ChainID: chainId,
+		Height:  height,
+		Time:    time,
+		LastBlockID: types.BlockID{
+			Hash: lastBlockHash,
+			PartSetHeader: types.PartSetHeader{
+				Total: lastBlockPartSetHeaderTotal,
+				Hash:  lastBlockPartSetHeaderHash,
+			},
+		},
+		LastCommitHash:     lastCommitHash,
+		DataHash:           dataHash,
+		ValidatorsHash:     validatorsHash,
+		NextValidatorsHash: nextValidatorsHash,
+		ConsensusHash:      consensusHash,
+		AppHash:            appHash,
+		LastResultsHash:    lastResultsHash,
+		EvidenceHash:       evidenceHash,
+		ProposerAddress:    proposerAddress,
+	}
+
+	partSetHeader := types.PartSetHeader{
+		Total: partSetHeaderTotal,
+		Hash:  partSetHeaderHash,
+	}
+
+	return header, vote, cometblsHeader, partSetHeader, round
+}
+
+func cometblsVote(header *types.Header, round int32, partSetHeader types.PartSetHeader) *tmtypes.Vote {
+	return &tmtypes.Vote{
+		Type:   tmtypes.PrecommitType,
+		Height: header.Height,
+		Round:  round,
+		BlockID: tmtypes.BlockID{
+			Hash: header.Hash(),
+			PartSetHeader: tmtypes.PartSetHeader{
+				Total: partSetHeader.Total,
+				Hash:  partSetHeader.Hash,
+			},
+		},
+	}
+}
+
+type Gadgets struct {
+	Vote                  lightclient.BlockVote
+	Header                lightclient.BlockHeader
+	TrustedValRoot        frontend.Variable
+	ExpectedInputsHash    frontend.Variable
+	ExpectedBlockHash     frontend.Variable
+	ExpectedVoteSignBytes frontend.Variable
+}
+
+func (c *Gadgets) Define(api frontend.API) error {
+	bhapi, err := lightclient.NewBlockHeaderAPI(api, c.Header, c.Vote)
+	if err != nil {
+		return err
+	}
+	err = bhapi.VerifyInputs(c.ExpectedInputsHash, c.TrustedValRoot)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(bhapi.BlockHash(), c.ExpectedBlockHash)
+	voteSignBytes, err := bhapi.VoteSignBytes()
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(voteSignBytes, c.ExpectedVoteSignBytes)
+	return nil
+}
+
+func FuzzGadgets(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+		header, vote, cometblsHeader, partSetHeader, round := getBlockHeader(r)
+		trustedValRoot := readBytes(r, 31)
+
+		blockHash := BlockHash(cometblsHeader)
+		assert.Equal(t, []byte(cometblsHeader.Hash()), blockHash)
+
+		voteSignBytes := VoteSignBytes(cometblsHeader, round, partSetHeader)
+		assert.Equal(t, types.VoteSignBytes(cometblsHeader.ChainID, cometblsVote(cometblsHeader, round, partSetHeader)), voteSignBytes)
+
+		inputsHash := inputshash.Hash(inputshash.LightHeader{
+			ChainId:            cometblsHeader.ChainID,
+			Height:             cometblsHeader.Height,
+			Time:               cometblsHeader.Time,
+			ValidatorsHash:     cometblsHeader.ValidatorsHash,
+			NextValidatorsHash: cometblsHeader.NextValidatorsHash,
+			AppHash:            cometblsHeader.AppHash,
+		}, trustedValRoot)
+
+		err := test.IsSolved(
+			&Gadgets{},
+			&Gadgets{
+				Vote:                  *vote,
+				Header:                *header,
+				TrustedValRoot:        trustedValRoot,
+				ExpectedInputsHash:    inputsHash,
+				ExpectedBlockHash:     blockHash,
+				ExpectedVoteSignBytes: voteSignBytes,
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+	})
+}
+
+type Poseidon2Gadgets struct {
+	Vote                  lightclient.BlockVote
+	Header                lightclient.BlockHeader
+	ExpectedBlockHash     frontend.Variable
+	ExpectedVoteSignBytes frontend.Variable
+}
+
+func (c *Poseidon2Gadgets) Define(api frontend.API) error {
+	bhapi, err := lightclient.NewBlockHeaderAPI(api, c.Header, c.Vote)
+	if err != nil {
+		return err
+	}
+	bhapi.WithHash(lcmerkle.Poseidon2)
+	api.AssertIsEqual(bhapi.BlockHash(), c.ExpectedBlockHash)
+	voteSignBytes, err := bhapi.VoteSignBytes()
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(voteSignBytes, c.ExpectedVoteSignBytes)
+	return nil
+}
+
+func FuzzPoseidon2Gadgets(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+		header, vote, cometblsHeader, partSetHeader, round := getBlockHeader(r)
+		hasher := NewHasher(lcmerkle.Poseidon2)
+
+		blockHash := hasher.BlockHash(cometblsHeader)
+		assert.NotEqual(t, BlockHash(cometblsHeader), blockHash)
+
+		voteSignBytes := hasher.VoteSignBytes(cometblsHeader, round, partSetHeader)
+		err := test.IsSolved(
+			&Poseidon2Gadgets{},
+			&Poseidon2Gadgets{
+				Vote:                  *vote,
+				Header:                *header,
+				ExpectedBlockHash:     blockHash,
+				ExpectedVoteSignBytes: voteSignBytes,
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+	})
+}
+
+type HashToCurve struct {
+	DST          frontend.Variable
+	Vote         lightclient.BlockVote
+	Header       lightclient.BlockHeader
+	ExpectedHash gadget.G2Affine
+}
+
+func (c *HashToCurve) Define(api frontend.API) error {
+	bhapi, err := lightclient.NewBlockHeaderAPI(api, c.Header, c.Vote)
+	if err != nil {
+		return err
+	}
+	hashed, err := bhapi.HashToCurve(c.DST)
+	if err != nil {
+		return err
+	}
+	emulatedAPI, err := g2.NewEmulatedAPI(api)
+	if err != nil {
+		return err
+	}
+	emulatedAPI.AssertIsEqual(&c.ExpectedHash, hashed)
+	return nil
+}
+
+func FuzzHashToCurve(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+		header, vote, cometblsHeader, partSetHeader, round := getBlockHeader(r)
+		dst := []byte(cometbn254.CometblsSigDST)
+		hashed := HashToG2(VoteSignBytes(cometblsHeader, round, partSetHeader), dst)
+		signBytes := types.VoteSignBytes(cometblsHeader.ChainID, cometblsVote(cometblsHeader, round, partSetHeader))
+		expected := cometbn254.HashToG2(signBytes)
+		assert.True(t, expected.Equal(&hashed))
+		err := test.IsSolved(
+			&HashToCurve{},
+			&HashToCurve{
+				DST:          dst,
+				Vote:         *vote,
+				Header:       *header,
+				ExpectedHash: gadget.NewG2Affine(hashed),
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+	})
+}
+
+func FuzzHashToG2(f *testing.F) {
+	f.Fuzz(func(t *testing.T, msgIn []byte, dstIn []byte) {
+		t.Parallel()
+		var msg fr.Element
+		msg.SetBytes(msgIn)
+		var dst fr.Element
+		dst.SetBytes(dstIn)
+		var msgBytes [32]byte
+		fr.LittleEndian.PutElement(&msgBytes, msg)
+		var dstBytes [32]byte
+		fr.LittleEndian.PutElement(&dstBytes, dst)
+		expected, err := cometbn254.HashToG2MiMC(msgBytes[:], dstBytes[:])
+		assert.NoError(t, err)
+		msgBE := msg.Bytes()
+		dstBE := dst.Bytes()
+		hashed := HashToG2(msgBE[:], dstBE[:])
+		assert.True(t, expected.Equal(&hashed))
+	})
+}
+
+type MerkleRoot struct {
+	LeafHashes   [lightclient.MaxVal]frontend.Variable
+	Size         frontend.Variable
+	ExpectedRoot frontend.Variable
+}
+
+func (c *MerkleRoot) Define(api frontend.API) error {
+	merkle := lcmerkle.NewMerkleTreeAPI(api)
+	api.AssertIsEqual(merkle.RootHash(c.LeafHashes[:], c.Size), c.ExpectedRoot)
+	return nil
+}
+
+func FuzzRootHash(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+		size := r.Intn(lightclient.MaxVal + 1)
+		leaves := make([][]byte, size)
+		leafHashes := make([][]byte, size)
+		var assignment [lightclient.MaxVal]frontend.Variable
+		for i := 0; i < lightclient.MaxVal; i++ {
+			assignment[i] = 0
+		}
+		for i := 0; i < size; i++ {
+			leaves[i] = readBytes(r, 31)
+			leafHashes[i] = LeafHash(leaves[i])
+			assignment[i] = leafHashes[i]
+		}
+		root := RootHash(leafHashes)
+		assert.Equal(t, merkle.MimcHashFromByteSlices(leaves), root)
+		err := test.IsSolved(
+			&MerkleRoot{},
+			&MerkleRoot{
+				LeafHashes:   assignment,
+				Size:         size,
+				ExpectedRoot: root,
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+	})
+}
+
+type VerifyQuorum struct {
+	Input            lightclient.TendermintLightClientInput
+	Message          gadget.G2Affine
+	ExpectedValRoot  frontend.Variable
+	PowerNumerator   frontend.Variable
+	PowerDenominator frontend.Variable
+	hash             lcmerkle.Hash
+}
+
+func (c *VerifyQuorum) Define(api frontend.API) error {
+	lc := lightclient.NewTendermintLightClientAPI(api, &c.Input).WithHash(c.hash)
+	return lc.Verify(&c.Message, c.ExpectedValRoot, c.PowerNumerator, c.PowerDenominator)
+}
+
+func toValidator(pubKey []byte, power int64) (*tmtypes.SimpleValidator, error) {
+	protoPK, err := ce.PubKeyToProto(cometbn254.PubKey(pubKey))
+	if err != nil {
+		return nil, err
+	}
+	return &tmtypes.SimpleValidator{
+		PubKey:      &protoPK,
+		VotingPower: power,
+	}, nil
+}
+
+func marshalValidators(validators []*tmtypes.SimpleValidator) ([lightclient.MaxVal]lightclient.Validator, [][]byte, error) {
+	lcValidators := [lightclient.MaxVal]lightclient.Validator{}
+	for i := 0; i < lightclient.MaxVal; i++ {
+		lcValidators[i].HashableX = 0
+		lcValidators[i].HashableXMSB = 0
+		lcValidators[i].HashableY = 0
+		lcValidators[i].HashableYMSB = 0
+		lcValidators[i].Power = 0
+	}
+	leaves := make([][]byte, len(validators))
+	for i, val := range validators {
+		tmPK, err := ce.PubKeyFromProto(*val.PubKey)
+		if err != nil {
+			return lcValidators, nil, err
+		}
+		var public curve.G1Affine
+		_, err = public.SetBytes(tmPK.Bytes())
+		if err != nil {
+			return lcValidators, nil, err
+		}
+		leaf, err := cometbn254.NewMerkleLeaf(public, val.VotingPower)
+		if err != nil {
+			return lcValidators, nil, err
+		}
+		lcValidators[i].HashableX = leaf.ShiftedX
+		lcValidators[i].HashableY = leaf.ShiftedY
+		lcValidators[i].HashableXMSB = leaf.MsbX
+		lcValidators[i].HashableYMSB = leaf.MsbY
+		lcValidators[i].Power = leaf.VotingPower
+		leaves[i], err = leaf.Hash()
+		if err != nil {
+			return lcValidators, nil, err
+		}
+	}
+	return lcValidators, leaves, nil
+}
+
+// The circuit must accept a random bitmap if and only if the native quorum
+// computation does.
+func FuzzQuorum(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		verifyQuorum(t, seed, lcmerkle.MiMC)
+	})
+}
+
+func FuzzPoseidon2Quorum(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		verifyQuorum(t, seed, lcmerkle.Poseidon2)
+	})
+}
+
+func verifyQuorum(t *testing.T, seed int64, hash lcmerkle.Hash) {
+	r := rand.New(rand.NewSource(seed))
+	nbOfValidators := 1 + r.Intn(16)
+	privKeys := make([]cometbn254.PrivKey, nbOfValidators)
+	validators := make([]*tmtypes.SimpleValidator, nbOfValidators)
+	for i := 0; i < nbOfValidators; i++ {
+		privKeys[i] = cometbn254.GenPrivKeyFromSeed(readBytes(r, 64))
+		val, err := toValidator(privKeys[i].PubKey().Bytes(), 1+r.Int63n(1000))
+		assert.NoError(t, err)
+		validators[i] = val
+	}
+	lcValidators, leaves, err := marshalValidators(validators)
+	assert.NoError(t, err)
+	valRoot, err := NewHasher(hash).ValidatorsHash(validators)
+	assert.NoError(t, err)
+	if hash == lcmerkle.MiMC {
+		assert.Equal(t, merkle.MimcHashFromByteSlices(leaves), valRoot)
+	} else {
+		assert.NotEqual(t, merkle.MimcHashFromByteSlices(leaves), valRoot)
+	}
+
+	message := readBytes(r, 31)
+	var bitmap big.Int
+	var aggregatedSignature curve.G2Affine
+	nbOfSignatures := 0
+	for nbOfSignatures == 0 {
+		for i := 0; i < nbOfValidators; i++ {
+			if r.Intn(2) == 0 || bitmap.Bit(i) == 1 {
+				continue
+			}
+			bitmap.SetBit(&bitmap, i, 1)
+			signature, err := privKeys[i].Sign(message)
+			assert.NoError(t, err)
+			var decompressedSignature curve.G2Affine
+			_, err = decompressedSignature.SetBytes(signature)
+			assert.NoError(t, err)
+			aggregatedSignature.Add(&aggregatedSignature, &decompressedSignature)
+			nbOfSignatures++
+		}
+	}
+
+	err = test.IsSolved(
+		&VerifyQuorum{hash: hash},
+		&VerifyQuorum{
+			Input: lightclient.TendermintLightClientInput{
+				Sig:           gadget.NewG2Affine(aggregatedSignature),
+				Validators:    lcValidators,
+				NbOfVal:       nbOfValidators,
+				NbOfSignature: nbOfSignatures,
+				Bitmap:        &bitmap,
+			},
+			Message:          gadget.NewG2Affine(cometbn254.HashToG2(message)),
+			ExpectedValRoot:  valRoot,
+			PowerNumerator:   2,
+			PowerDenominator: 3,
+			hash:             hash,
+		},
+		ecc.BN254.ScalarField(),
+	)
+	if Quorum(validators, &bitmap, 2, 3) {
+		assert.NoError(t, err)
+	} else {
+		assert.Error(t, err)
+	}
+}
@@ -2,9 +2,11 @@ package merkle
 
 import (
 	"math"
+	"math/big"
 
 	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/cmp"
 )
 
 const (
@@ -13,11 +15,24 @@ const (
 )
 
 type MerkleTreeAPI struct {
-	api frontend.API
+	api  frontend.API
+	hash Hash
 }
 
 func NewMerkleTreeAPI(api frontend.API) *MerkleTreeAPI {
-	return &MerkleTreeAPI{api: api}
+	return NewMerkleTreeAPIWithHash(api, MiMC)
+}
+
+func NewMerkleTreeAPIWithHash(api frontend.API, hash Hash) *MerkleTreeAPI {
+	return &MerkleTreeAPI{api: api, hash: hash}
+}
+
+func (m *MerkleTreeAPI) newHasher() hash.FieldHasher {
+	h, err := m.hash.New(m.api)
+	if err != nil {
+		panic(err)
+	}
+	return h
 }
 
 // Union whitepaper: (11) H_leaf
@@ -28,22 +43,16 @@ func (m *MerkleTreeAPI) LeafHash(leaf []frontend.Variable) frontend.Variable {
 	for i := 0; i < len(leaf); i++ {
 		preimage[i+1] = leaf[i]
 	}
-	mimc, err := mimc.NewMiMC(m.api)
-	if err != nil {
-		panic(err)
-	}
-	mimc.Write(preimage[:]...)
-	return mimc.Sum()
+	h := m.newHasher()
+	h.Write(preimage[:]...)
+	return h.Sum()
 }
 
 // Union whitepaper: (11) H_inner
 func (m *MerkleTreeAPI) InnerHash(left frontend.Variable, right frontend.Variable) frontend.Variable {
-	mimc, err := mimc.NewMiMC(m.api)
-	if err != nil {
-		panic(err)
-	}
-	mimc.Write(InnerPrefix, left, right)
-	return mimc.Sum()
+	h := m.newHasher()
+	h.Write(InnerPrefix, left, right)
+	return h.Sum()
 }
 
 // Union whitepaper: (11) merkle_root
@@ -67,10 +76,40 @@ func (m *MerkleTreeAPI) RootHash(leafHashes []frontend.Variable, size frontend.V
 			w += 1
 		}
 	}
-	mimc, err := mimc.NewMiMC(m.api)
-	if err != nil {
-		panic(err)
-	}
-	emptyHash := mimc.Sum()
+	emptyHash := m.newHasher().Sum()
 	return m.api.Select(m.api.IsZero(initialSize), emptyHash, leafHashes[0])
 }
+
+// Verify that leaf is the index-th leaf of the tree of the given size
+// committed by root, siblings being ordered from the leaf to the root.
+//
+// The tree shape is fully determined by the size: at height h, the node
+// containing the leaf has a left sibling if the h-th bit of the index is set,
+// a right sibling if the subtree on its right is non-empty and is otherwise
+// promoted as is. The siblings at the heights without sibling are ignored.
+// The size is required, otherwise a leaf could be proven at another index by
+// skipping levels.
+func (m *MerkleTreeAPI) VerifyInclusion(leaf frontend.Variable, index frontend.Variable, siblings []frontend.Variable, size frontend.Variable, root frontend.Variable) {
+	depth := len(siblings)
+	m.api.AssertIsLessOrEqual(size, 1<<depth)
+	// index < size, which implies size > 0
+	m.api.AssertIsLessOrEqual(m.api.Add(index, 1), size)
+	bits := m.api.ToBinary(index, depth)
+	comparator := cmp.NewBoundedComparator(m.api, big.NewInt(1<<(depth+1)), false)
+	node := m.LeafHash([]frontend.Variable{leaf})
+	for h := 0; h < depth; h++ {
+		// Start of the subtree on the right of the current node
+		rightStart := frontend.Variable(1 << h)
+		for j := h; j < depth; j++ {
+			rightStart = m.api.Add(rightStart, m.api.Mul(bits[j], 1<<j))
+		}
+		hasRight := comparator.IsLess(rightStart, size)
+		hasSibling := m.api.Or(bits[h], hasRight)
+		parent := m.InnerHash(
+			m.api.Select(bits[h], siblings[h], node),
+			m.api.Select(bits[h], node, siblings[h]),
+		)
+		node = m.api.Select(hasSibling, parent, node)
+	}
+	m.api.AssertIsEqual(node, root)
+}
@@ -6,6 +6,9 @@ import (
 	"math/rand"
 	"testing"
 
+	tmtypes "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	ce "github.com/cometbft/cometbft/crypto/encoding"
 	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
@@ -14,7 +17,10 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-const MaxLeaves = 128
+const (
+	MaxLeaves = 128
+	MaxDepth  = 7
+)
 
 type MerkleRoot struct {
 	Root       frontend.Variable
@@ -112,3 +118,225 @@ func FuzzMerkleRoot(f *testing.F) {
 		assert.NoError(t, err)
 	})
 }
+
+type Inclusion struct {
+	Root     frontend.Variable
+	Leaf     frontend.Variable
+	Index    frontend.Variable
+	Siblings [MaxDepth]frontend.Variable
+	Size     frontend.Variable
+}
+
+func (c *Inclusion) Define(api frontend.API) error {
+	merkle := NewMerkleTreeAPI(api)
+	merkle.VerifyInclusion(c.Leaf, c.Index, c.Siblings[:], c.Size, c.Root)
+	return nil
+}
+
+func randomLeaves(t *testing.T, rng *rand.Rand, size int) [][]byte {
+	leaves := make([][]byte, size)
+	buff := make([]byte, 32)
+	for j := 0; j < size; j++ {
+		_, err := rng.Read(buff)
+		assert.NoError(t, err)
+		var leafValue fr.Element
+		leafValue.SetBigInt(new(big.Int).SetBytes(buff))
+		leaves[j] = leafValue.Marshal()
+	}
+	return leaves
+}
+
+func verifyInclusion(t *testing.T, leaves [][]byte, index int, claimedIndex int, claimedSize int) error {
+	siblings, err := InclusionProof(leaves, index, MaxDepth)
+	assert.NoError(t, err)
+	circuitSiblings := [MaxDepth]frontend.Variable{}
+	for h := 0; h < MaxDepth; h++ {
+		circuitSiblings[h] = siblings[h]
+	}
+	return test.IsSolved(
+		&Inclusion{},
+		&Inclusion{
+			Root:     merkle.MimcHashFromByteSlices(leaves),
+			Leaf:     leaves[index],
+			Index:    claimedIndex,
+			Siblings: circuitSiblings,
+			Size:     claimedSize,
+		},
+		ecc.BN254.ScalarField(),
+	)
+}
+
+func TestInclusion(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(0xCAFEBABE))
+	for _, size := range []int{1, 2, 3, 5, 7, 8, 33, 100, MaxLeaves} {
+		leaves := randomLeaves(t, rng, size)
+		for _, index := range []int{0, size / 2, size - 1} {
+			err := verifyInclusion(t, leaves, index, index, size)
+			assert.NoError(t, err, "size: %d, index: %d", size, index)
+		}
+	}
+}
+
+func FuzzInclusion(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		rng := rand.New(rand.NewSource(seed))
+		size := 1 + rng.Intn(MaxLeaves)
+		index := rng.Intn(size)
+		leaves := randomLeaves(t, rng, size)
+		err := verifyInclusion(t, leaves, index, index, size)
+		assert.NoError(t, err)
+	})
+}
+
+func TestInclusionWrongIndex(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(0xCAFEBABE))
+	leaves := randomLeaves(t, rng, 33)
+	err := verifyInclusion(t, leaves, 10, 11, len(leaves))
+	assert.Error(t, err)
+}
+
+// The 5th leaf of a 5 leaves tree is the right child of the root, the same
+// hashes would verify at index 1 if levels could be skipped.
+func TestInclusionCantSkipLevels(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(0xCAFEBABE))
+	leaves := randomLeaves(t, rng, 5)
+	err := verifyInclusion(t, leaves, 4, 1, len(leaves))
+	assert.Error(t, err)
+	err = verifyInclusion(t, leaves, 4, 4, 8)
+	assert.Error(t, err)
+}
+
+func TestInclusionIndexOutOfBounds(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(0xCAFEBABE))
+	leaves := randomLeaves(t, rng, 4)
+	err := verifyInclusion(t, leaves, 3, 3, 3)
+	assert.Error(t, err)
+}
+
+func TestValidatorInclusionProof(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(0xCAFEBABE))
+	validators := make([]*tmtypes.SimpleValidator, 1+rng.Intn(MaxLeaves))
+	for i := 0; i < len(validators); i++ {
+		protoPK, err := ce.PubKeyToProto(cometbn254.GenPrivKey().PubKey())
+		assert.NoError(t, err)
+		validators[i] = &tmtypes.SimpleValidator{
+			PubKey:      &protoPK,
+			VotingPower: 1 + rng.Int63n(1<<32),
+		}
+	}
+	index := rng.Intn(len(validators))
+	leaf, siblings, root, err := ValidatorInclusionProof(validators, index, MaxDepth)
+	assert.NoError(t, err)
+	leaves, err := ValidatorsLeaves(validators)
+	assert.NoError(t, err)
+	assert.Equal(t, merkle.MimcHashFromByteSlices(leaves), root)
+	circuitSiblings := [MaxDepth]frontend.Variable{}
+	for h := 0; h < MaxDepth; h++ {
+		circuitSiblings[h] = siblings[h]
+	}
+	err = test.IsSolved(
+		&Inclusion{},
+		&Inclusion{
+			Root:     root,
+			Leaf:     leaf,
+			Index:    index,
+			Siblings: circuitSiblings,
+			Size:     len(validators),
+		},
+		ecc.BN254.ScalarField(),
+	)
+	assert.NoError(t, err)
+}
+
+func TestHashFromByteSlicesMiMC(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(0xCAFEBABE))
+	for size := 0; size <= MaxLeaves; size++ {
+		leaves := randomLeaves(t, rng, size)
+		assert.Equal(t, merkle.MimcHashFromByteSlices(leaves), HashFromByteSlices(MiMC, leaves), "size: %d", size)
+	}
+}
+
+type Poseidon2MerkleRoot struct {
+	Root       frontend.Variable
+	LeavesData [MaxLeaves]frontend.Variable
+	NbOfLeaves frontend.Variable
+}
+
+func (c *Poseidon2MerkleRoot) Define(api frontend.API) error {
+	merkle := NewMerkleTreeAPIWithHash(api, Poseidon2)
+	leavesHash := make([]frontend.Variable, MaxLeaves)
+	for i := 0; i < MaxLeaves; i++ {
+		leavesHash[i] = merkle.LeafHash([]frontend.Variable{c.LeavesData[i]})
+	}
+	api.AssertIsEqual(c.Root, merkle.RootHash(leavesHash, c.NbOfLeaves))
+	return nil
+}
+
+type Poseidon2Inclusion struct {
+	Inclusion
+}
+
+func (c *Poseidon2Inclusion) Define(api frontend.API) error {
+	merkle := NewMerkleTreeAPIWithHash(api, Poseidon2)
+	merkle.VerifyInclusion(c.Leaf, c.Index, c.Siblings[:], c.Size, c.Root)
+	return nil
+}
+
+func FuzzPoseidon2MerkleRoot(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		rng := rand.New(rand.NewSource(seed))
+		k := rng.Intn(MaxLeaves + 1)
+		leaves := randomLeaves(t, rng, k)
+		circuitLeaves := [MaxLeaves]frontend.Variable{}
+		for j := 0; j < MaxLeaves; j++ {
+			circuitLeaves[j] = 0
+		}
+		for j := 0; j < k; j++ {
+			circuitLeaves[j] = leaves[j]
+		}
+		root := HashFromByteSlices(Poseidon2, leaves)
+		assert.NotEqual(t, HashFromByteSlices(MiMC, leaves), root)
+		err := test.IsSolved(
+			&Poseidon2MerkleRoot{},
+			&Poseidon2MerkleRoot{
+				Root:       root,
+				LeavesData: circuitLeaves,
+				NbOfLeaves: k,
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+		if k == 0 {
+			return
+		}
+		index := rng.Intn(k)
+		siblings, err := InclusionProofWithHash(Poseidon2, leaves, index, MaxDepth)
+		assert.NoError(t, err)
+		circuitSiblings := [MaxDepth]frontend.Variable{}
+		for h := 0; h < MaxDepth; h++ {
+			circuitSiblings[h] = siblings[h]
+		}
+		err = test.IsSolved(
+			&Poseidon2Inclusion{},
+			&Poseidon2Inclusion{
+				Inclusion{
+					Root:     root,
+					Leaf:     leaves[index],
+					Index:    index,
+					Siblings: circuitSiblings,
+					Size:     k,
+				},
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+	})
+}
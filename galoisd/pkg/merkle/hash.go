@@ -0,0 +1,53 @@
+package merkle
+
+import (
+	gohash "hash"
+
+	"galois/pkg/poseidon2"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	gmimc "github.com/consensys/gnark/std/hash/mimc"
+)
+
+// A SNARK friendly hash function over the scalar field, used for the merkle
+// trees, the validator leaves and the vote sign bytes.
+type Hash interface {
+	// In-circuit hasher, the inputs are field elements
+	New(api frontend.API) (hash.FieldHasher, error)
+	// Native hasher computing the same digest, the inputs are big endian
+	// encoded field elements
+	NewNative() gohash.Hash
+}
+
+type mimcHash struct{}
+
+func (mimcHash) New(api frontend.API) (hash.FieldHasher, error) {
+	h, err := gmimc.NewMiMC(api)
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (mimcHash) NewNative() gohash.Hash {
+	return mimc.NewMiMC()
+}
+
+type poseidon2Hash struct{}
+
+func (poseidon2Hash) New(api frontend.API) (hash.FieldHasher, error) {
+	return poseidon2.NewPoseidon2(api)
+}
+
+func (poseidon2Hash) NewNative() gohash.Hash {
+	return poseidon2.NewHasher()
+}
+
+var (
+	// The hash CometBLS currently commits with
+	MiMC Hash = mimcHash{}
+	// Cheaper in-circuit alternative, see the poseidon2 package
+	Poseidon2 Hash = poseidon2Hash{}
+)
@@ -0,0 +1,111 @@
+package merkle
+
+import (
+	"fmt"
+
+	tmtypes "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	cometbn254 "github.com/cometbft/cometbft/crypto/bn254"
+	ce "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+func nativeHash(h Hash, xs ...[]byte) []byte {
+	s := h.NewNative()
+	for _, x := range xs {
+		_, err := s.Write(x)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return s.Sum(nil)
+}
+
+// Native counterpart of LeafHash, RootHash and InnerHash, computing the root
+// of the tree whose leaves are the given items. With MiMC, this is exactly
+// merkle.MimcHashFromByteSlices.
+func HashFromByteSlices(h Hash, items [][]byte) []byte {
+	switch len(items) {
+	case 0:
+		return nativeHash(h)
+	case 1:
+		return nativeHash(h, []byte{LeafPrefix}, items[0])
+	default:
+		// Largest power of two strictly smaller than the size
+		k := 1
+		for k*2 < len(items) {
+			k *= 2
+		}
+		return nativeHash(h, []byte{InnerPrefix}, HashFromByteSlices(h, items[:k]), HashFromByteSlices(h, items[k:]))
+	}
+}
+
+// Native counterpart of VerifyInclusion, compute the siblings of the
+// index-th leaf, from the leaf to the root. The heights without sibling are
+// filled with zeroes up to depth.
+func InclusionProof(leaves [][]byte, index int, depth int) ([][]byte, error) {
+	return InclusionProofWithHash(MiMC, leaves, index, depth)
+}
+
+func InclusionProofWithHash(hash Hash, leaves [][]byte, index int, depth int) ([][]byte, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, fmt.Errorf("index out of bounds: %d, size: %d", index, len(leaves))
+	}
+	if len(leaves) > 1<<depth {
+		return nil, fmt.Errorf("too many leaves for the depth: %d > %d", len(leaves), 1<<depth)
+	}
+	siblings := make([][]byte, depth)
+	for h := 0; h < depth; h++ {
+		width := 1 << h
+		// Start of the subtree containing the leaf at height h
+		start := index &^ (width - 1)
+		if index&width != 0 {
+			siblings[h] = HashFromByteSlices(hash, leaves[start-width:start])
+		} else if start+width < len(leaves) {
+			siblings[h] = HashFromByteSlices(hash, leaves[start+width:min(start+2*width, len(leaves))])
+		} else {
+			siblings[h] = []byte{0}
+		}
+	}
+	return siblings, nil
+}
+
+// Compute the merkle leaves of a CometBLS validator set, as committed in the
+// ValidatorsHash.
+func ValidatorsLeaves(validators []*tmtypes.SimpleValidator) ([][]byte, error) {
+	leaves := make([][]byte, len(validators))
+	for i, val := range validators {
+		tmPK, err := ce.PubKeyFromProto(*val.PubKey)
+		if err != nil {
+			return nil, fmt.Errorf("Could not deserialize proto to tendermint public key %s", err)
+		}
+		var public curve.G1Affine
+		_, err = public.SetBytes(tmPK.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("Could not deserialize bn254 public key %s", err)
+		}
+		leaf, err := cometbn254.NewMerkleLeaf(public, val.VotingPower)
+		if err != nil {
+			return nil, fmt.Errorf("Could not create merkle leaf %s", err)
+		}
+		leaves[i], err = leaf.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("Could not create merkle hash %s", err)
+		}
+	}
+	return leaves, nil
+}
+
+// Inclusion proof of the index-th validator of a CometBLS validator set,
+// returns the leaf, the siblings and the root (ValidatorsHash).
+func ValidatorInclusionProof(validators []*tmtypes.SimpleValidator, index int, depth int) ([]byte, [][]byte, []byte, error) {
+	leaves, err := ValidatorsLeaves(validators)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	siblings, err := InclusionProof(leaves, index, depth)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return leaves[index], siblings, merkle.MimcHashFromByteSlices(leaves), nil
+}
@@ -0,0 +1,90 @@
+package poseidon2
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+)
+
+type Poseidon2API struct {
+	api frontend.API
+}
+
+func NewPoseidon2API(api frontend.API) *Poseidon2API {
+	return &Poseidon2API{api: api}
+}
+
+func (p *Poseidon2API) sBox(x frontend.Variable) frontend.Variable {
+	x2 := p.api.Mul(x, x)
+	x4 := p.api.Mul(x2, x2)
+	return p.api.Mul(x4, x)
+}
+
+func (p *Poseidon2API) externalMatrix(state *[Width]frontend.Variable) {
+	sum := p.api.Add(state[0], state[1])
+	state[0] = p.api.Add(state[0], sum)
+	state[1] = p.api.Add(state[1], sum)
+}
+
+func (p *Poseidon2API) internalMatrix(state *[Width]frontend.Variable) {
+	sum := p.api.Add(state[0], state[1])
+	state[0] = p.api.Add(state[0], sum)
+	state[1] = p.api.Add(p.api.Mul(state[1], 2), sum)
+}
+
+// In-circuit counterpart of Permutation
+func (p *Poseidon2API) Permutation(state *[Width]frontend.Variable) {
+	fullRound := func(r int) {
+		for j := 0; j < Width; j++ {
+			state[j] = p.sBox(p.api.Add(state[j], RoundConstants[r][j].BigInt(new(big.Int))))
+		}
+		p.externalMatrix(state)
+	}
+	p.externalMatrix(state)
+	r := 0
+	for ; r < FullRounds/2; r++ {
+		fullRound(r)
+	}
+	for ; r < FullRounds/2+PartialRounds; r++ {
+		state[0] = p.sBox(p.api.Add(state[0], RoundConstants[r][0].BigInt(new(big.Int))))
+		p.internalMatrix(state)
+	}
+	for ; r < FullRounds+PartialRounds; r++ {
+		fullRound(r)
+	}
+}
+
+// In-circuit counterpart of Compress
+func (p *Poseidon2API) Compress(left frontend.Variable, right frontend.Variable) frontend.Variable {
+	state := [Width]frontend.Variable{left, right}
+	p.Permutation(&state)
+	return p.api.Add(state[1], right)
+}
+
+type hasher struct {
+	p    *Poseidon2API
+	data []frontend.Variable
+}
+
+// In-circuit counterpart of NewHasher
+func NewPoseidon2(api frontend.API) (hash.FieldHasher, error) {
+	return &hasher{p: NewPoseidon2API(api)}, nil
+}
+
+func (h *hasher) Write(data ...frontend.Variable) {
+	h.data = append(h.data, data...)
+}
+
+func (h *hasher) Sum() frontend.Variable {
+	state := frontend.Variable(0)
+	for _, e := range h.data {
+		state = h.p.Compress(state, e)
+	}
+	h.data = nil
+	return state
+}
+
+func (h *hasher) Reset() {
+	h.data = nil
+}
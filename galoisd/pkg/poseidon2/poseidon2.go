@@ -0,0 +1,172 @@
+// Poseidon2 over the BN254 scalar field, with a width of 2, used as a
+// cheaper in-circuit alternative to MiMC.
+//
+// The hash is a Merkle-Damgard construction over the compression function
+// Compress(left, right) = Permutation(left, right)[1] + right, starting from a
+// zero state, such that it's a drop-in replacement for the MiMC hasher.
+package poseidon2
+
+import (
+	"errors"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	Width         = 2
+	Degree        = 5
+	FullRounds    = 6
+	PartialRounds = 50
+
+	BlockSize = fr.Bytes
+
+	Seed = "Poseidon2-BN254[t=2,rF=6,rP=50,d=5]"
+)
+
+// Round constants, Width per full round and a single one per partial round
+var RoundConstants [FullRounds + PartialRounds][]fr.Element
+
+// The constants are derived as gnark-crypto's reference implementation does,
+// by iterating keccak256 over the keccak256 of the seed
+func init() {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(Seed))
+	rnd := hash.Sum(nil)
+	next := func() fr.Element {
+		hash.Reset()
+		hash.Write(rnd)
+		rnd = hash.Sum(nil)
+		var e fr.Element
+		e.SetBytes(rnd)
+		return e
+	}
+	for i := 0; i < FullRounds/2; i++ {
+		RoundConstants[i] = make([]fr.Element, Width)
+		for j := 0; j < Width; j++ {
+			RoundConstants[i][j] = next()
+		}
+	}
+	for i := FullRounds / 2; i < FullRounds/2+PartialRounds; i++ {
+		RoundConstants[i] = []fr.Element{next()}
+	}
+	for i := FullRounds/2 + PartialRounds; i < FullRounds+PartialRounds; i++ {
+		RoundConstants[i] = make([]fr.Element, Width)
+		for j := 0; j < Width; j++ {
+			RoundConstants[i][j] = next()
+		}
+	}
+}
+
+func sBox(x *fr.Element) {
+	var x2 fr.Element
+	x2.Square(x)
+	x2.Square(&x2)
+	x.Mul(x, &x2)
+}
+
+// circ(2, 1)
+func externalMatrix(state *[Width]fr.Element) {
+	var sum fr.Element
+	sum.Add(&state[0], &state[1])
+	state[0].Add(&state[0], &sum)
+	state[1].Add(&state[1], &sum)
+}
+
+// [[2, 1], [1, 3]]
+func internalMatrix(state *[Width]fr.Element) {
+	var sum fr.Element
+	sum.Add(&state[0], &state[1])
+	state[0].Add(&state[0], &sum)
+	state[1].Double(&state[1]).Add(&state[1], &sum)
+}
+
+func fullRound(state *[Width]fr.Element, constants []fr.Element) {
+	for j := 0; j < Width; j++ {
+		state[j].Add(&state[j], &constants[j])
+		sBox(&state[j])
+	}
+	externalMatrix(state)
+}
+
+func partialRound(state *[Width]fr.Element, constant *fr.Element) {
+	state[0].Add(&state[0], constant)
+	sBox(&state[0])
+	internalMatrix(state)
+}
+
+func Permutation(state *[Width]fr.Element) {
+	externalMatrix(state)
+	r := 0
+	for ; r < FullRounds/2; r++ {
+		fullRound(state, RoundConstants[r])
+	}
+	for ; r < FullRounds/2+PartialRounds; r++ {
+		partialRound(state, &RoundConstants[r][0])
+	}
+	for ; r < FullRounds+PartialRounds; r++ {
+		fullRound(state, RoundConstants[r])
+	}
+}
+
+func Compress(left fr.Element, right fr.Element) fr.Element {
+	state := [Width]fr.Element{left, right}
+	Permutation(&state)
+	var result fr.Element
+	result.Add(&state[1], &right)
+	return result
+}
+
+type digest struct {
+	data []fr.Element
+}
+
+// Native hasher, the input is expected to be a sequence of big endian
+// encoded field elements. Shorter inputs are left padded to a single element,
+// as the gnark-crypto MiMC hasher does.
+func NewHasher() hash.Hash {
+	return &digest{}
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	if len(p) > 0 && len(p) < BlockSize {
+		pp := make([]byte, BlockSize)
+		copy(pp[len(pp)-len(p):], p)
+		p = pp
+	}
+	if len(p)%BlockSize != 0 {
+		return 0, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize")
+	}
+	for start := 0; start < len(p); start += BlockSize {
+		var e fr.Element
+		err := e.SetBytesCanonical(p[start : start+BlockSize])
+		if err != nil {
+			return 0, err
+		}
+		d.data = append(d.data, e)
+	}
+	return len(p), nil
+}
+
+func (d *digest) Sum(b []byte) []byte {
+	var h fr.Element
+	for _, e := range d.data {
+		h = Compress(h, e)
+	}
+	d.data = nil
+	hash := h.Bytes()
+	return append(b, hash[:]...)
+}
+
+func (d *digest) Reset() {
+	d.data = nil
+}
+
+func (d *digest) Size() int {
+	return BlockSize
+}
+
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
@@ -0,0 +1,155 @@
+package poseidon2
+
+import (
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const MaxElements = 8
+
+type Hash struct {
+	Elements     [MaxElements]frontend.Variable
+	NbOfElements int
+	Expected     frontend.Variable
+}
+
+func (c *Hash) Define(api frontend.API) error {
+	h, err := NewPoseidon2(api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.Elements[:c.NbOfElements]...)
+	api.AssertIsEqual(h.Sum(), c.Expected)
+	return nil
+}
+
+func randomElements(rng *rand.Rand, size int) []fr.Element {
+	elements := make([]fr.Element, size)
+	for i := 0; i < size; i++ {
+		elements[i].SetUint64(rng.Uint64())
+		elements[i].Mul(&elements[i], &elements[i]).Mul(&elements[i], &elements[i])
+	}
+	return elements
+}
+
+func nativeHash(t *testing.T, elements []fr.Element) []byte {
+	h := NewHasher()
+	for _, e := range elements {
+		b := e.Bytes()
+		_, err := h.Write(b[:])
+		assert.NoError(t, err)
+	}
+	return h.Sum(nil)
+}
+
+func FuzzHash(f *testing.F) {
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		rng := rand.New(rand.NewSource(seed))
+		size := rng.Intn(MaxElements + 1)
+		elements := randomElements(rng, size)
+		var assignment [MaxElements]frontend.Variable
+		for i := 0; i < MaxElements; i++ {
+			assignment[i] = 0
+		}
+		for i := 0; i < size; i++ {
+			assignment[i] = elements[i]
+		}
+		expected := nativeHash(t, elements)
+		err := test.IsSolved(
+			&Hash{NbOfElements: size},
+			&Hash{
+				Elements:     assignment,
+				NbOfElements: size,
+				Expected:     expected,
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.NoError(t, err)
+		var wrong fr.Element
+		wrong.SetBytes(expected)
+		wrong.Add(&wrong, new(fr.Element).SetOne())
+		err = test.IsSolved(
+			&Hash{NbOfElements: size},
+			&Hash{
+				Elements:     assignment,
+				NbOfElements: size,
+				Expected:     wrong,
+			},
+			ecc.BN254.ScalarField(),
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestPermutationIsNotTrivial(t *testing.T) {
+	var zero fr.Element
+	state := [Width]fr.Element{}
+	Permutation(&state)
+	assert.False(t, state[0].Equal(&zero))
+	assert.False(t, state[1].Equal(&zero))
+	left := Compress(state[0], state[1])
+	right := Compress(state[1], state[0])
+	assert.False(t, left.Equal(&right))
+}
+
+func TestHasherRejectsNonCanonical(t *testing.T) {
+	h := NewHasher()
+	modulus := fr.Modulus().Bytes()
+	_, err := h.Write(modulus)
+	assert.Error(t, err)
+	_, err = h.Write(make([]byte, BlockSize+1))
+	assert.Error(t, err)
+}
+
+func TestHasherPadsShortInputs(t *testing.T) {
+	padded := make([]byte, BlockSize)
+	padded[BlockSize-1] = 1
+	h := NewHasher()
+	_, err := h.Write([]byte{1})
+	assert.NoError(t, err)
+	short := h.Sum(nil)
+	_, err = h.Write(padded)
+	assert.NoError(t, err)
+	assert.Equal(t, short, h.Sum(nil))
+}
+
+// Outputs of the reference implementation, gnark-crypto v0.19.0
+// ecc/bn254/fr/poseidon2 with its default parameters NewParameters(2, 6, 50)
+// and its NewMerkleDamgardHasher.
+func TestReferenceVectors(t *testing.T) {
+	element := func(s string) fr.Element {
+		var e fr.Element
+		_, err := e.SetString(s)
+		assert.NoError(t, err)
+		return e
+	}
+	assert.Equal(t, element("13408317191766118125459928988660904723912386643555460372160024256765517343823"), RoundConstants[0][0])
+	assert.Equal(t, element("17639106492467163824471711470904197146741191403150907096205381935655010152412"), RoundConstants[FullRounds/2][0])
+	assert.Equal(t, element("3910091859541134120391800995572544668894173656502109592309955225780361554898"), RoundConstants[FullRounds+PartialRounds-1][1])
+
+	state := [Width]fr.Element{}
+	Permutation(&state)
+	assert.Equal(t, [Width]fr.Element{
+		element("5760396826252723620130659814739767625264053726049147948400048226670170422969"),
+		element("18622970401557034651033185129330286139447343337105683528700775943440799145467"),
+	}, state)
+	state = [Width]fr.Element{fr.NewElement(1), fr.NewElement(2)}
+	Permutation(&state)
+	assert.Equal(t, [Width]fr.Element{
+		element("1197409642805673503548047715485910702500396810305169145705533208671921662963"),
+		element("1313337560616139085277676701856612540166622156368305732529371734734451176750"),
+	}, state)
+
+	compressed := Compress(fr.NewElement(1), fr.NewElement(2))
+	assert.Equal(t, "02e7529d93e1a7ae526147c2ee72588aee90e6a7c3e361de6daa6be045c6f530", hex.EncodeToString(compressed.Marshal()))
+	hash := nativeHash(t, []fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)})
+	assert.Equal(t, "2217b2961d96ae36f9f7701ccfaae198e00d7246805f514f8edb13f7f73699bc", hex.EncodeToString(hash))
+}
@@ -8,6 +8,9 @@ pub struct ClientState {
     /// submitted headers are valid for upgrade
     #[prost(uint64, tag = "2")]
     pub trusting_period: u64,
+    /// duration of the staking unbonding period
+    #[prost(uint64, tag = "3")]
+    pub unbonding_period: u64,
     /// defines how much new (untrusted) header's Time can drift into the future.
     #[prost(uint64, tag = "4")]
     pub max_clock_drift: u64,
@@ -19,6 +22,35 @@ pub struct ClientState {
     #[prost(message, optional, tag = "6")]
     pub latest_height:
         ::core::option::Option<super::super::super::super::super::ibc::core::client::v1::Height>,
+    /// Fingerprint of the groth16 verifying key the client trusts, the sha256 of
+    /// its compressed encoding. The key embedded in the client if empty
+    #[prost(bytes = "vec", tag = "7")]
+    pub verifying_key_fingerprint: ::prost::alloc::vec::Vec<u8>,
+    /// Path at which next upgraded client will be committed.
+    /// Each element corresponds to the key for a single CommitmentProof in the
+    /// chained proof. NOTE: ClientState must stored under
+    /// `{upgradePath}/{upgradeHeight}/clientState` ConsensusState must be stored
+    /// under `{upgradepath}/{upgradeHeight}/consensusState`, the standard upgrade
+    /// path of the cosmos-sdk if empty
+    #[prost(string, repeated, tag = "8")]
+    pub upgrade_path: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    /// Compressed encoding of the groth16 verifying key the client trusts, as
+    /// exported by galoisd. Only carried when creating, upgrading or substituting
+    /// the client: the key is then stored once in the client store under its
+    /// fingerprint, the stored client state only names it. The key embedded in
+    /// the client is never stored
+    #[prost(bytes = "vec", tag = "9")]
+    pub verifying_key: ::prost::alloc::vec::Vec<u8>,
+    /// Fingerprint of the PLONK verifying key the client trusts, the sha256 of
+    /// its encoding. PLONK proofs are rejected if empty
+    #[prost(bytes = "vec", tag = "10")]
+    pub plonk_verifying_key_fingerprint: ::prost::alloc::vec::Vec<u8>,
+    /// Encoding of the PLONK verifying key the client trusts, as exported by
+    /// galoisd for the plonk backend. Only carried when creating, upgrading or
+    /// substituting the client: the key is then stored once in the client store
+    /// under its fingerprint, the stored client state only names it
+    #[prost(bytes = "vec", tag = "11")]
+    pub plonk_verifying_key: ::prost::alloc::vec::Vec<u8>,
 }
 impl ::prost::Name for ClientState {
     const NAME: &'static str = "ClientState";
@@ -53,9 +85,9 @@ impl ::prost::Name for ConsensusState {
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct Misbehaviour {
     #[prost(message, optional, tag = "1")]
-    pub header_a: ::core::option::Option<Header>,
+    pub header_1: ::core::option::Option<Header>,
     #[prost(message, optional, tag = "2")]
-    pub header_b: ::core::option::Option<Header>,
+    pub header_2: ::core::option::Option<Header>,
 }
 impl ::prost::Name for Misbehaviour {
     const NAME: &'static str = "Misbehaviour";
@@ -93,8 +125,14 @@ pub struct Header {
     #[prost(message, optional, tag = "2")]
     pub trusted_height:
         ::core::option::Option<super::super::super::super::super::ibc::core::client::v1::Height>,
+    /// The EVM encoding of a groth16 proof (`evm_proof` of galoisd), or the raw
+    /// gnark encoding of a PLONK proof (`content` of galoisd). The Solidity
+    /// encoding of PLONK proofs can't be used, it omits the claimed value of the
+    /// linearized polynomial the gnark verifier checks
     #[prost(bytes = "vec", tag = "3")]
     pub zero_knowledge_proof: ::prost::alloc::vec::Vec<u8>,
+    #[prost(enumeration = "ProofType", tag = "4")]
+    pub proof_type: i32,
 }
 impl ::prost::Name for Header {
     const NAME: &'static str = "Header";
@@ -103,4 +141,46 @@ impl ::prost::Name for Header {
         ::prost::alloc::format!("union.ibc.lightclients.cometbls.v1.{}", Self::NAME)
     }
 }
+/// Headers updating the client at once, each trusting a height the client
+/// already has a consensus state for or the height of an earlier header.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct BatchHeader {
+    #[prost(message, repeated, tag = "1")]
+    pub headers: ::prost::alloc::vec::Vec<Header>,
+}
+impl ::prost::Name for BatchHeader {
+    const NAME: &'static str = "BatchHeader";
+    const PACKAGE: &'static str = "union.ibc.lightclients.cometbls.v1";
+    fn full_name() -> ::prost::alloc::string::String {
+        ::prost::alloc::format!("union.ibc.lightclients.cometbls.v1.{}", Self::NAME)
+    }
+}
+/// The proving system the zero knowledge proof of a header was produced with.
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
+#[repr(i32)]
+pub enum ProofType {
+    Groth16 = 0,
+    Plonk = 1,
+}
+impl ProofType {
+    /// String value of the enum field names used in the ProtoBuf definition.
+    ///
+    /// The values are not transformed in any way and thus are considered stable
+    /// (if the ProtoBuf definition does not change) and safe for programmatic use.
+    pub fn as_str_name(&self) -> &'static str {
+        match self {
+            ProofType::Groth16 => "PROOF_TYPE_GROTH16",
+            ProofType::Plonk => "PROOF_TYPE_PLONK",
+        }
+    }
+    /// Creates an enum from field names used in the ProtoBuf definition.
+    pub fn from_str_name(value: &str) -> ::core::option::Option<Self> {
+        match value {
+            "PROOF_TYPE_GROTH16" => Some(Self::Groth16),
+            "PROOF_TYPE_PLONK" => Some(Self::Plonk),
+            _ => None,
+        }
+    }
+}
 // @@protoc_insertion_point(module)
@@ -45,9 +45,15 @@ pub mod proto {
             Self {
                 chain_id: value.chain_id.to_string(),
                 trusting_period: value.trusting_period,
+                unbonding_period: 0,
                 max_clock_drift: value.max_clock_drift,
                 frozen_height: Some(value.frozen_height.into()),
                 latest_height: Some(value.latest_height.into()),
+                verifying_key_fingerprint: vec![],
+                upgrade_path: vec![],
+                verifying_key: vec![],
+                plonk_verifying_key_fingerprint: vec![],
+                plonk_verifying_key: vec![],
             }
         }
     }
@@ -25,6 +25,8 @@ pub mod proto {
                 signed_header: Some(value.signed_header.into()),
                 trusted_height: Some(value.trusted_height.into()),
                 zero_knowledge_proof: value.zero_knowledge_proof.into(),
+                proof_type: protos::union::ibc::lightclients::cometbls::v1::ProofType::Groth16
+                    .into(),
             }
         }
     }
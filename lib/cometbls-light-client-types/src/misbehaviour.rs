@@ -19,8 +19,8 @@ pub mod proto {
     impl From<Misbehaviour> for protos::union::ibc::lightclients::cometbls::v1::Misbehaviour {
         fn from(value: Misbehaviour) -> Self {
             Self {
-                header_a: Some(value.header_a.into()),
-                header_b: Some(value.header_b.into()),
+                header_1: Some(value.header_a.into()),
+                header_2: Some(value.header_b.into()),
             }
         }
     }
@@ -40,8 +40,8 @@ pub mod proto {
             value: protos::union::ibc::lightclients::cometbls::v1::Misbehaviour,
         ) -> Result<Self, Self::Error> {
             Ok(Self {
-                header_a: required!(value.header_a)?.try_into()?,
-                header_b: required!(value.header_b)?.try_into()?,
+                header_a: required!(value.header_1)?.try_into()?,
+                header_b: required!(value.header_2)?.try_into()?,
             })
         }
     }
@@ -5,83 +5,133 @@ use std::ffi::{c_char, c_int};
 
 pub const CONTRIBUTION_SIZE: usize = 306032532;
 
+/// Opaque handle of an output held by galois.
+type GaloisBuffer = usize;
+
+const GALOIS_OK: c_int = 0;
+
 #[link(name = "galois")]
 extern "C" {
     fn Phase2Contribute(
         phase2_payload_raw: *const c_char,
-        phase2_contrib_raw: *mut c_char,
-        len: c_int,
-    ) -> bool;
+        phase2_payload_len: usize,
+        phase2_contrib: *mut GaloisBuffer,
+        error: *mut GaloisBuffer,
+    ) -> c_int;
 
     fn Phase2Verify(
         phase2_previous_raw: *const c_char,
+        phase2_previous_len: usize,
         phase2_contrib_raw: *const c_char,
-        len: c_int,
-    ) -> bool;
+        phase2_contrib_len: usize,
+        error: *mut GaloisBuffer,
+    ) -> c_int;
+
+    fn GaloisBufferLen(buffer: GaloisBuffer, len: *mut usize, error: *mut GaloisBuffer) -> c_int;
+
+    fn GaloisBufferCopy(
+        buffer: GaloisBuffer,
+        out: *mut c_char,
+        out_len: usize,
+        error: *mut GaloisBuffer,
+    ) -> c_int;
+
+    fn GaloisBufferFree(buffer: GaloisBuffer, error: *mut GaloisBuffer) -> c_int;
 }
 
-#[derive(thiserror::Error, Debug, Copy, Clone)]
+#[derive(thiserror::Error, Debug, Clone)]
 pub enum Phase2ContributionError {
-    #[error("Failed to convert initial payload size, based af is you reached this.")]
-    FailedToConvertPayloadSize,
-    #[error("Looks like you spent time contributing for no reason because it failed.")]
-    FailedToContribute,
+    #[error(
+        "Looks like you spent time contributing for no reason because it failed: {1} (code {0})."
+    )]
+    FailedToContribute(c_int, String),
 }
 
-#[derive(thiserror::Error, Debug, Copy, Clone)]
+#[derive(thiserror::Error, Debug, Clone)]
 pub enum Phase2VerificationError {
-    #[error("Failed to convert contribution payload size, based af is you reached this.")]
-    FailedToConvertPayloadSize,
-    #[error("1 BTC = 1 BTC, what are you trying to achieve?")]
-    InconsistentPayloadSize,
-    #[error("Cheating is great, but not allowed. You may lose your slot if the coordinator chose to :'(.")]
-    Phase2VerificationFailed,
+    #[error("Cheating is great, but not allowed. You may lose your slot if the coordinator chose to :'(. {1} (code {0}).")]
+    Phase2VerificationFailed(c_int, String),
+}
+
+/// Message of a galois error, returned by the call that failed such that
+/// concurrent calls don't overwrite each other's.
+fn take_error(error: GaloisBuffer) -> String {
+    if error == 0 {
+        return String::new();
+    }
+    match take_buffer(error) {
+        Ok(message) => String::from_utf8_lossy(&message).into_owned(),
+        Err((_, message)) => message,
+    }
+}
+
+/// Copy the buffer out of galois then free it.
+fn take_buffer(buffer: GaloisBuffer) -> Result<Vec<u8>, (c_int, String)> {
+    unsafe {
+        let mut len = 0;
+        let mut error: GaloisBuffer = 0;
+        let code = GaloisBufferLen(buffer, &mut len, &mut error);
+        if code != GALOIS_OK {
+            GaloisBufferFree(buffer, std::ptr::null_mut());
+            return Err((code, take_error(error)));
+        }
+        let mut content = vec![0u8; len];
+        let code = GaloisBufferCopy(
+            buffer,
+            content.as_mut_ptr() as *mut _,
+            content.len(),
+            &mut error,
+        );
+        GaloisBufferFree(buffer, std::ptr::null_mut());
+        if code != GALOIS_OK {
+            return Err((code, take_error(error)));
+        }
+        Ok(content)
+    }
 }
 
 pub fn phase2_contribute(phase2_payload: &[u8]) -> Result<Vec<u8>, Phase2ContributionError> {
-    let payload_len = phase2_payload
-        .len()
-        .try_into()
-        .map_err(|_| Phase2ContributionError::FailedToConvertPayloadSize)?;
-    let mut phase2_contrib_raw = vec![0u8; phase2_payload.len()];
-    let result = unsafe {
+    let mut phase2_contrib: GaloisBuffer = 0;
+    let mut error: GaloisBuffer = 0;
+    let code = unsafe {
         Phase2Contribute(
             phase2_payload.as_ptr() as *const _,
-            phase2_contrib_raw.as_mut_ptr() as *mut _,
-            payload_len,
+            phase2_payload.len(),
+            &mut phase2_contrib,
+            &mut error,
         )
     };
-    if result {
-        Ok(phase2_contrib_raw)
-    } else {
-        Err(Phase2ContributionError::FailedToContribute)
+    if code != GALOIS_OK {
+        return Err(Phase2ContributionError::FailedToContribute(
+            code,
+            take_error(error),
+        ));
     }
+    take_buffer(phase2_contrib)
+        .map_err(|(code, message)| Phase2ContributionError::FailedToContribute(code, message))
 }
 
 pub fn phase2_verify(
     phase2_payload: &[u8],
     phase2_contrib: &[u8],
 ) -> Result<(), Phase2VerificationError> {
-    let payload_len = phase2_payload
-        .len()
-        .try_into()
-        .map_err(|_| Phase2VerificationError::FailedToConvertPayloadSize)?;
-    if phase2_contrib.len() != phase2_payload.len() {
-        Err(Phase2VerificationError::InconsistentPayloadSize)
-    } else {
-        let result = unsafe {
-            Phase2Verify(
-                phase2_payload.as_ptr() as *const _,
-                phase2_contrib.as_ptr() as *mut _,
-                payload_len,
-            )
-        };
-        if result {
-            Ok(())
-        } else {
-            Err(Phase2VerificationError::Phase2VerificationFailed)
-        }
+    let mut error: GaloisBuffer = 0;
+    let code = unsafe {
+        Phase2Verify(
+            phase2_payload.as_ptr() as *const _,
+            phase2_payload.len(),
+            phase2_contrib.as_ptr() as *const _,
+            phase2_contrib.len(),
+            &mut error,
+        )
+    };
+    if code != GALOIS_OK {
+        return Err(Phase2VerificationError::Phase2VerificationFailed(
+            code,
+            take_error(error),
+        ));
     }
+    Ok(())
 }
 
 pub fn signed_message(
@@ -180,8 +180,9 @@ _: {
                 cp --no-preserve=mode -RL ${generate-uniond-proto}/openapi_combined.yaml ./docs/static/openapi.yml
                 cp --no-preserve=mode -RL ${generate-uniond-proto}/union/x/* ./x/
                 cp --no-preserve=mode -RL ${generate-uniond-proto}/union/staking/* ./x/staking
+                cp --no-preserve=mode -RL ${generate-uniond-proto}/union/ibc/lightclients/cometbls/* ../11-cometbls
 
-                echo "Done! Generated .pb.go files are added to ./uniond/x"
+                echo "Done! Generated .pb.go files are added to ./uniond/x and ./11-cometbls"
               '';
             }
           );